@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -323,10 +324,16 @@ func (ci CI) TestE2E() error {
 		return err
 	}
 
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return fmt.Errorf("error when loading declarative rule catalogs: %v", err)
+	}
+	defer writeSelectionReport(rctx, artifactDir)
+
 	// Eventually we'll introduce mage rules for all repositories, so this condition won't be needed anymore
 	if pr.RepoName == "e2e-tests" || pr.RepoName == "integration-service" ||
 		pr.RepoName == "release-service" || pr.RepoName == "image-controller" ||
-		pr.RepoName == "build-service" || pr.RepoName == "release-service-catalog" {
+		pr.RepoName == "build-service" || pr.RepoName == "release-service-catalog" ||
+		engine.MageEngine.HasCatalog("ci", pr.RepoName) {
 		return engine.MageEngine.RunRulesOfCategory("ci", rctx)
 	}
 
@@ -379,6 +386,9 @@ func RunE2ETests() error {
 	case "infra-deployments":
 		return engine.MageEngine.RunRules(rctx, "tests", "infra-deployments")
 	default:
+		if engine.MageEngine.HasCatalog("tests", rctx.RepoName) {
+			return engine.MageEngine.RunRules(rctx, "tests", rctx.RepoName)
+		}
 		labelFilter := utils.GetEnv("E2E_TEST_SUITE_LABEL", "!upgrade-create && !upgrade-verify && !upgrade-cleanup && !release-pipelines")
 		return runTests(labelFilter, "e2e-report.xml")
 	}
@@ -1330,7 +1340,12 @@ func (Local) PreviewTestSelection() error {
 	rctx.DiffFiles = files
 	rctx.DryRun = true
 
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
+
 	err = engine.MageEngine.RunRules(rctx, "tests", "e2e-repo")
+	writeSelectionReport(rctx, artifactDir)
 
 	if err != nil {
 		return err
@@ -1339,6 +1354,67 @@ func (Local) PreviewTestSelection() error {
 	return nil
 }
 
+// SimulateRules evaluates a rules engine category/catalog without executing anything and prints the side effects
+// the matched rules would have performed. Env vars: REPO_NAME (required), RULES_CATEGORY (defaults to "ci"),
+// RULES_CATALOG (optional), JOB_NAME, JOB_TYPE, PR_REMOTE_NAME, PR_BRANCH_NAME, PR_COMMIT_SHA (optional)
+func (Local) SimulateRules() error {
+	rctx := rulesengine.NewRuleCtx()
+	rctx.Simulate = true
+	if rctx.RepoName = os.Getenv("REPO_NAME"); rctx.RepoName == "" {
+		return fmt.Errorf("env var REPO_NAME is not set")
+	}
+	rctx.JobName = os.Getenv("JOB_NAME")
+	rctx.JobType = os.Getenv("JOB_TYPE")
+	rctx.PrRemoteName = os.Getenv("PR_REMOTE_NAME")
+	rctx.PrBranchName = os.Getenv("PR_BRANCH_NAME")
+	rctx.PrCommitSha = os.Getenv("PR_COMMIT_SHA")
+
+	files, err := utils.GetChangedFiles(rctx.RepoName)
+	if err != nil {
+		return err
+	}
+	rctx.DiffFiles = files
+
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
+
+	args := []string{utils.GetEnv("RULES_CATEGORY", "ci")}
+	if catalog := os.Getenv("RULES_CATALOG"); catalog != "" {
+		args = append(args, catalog)
+	}
+	err = engine.MageEngine.RunRules(rctx, args...)
+	writeSelectionReport(rctx, artifactDir)
+	if rctx.Plan != nil {
+		klog.Infof("Simulation plan:\n%s", rctx.Plan.String())
+		if writeErr := os.WriteFile(filepath.Join(artifactDir, "simulation-plan.txt"), []byte(rctx.Plan.String()), 0644); writeErr != nil {
+			klog.Error(writeErr)
+		}
+	}
+	return err
+}
+
+// writeSelectionReport logs the rules engine evaluation trace and stores it as
+// test-selection-report.json and test-selection-report.txt into dir
+func writeSelectionReport(rctx *rulesengine.RuleCtx, dir string) {
+	if rctx.SelectionReport == nil {
+		return
+	}
+	klog.Infof("Test selection report:\n%s", rctx.SelectionReport.Tree())
+	if err := rctx.SelectionReport.WriteFiles(dir); err != nil {
+		klog.Error(err)
+	}
+}
+
+// ValidateRuleCatalogs parses the declarative rule catalogs from RULES_CATALOG_DIR and reports any schema error
+func (Local) ValidateRuleCatalogs() error {
+	if err := engine.LoadDeclarativeCatalogs(); err != nil {
+		return err
+	}
+	klog.Infof("Rule catalogs are valid. Registered categories: %s", engine.MageEngine.ListCatagoriesOfCatalogs())
+	return nil
+}
+
 func (Local) RunRuleDemo() error {
 	rctx := rulesengine.NewRuleCtx()
 	files, err := utils.GetChangedFiles("e2e-tests")
@@ -1376,5 +1452,7 @@ func (Local) RunInfraDeploymentsRuleDemo() error {
 	rctx.DiffFiles = files
 
 	// filtering the rule engine to load only infra-deployments rule catalog within the test category
-	return engine.MageEngine.RunRules(rctx, "tests", "infra-deployments")
+	err = engine.MageEngine.RunRules(rctx, "tests", "infra-deployments")
+	writeSelectionReport(rctx, artifactDir)
+	return err
 }
@@ -0,0 +1,803 @@
+package rulesengine
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"gopkg.in/yaml.v3"
+	"k8s.io/klog"
+)
+
+// RunTestsActionName is the name of the registered action the declarative
+// `runTests` action resolves to. The repos package registers the ginkgo runner
+// under this name.
+const RunTestsActionName = "run-tests"
+
+var (
+	registeredConditionals = map[string]Conditional{}
+	registeredActions      = map[string]Action{}
+)
+
+// RegisterConditional makes a Go implemented conditional available to
+// declarative catalogs through the `condition: <name>` clause.
+func RegisterConditional(name string, c Conditional) {
+	registeredConditionals[name] = c
+}
+
+// RegisterAction makes a Go implemented action available to
+// declarative catalogs through the `action: <name>` clause.
+func RegisterAction(name string, a Action) {
+	registeredActions[name] = a
+}
+
+// LoadedCatalog is a rule catalog parsed from a declarative catalog file
+// together with the engine category and name it should be registered under.
+type LoadedCatalog struct {
+	Category string
+	Name     string
+	Source   string
+	Rules    RuleCatalog
+}
+
+// LoadCatalogFile parses the declarative catalog file at path and registers it in the engine.
+func (e *RuleEngine) LoadCatalogFile(path string) error {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read rule catalog %s: %+v", path, err)
+	}
+
+	loaded, err := ParseCatalog(path, data)
+	if err != nil {
+		return err
+	}
+
+	return e.RegisterCatalog(loaded)
+}
+
+// LoadCatalogDir loads every *.yaml, *.yml and *.json catalog file found in dir, in lexical order.
+func (e *RuleEngine) LoadCatalogDir(dir string) error {
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read rule catalog directory %s: %+v", dir, err)
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filepath.Join(dir, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		if err := e.LoadCatalogFile(file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RegisterCatalog adds a loaded catalog to the engine. Declarative catalogs are
+// not allowed to override catalogs that are already registered.
+func (e *RuleEngine) RegisterCatalog(loaded *LoadedCatalog) error {
+
+	if *e == nil {
+		*e = RuleEngine{}
+	}
+	if _, ok := (*e)[loaded.Category]; !ok {
+		(*e)[loaded.Category] = map[string]RuleCatalog{}
+	}
+	if _, ok := (*e)[loaded.Category][loaded.Name]; ok {
+		return fmt.Errorf("%s: catalog %s is already registered in category %s", loaded.Source, loaded.Name, loaded.Category)
+	}
+
+	(*e)[loaded.Category][loaded.Name] = loaded.Rules
+	klog.Infof("Registered the catalog, %s, from %s under category, %s", loaded.Name, loaded.Source, loaded.Category)
+
+	return nil
+}
+
+// HasCatalog reports whether a catalog with the given name is registered under category.
+func (e *RuleEngine) HasCatalog(cat, name string) bool {
+
+	_, ok := (*e)[cat][name]
+	return ok
+}
+
+// ParseCatalog parses a declarative rule catalog. The data can be either YAML or JSON,
+// source is only used to prefix the errors so they point to the offending file and line.
+func ParseCatalog(source string, data []byte) (*LoadedCatalog, error) {
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %+v", source, err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%s: rule catalog is empty", source)
+	}
+
+	p := &catalogParser{source: source, rules: map[string]*Rule{}}
+	return p.parseCatalog(doc.Content[0])
+}
+
+type catalogParser struct {
+	source string
+	rules  map[string]*Rule
+}
+
+func (p *catalogParser) errorf(n *yaml.Node, format string, args ...any) error {
+
+	return fmt.Errorf("%s:%d:%d: %s", p.source, n.Line, n.Column, fmt.Sprintf(format, args...))
+}
+
+// fields validates n is a mapping with only the allowed keys and returns the values by key.
+func (p *catalogParser) fields(n *yaml.Node, what string, allowed ...string) (map[string]*yaml.Node, error) {
+
+	if n.Kind != yaml.MappingNode {
+		return nil, p.errorf(n, "%s must be a mapping", what)
+	}
+
+	values := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if !contains(allowed, key.Value) {
+			return nil, p.errorf(key, "unknown field %q in %s, expected one of: %s", key.Value, what, strings.Join(allowed, ", "))
+		}
+		if _, ok := values[key.Value]; ok {
+			return nil, p.errorf(key, "duplicate field %q in %s", key.Value, what)
+		}
+		values[key.Value] = value
+	}
+
+	return values, nil
+}
+
+// singleField validates n is a mapping with exactly one of the allowed keys.
+func (p *catalogParser) singleField(n *yaml.Node, what string, allowed ...string) (string, *yaml.Node, error) {
+
+	values, err := p.fields(n, what, allowed...)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(values) != 1 {
+		return "", nil, p.errorf(n, "%s must declare exactly one of: %s", what, strings.Join(allowed, ", "))
+	}
+	for k, v := range values {
+		return k, v, nil
+	}
+
+	return "", nil, nil
+}
+
+func (p *catalogParser) str(n *yaml.Node, what string) (string, error) {
+
+	if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+		return "", p.errorf(n, "%s must be a string", what)
+	}
+	return n.Value, nil
+}
+
+func (p *catalogParser) requiredStr(values map[string]*yaml.Node, parent *yaml.Node, key, what string) (string, error) {
+
+	n, ok := values[key]
+	if !ok {
+		return "", p.errorf(parent, "%s is missing the required field %q", what, key)
+	}
+	s, err := p.str(n, fmt.Sprintf("%s %s", what, key))
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(s) == "" {
+		return "", p.errorf(n, "%s %s must not be empty", what, key)
+	}
+	return s, nil
+}
+
+func (p *catalogParser) boolean(n *yaml.Node, what string) (bool, error) {
+
+	var b bool
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!bool" {
+		return false, p.errorf(n, "%s must be a boolean", what)
+	}
+	if err := n.Decode(&b); err != nil {
+		return false, p.errorf(n, "%s must be a boolean", what)
+	}
+	return b, nil
+}
+
+func (p *catalogParser) integer(n *yaml.Node, what string) (int, error) {
+
+	var i int
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!int" {
+		return 0, p.errorf(n, "%s must be an integer", what)
+	}
+	if err := n.Decode(&i); err != nil {
+		return 0, p.errorf(n, "%s must be an integer", what)
+	}
+	return i, nil
+}
+
+func (p *catalogParser) strList(n *yaml.Node, what string) ([]string, error) {
+
+	if n.Kind == yaml.ScalarNode {
+		s, err := p.str(n, what)
+		return []string{s}, err
+	}
+	if n.Kind != yaml.SequenceNode {
+		return nil, p.errorf(n, "%s must be a string or a list of strings", what)
+	}
+	var list []string
+	for _, item := range n.Content {
+		s, err := p.str(item, what)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+func (p *catalogParser) seq(n *yaml.Node, what string) ([]*yaml.Node, error) {
+
+	if n.Kind != yaml.SequenceNode {
+		return nil, p.errorf(n, "%s must be a list", what)
+	}
+	if len(n.Content) == 0 {
+		return nil, p.errorf(n, "%s must not be empty", what)
+	}
+	return n.Content, nil
+}
+
+func (p *catalogParser) parseCatalog(root *yaml.Node) (*LoadedCatalog, error) {
+
+	values, err := p.fields(root, "catalog", "category", "catalog", "rules", "subRules")
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := &LoadedCatalog{Source: p.source}
+	if loaded.Category, err = p.requiredStr(values, root, "category", "catalog"); err != nil {
+		return nil, err
+	}
+	if loaded.Name, err = p.requiredStr(values, root, "catalog", "catalog"); err != nil {
+		return nil, err
+	}
+	rulesNode, ok := values["rules"]
+	if !ok {
+		return nil, p.errorf(root, "catalog is missing the required field %q", "rules")
+	}
+	ruleNodes, err := p.seq(rulesNode, "rules")
+	if err != nil {
+		return nil, err
+	}
+	var subRuleNodes []*yaml.Node
+	if n, ok := values["subRules"]; ok {
+		if subRuleNodes, err = p.seq(n, "subRules"); err != nil {
+			return nil, err
+		}
+	}
+
+	// Register every rule name first so rules can reference each
+	// other in their conditions regardless of declaration order.
+	all := append(append([]*yaml.Node{}, subRuleNodes...), ruleNodes...)
+	ruleValues := make([]map[string]*yaml.Node, len(all))
+	for i, n := range all {
+		if ruleValues[i], err = p.fields(n, "rule", "name", "description", "priority", "mode", "when", "then"); err != nil {
+			return nil, err
+		}
+		name, err := p.requiredStr(ruleValues[i], n, "name", "rule")
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := p.rules[name]; ok {
+			return nil, p.errorf(ruleValues[i]["name"], "rule %q is declared more than once", name)
+		}
+		p.rules[name] = &Rule{Name: name}
+	}
+
+	for i, n := range all {
+		rule := p.rules[ruleValues[i]["name"].Value]
+		if err := p.parseRule(n, ruleValues[i], rule); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := len(subRuleNodes); i < len(all); i++ {
+		loaded.Rules = append(loaded.Rules, *p.rules[ruleValues[i]["name"].Value])
+	}
+
+	return loaded, nil
+}
+
+func (p *catalogParser) parseRule(n *yaml.Node, values map[string]*yaml.Node, rule *Rule) error {
+
+	var err error
+	what := fmt.Sprintf("rule %q", rule.Name)
+
+	if d, ok := values["description"]; ok {
+		if rule.Description, err = p.str(d, what+" description"); err != nil {
+			return err
+		}
+	}
+
+	if pn, ok := values["priority"]; ok {
+		if rule.Priority, err = p.integer(pn, what+" priority"); err != nil {
+			return err
+		}
+	}
+	if mn, ok := values["mode"]; ok {
+		mode, err := p.str(mn, what+" mode")
+		if err != nil {
+			return err
+		}
+		switch RuleMode(mode) {
+		case RuleModeTerminal, RuleModeAdditive:
+			rule.Mode = RuleMode(mode)
+		default:
+			return p.errorf(mn, "%s mode must be one of %q, %q", what, RuleModeTerminal, RuleModeAdditive)
+		}
+	}
+
+	when, ok := values["when"]
+	if !ok {
+		return p.errorf(n, "%s is missing the required field %q", what, "when")
+	}
+	if rule.Condition, err = p.parseCondition(when); err != nil {
+		return err
+	}
+
+	if then, ok := values["then"]; ok {
+		actionNodes, err := p.seq(then, what+" then")
+		if err != nil {
+			return err
+		}
+		for _, an := range actionNodes {
+			action, err := p.parseAction(an)
+			if err != nil {
+				return err
+			}
+			rule.Actions = append(rule.Actions, action)
+		}
+	}
+
+	return nil
+}
+
+func (p *catalogParser) parseCondition(n *yaml.Node) (Conditional, error) {
+
+	key, value, err := p.singleField(n, "condition",
+		"all", "any", "none", "repoName", "jobType", "jobName", "eventType", "paired", "diffFiles", "noDiffFiles", "rule", "condition")
+	if err != nil {
+		return nil, err
+	}
+
+	switch key {
+	case "all", "any", "none":
+		items, err := p.seq(value, key)
+		if err != nil {
+			return nil, err
+		}
+		var conds []Conditional
+		for _, item := range items {
+			c, err := p.parseCondition(item)
+			if err != nil {
+				return nil, err
+			}
+			conds = append(conds, c)
+		}
+		switch key {
+		case "all":
+			return All(conds), nil
+		case "any":
+			return Any(conds), nil
+		default:
+			return None(conds), nil
+		}
+	case "repoName", "jobType", "eventType":
+		expected, err := p.str(value, key)
+		if err != nil {
+			return nil, err
+		}
+		return Describe(fmt.Sprintf("%s == %q", key, expected), ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			switch key {
+			case "repoName":
+				return rctx.RepoName == expected, nil
+			case "jobType":
+				return rctx.JobType == expected, nil
+			default:
+				return rctx.TektonEventType == expected, nil
+			}
+		})), nil
+	case "jobName":
+		substr, err := p.str(value, key)
+		if err != nil {
+			return nil, err
+		}
+		return Describe(fmt.Sprintf("jobName contains %q", substr), ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return strings.Contains(rctx.JobName, substr), nil
+		})), nil
+	case "paired":
+		expected, err := p.boolean(value, key)
+		if err != nil {
+			return nil, err
+		}
+		return Describe(fmt.Sprintf("paired == %t", expected), ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return rctx.IsPaired == expected, nil
+		})), nil
+	case "diffFiles":
+		filter, err := p.parseFileFilter(value, key)
+		if err != nil {
+			return nil, err
+		}
+		return Describe(fmt.Sprintf("diffFiles %s", filter), ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return len(filter.apply(rctx.DiffFiles)) != 0, nil
+		})), nil
+	case "noDiffFiles":
+		expected, err := p.boolean(value, key)
+		if err != nil {
+			return nil, err
+		}
+		return Describe(fmt.Sprintf("noDiffFiles == %t", expected), ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return (len(rctx.DiffFiles) == 0) == expected, nil
+		})), nil
+	case "rule":
+		name, err := p.str(value, key)
+		if err != nil {
+			return nil, err
+		}
+		rule, ok := p.rules[name]
+		if !ok {
+			return nil, p.errorf(value, "rule %q referenced in condition is not declared in this catalog", name)
+		}
+		return rule, nil
+	default:
+		name, err := p.str(value, key)
+		if err != nil {
+			return nil, err
+		}
+		c, ok := registeredConditionals[name]
+		if !ok {
+			return nil, p.errorf(value, "conditional %q is not registered", name)
+		}
+		if _, isRule := c.(*Rule); isRule {
+			return c, nil
+		}
+		return Describe(name, c), nil
+	}
+}
+
+func (p *catalogParser) parseAction(n *yaml.Node) (Action, error) {
+
+	key, value, err := p.singleField(n, "action", "addFocusFiles", "addLabelFilter", "set", "runTests", "action")
+	if err != nil {
+		return nil, err
+	}
+
+	switch key {
+	case "addFocusFiles":
+		return p.parseAddFocusFiles(value)
+	case "addLabelFilter":
+		label, err := p.str(value, key)
+		if err != nil {
+			return nil, err
+		}
+		return ActionFunc(func(rctx *RuleCtx) error {
+			AddLabelFilter(rctx, label)
+			return nil
+		}), nil
+	case "set":
+		return p.parseSet(value)
+	case "runTests":
+		run, err := p.boolean(value, key)
+		if err != nil {
+			return nil, err
+		}
+		if !run {
+			return nil, p.errorf(value, "runTests can only be set to true")
+		}
+		a, ok := registeredActions[RunTestsActionName]
+		if !ok {
+			return nil, p.errorf(value, "action %q is not registered", RunTestsActionName)
+		}
+		return a, nil
+	default:
+		name, err := p.str(value, key)
+		if err != nil {
+			return nil, err
+		}
+		a, ok := registeredActions[name]
+		if !ok {
+			return nil, p.errorf(value, "action %q is not registered", name)
+		}
+		return a, nil
+	}
+}
+
+func (p *catalogParser) parseAddFocusFiles(n *yaml.Node) (Action, error) {
+
+	values, err := p.fields(n, "addFocusFiles", "fromDiffFiles", "fromRepoGlob", "files", "exclude")
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, p.errorf(n, "addFocusFiles must declare at least one of: fromDiffFiles, fromRepoGlob, files")
+	}
+
+	var fromDiff *fileFilter
+	var repoGlobs, files, exclude []string
+	if v, ok := values["fromDiffFiles"]; ok {
+		if fromDiff, err = p.parseFileFilter(v, "fromDiffFiles"); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := values["fromRepoGlob"]; ok {
+		if repoGlobs, err = p.strList(v, "fromRepoGlob"); err != nil {
+			return nil, err
+		}
+		for _, g := range repoGlobs {
+			if !doublestar.ValidatePattern(g) {
+				return nil, p.errorf(v, "invalid glob %q", g)
+			}
+		}
+	}
+	if v, ok := values["files"]; ok {
+		if files, err = p.strList(v, "files"); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := values["exclude"]; ok {
+		if exclude, err = p.strList(v, "exclude"); err != nil {
+			return nil, err
+		}
+	}
+
+	return ActionFunc(func(rctx *RuleCtx) error {
+
+		var candidates []string
+		if fromDiff != nil {
+			for _, file := range fromDiff.apply(rctx.DiffFiles) {
+				candidates = append(candidates, file.Name)
+			}
+		}
+		for _, g := range repoGlobs {
+			matched, err := doublestar.FilepathGlob(g)
+			if err != nil {
+				return err
+			}
+			candidates = append(candidates, matched...)
+		}
+		candidates = append(candidates, files...)
+
+		for _, c := range candidates {
+			if matchesAny(exclude, c) {
+				continue
+			}
+			rctx.FocusFiles = dedupeAppend(rctx.FocusFiles, c)
+		}
+		return nil
+	}), nil
+}
+
+func (p *catalogParser) parseSet(n *yaml.Node) (Action, error) {
+
+	values, err := p.fields(n, "set", "labelFilter", "timeout", "requiresMultiPlatformTests",
+		"requiresSprayProxyRegistering", "componentEnvVarPrefix", "componentImageTag")
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, p.errorf(n, "set must declare at least one field")
+	}
+
+	var setters []func(rctx *RuleCtx)
+	for key, v := range values {
+		switch key {
+		case "labelFilter", "componentEnvVarPrefix", "componentImageTag":
+			s, err := p.str(v, key)
+			if err != nil {
+				return nil, err
+			}
+			k := key
+			setters = append(setters, func(rctx *RuleCtx) {
+				switch k {
+				case "labelFilter":
+					rctx.LabelFilter = s
+				case "componentEnvVarPrefix":
+					rctx.ComponentEnvVarPrefix = s
+				default:
+					rctx.ComponentImageTag = s
+				}
+			})
+		case "timeout":
+			s, err := p.str(v, key)
+			if err != nil {
+				return nil, err
+			}
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return nil, p.errorf(v, "invalid timeout %q: %+v", s, err)
+			}
+			setters = append(setters, func(rctx *RuleCtx) { rctx.Timeout = d })
+		default:
+			b, err := p.boolean(v, key)
+			if err != nil {
+				return nil, err
+			}
+			if key == "requiresMultiPlatformTests" {
+				setters = append(setters, func(rctx *RuleCtx) { rctx.RequiresMultiPlatformTests = b })
+			} else {
+				setters = append(setters, func(rctx *RuleCtx) { rctx.RequiresSprayProxyRegistering = b })
+			}
+		}
+	}
+
+	return ActionFunc(func(rctx *RuleCtx) error {
+		for _, set := range setters {
+			set(rctx)
+		}
+		return nil
+	}), nil
+}
+
+// fileFilter selects changed files by glob, substring and git status.
+// All the declared criteria have to match for a file to be selected.
+type fileFilter struct {
+	globs    []string
+	contains string
+	status   string
+	exclude  []string
+}
+
+func (p *catalogParser) parseFileFilter(n *yaml.Node, what string) (*fileFilter, error) {
+
+	values, err := p.fields(n, what, "glob", "contains", "status", "exclude")
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, p.errorf(n, "%s must declare at least one of: glob, contains, status", what)
+	}
+
+	f := &fileFilter{}
+	if v, ok := values["glob"]; ok {
+		if f.globs, err = p.strList(v, what+" glob"); err != nil {
+			return nil, err
+		}
+		for _, g := range f.globs {
+			if !doublestar.ValidatePattern(g) {
+				return nil, p.errorf(v, "invalid glob %q", g)
+			}
+		}
+	}
+	if v, ok := values["contains"]; ok {
+		if f.contains, err = p.str(v, what+" contains"); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := values["status"]; ok {
+		if f.status, err = p.str(v, what+" status"); err != nil {
+			return nil, err
+		}
+	}
+	if v, ok := values["exclude"]; ok {
+		if f.exclude, err = p.strList(v, what+" exclude"); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+func (f *fileFilter) apply(files Files) Files {
+
+	var selected Files
+	for _, file := range files {
+		if len(f.globs) != 0 && !matchesAny(f.globs, file.Name) {
+			continue
+		}
+		if f.contains != "" && !strings.Contains(file.Name, f.contains) {
+			continue
+		}
+		if f.status != "" && !strings.Contains(file.Status, strings.ToUpper(f.status)) {
+			continue
+		}
+		if matchesAny(f.exclude, file.Name) {
+			continue
+		}
+		selected = append(selected, file)
+	}
+	traceMatchedFiles(selected)
+
+	return selected
+}
+
+func (f *fileFilter) String() string {
+
+	var criteria []string
+	if len(f.globs) != 0 {
+		criteria = append(criteria, fmt.Sprintf("glob=%s", strings.Join(f.globs, "|")))
+	}
+	if f.contains != "" {
+		criteria = append(criteria, fmt.Sprintf("contains=%s", f.contains))
+	}
+	if f.status != "" {
+		criteria = append(criteria, fmt.Sprintf("status=%s", f.status))
+	}
+	if len(f.exclude) != 0 {
+		criteria = append(criteria, fmt.Sprintf("exclude=%s", strings.Join(f.exclude, "|")))
+	}
+
+	return strings.Join(criteria, " ")
+}
+
+func matchesAny(globs []string, name string) bool {
+
+	for _, g := range globs {
+		if matched, _ := doublestar.PathMatch(g, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// AddLabelFilter ensures the label is one of the terms of the LabelFilter of rctx, see HasLabelFilterTerm.
+func AddLabelFilter(rctx *RuleCtx, label string) {
+
+	if HasLabelFilterTerm(rctx.LabelFilter, label) {
+		return
+	}
+	if rctx.LabelFilter == "" {
+		rctx.LabelFilter = label
+		return
+	}
+	rctx.LabelFilter = fmt.Sprintf("%s,%s", rctx.LabelFilter, label)
+}
+
+// HasLabelFilterTerm tells whether the label is one of the terms of the filter joined by "||", "&&" or ","
+// (parentheses aside), so "build" is not mistaken for "build-templates" and "upgrade" for "!upgrade-create".
+func HasLabelFilterTerm(filter, label string) bool {
+
+	return contains(labelFilterTerms(filter), label)
+}
+
+// labelFilterTerms returns the terms of the filter joined by "||", "&&" or "," (parentheses aside).
+func labelFilterTerms(filter string) []string {
+
+	var terms []string
+	for _, term := range strings.FieldsFunc(filter, func(r rune) bool { return strings.ContainsRune("|&,()", r) }) {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func dedupeAppend(list []string, item string) []string {
+
+	if contains(list, item) {
+		return list
+	}
+	return append(list, item)
+}
+
+func contains(list []string, item string) bool {
+
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package rulesengine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCatalogFromYAML(t *testing.T) {
+	engine := RuleEngine{}
+	assert.NoError(t, engine.LoadCatalogFile("testdata/example_catalog.yaml"))
+	assert.True(t, engine.HasCatalog("tests", "example-repo"))
+	assert.Len(t, engine["tests"]["example-repo"], 2)
+	assert.Equal(t, 10, engine["tests"]["example-repo"][0].Priority)
+	assert.True(t, engine["tests"]["example-repo"][0].IsTerminal())
+
+	rctx := NewRuleCtx()
+	rctx.RepoName = "example-repo"
+	rctx.DiffFiles = Files{
+		{Name: "tests/build/build.go", Status: "M"},
+		{Name: "tests/build/const.go", Status: "M"},
+		{Name: "README.md", Status: "M"},
+	}
+
+	assert.NoError(t, engine.RunRules(rctx, "tests", "example-repo"))
+	assert.Equal(t, []string{"tests/build/build.go"}, rctx.FocusFiles)
+	assert.Equal(t, "example", rctx.LabelFilter)
+	assert.Equal(t, 2*time.Hour, rctx.Timeout)
+	assert.True(t, rctx.RequiresSprayProxyRegistering)
+}
+
+func TestParseCatalogSubRuleReference(t *testing.T) {
+	engine := RuleEngine{}
+	assert.NoError(t, engine.LoadCatalogFile("testdata/example_catalog.yaml"))
+
+	rctx := NewRuleCtx()
+	rctx.RepoName = "example-repo"
+	rctx.DiffFiles = Files{{Name: "pkg/utils/util.go", Status: "M"}}
+
+	assert.NoError(t, engine.RunRules(rctx, "tests", "example-repo"))
+	assert.Equal(t, "!upgrade-create", rctx.LabelFilter)
+	assert.Empty(t, rctx.FocusFiles)
+}
+
+func TestParseCatalogFromJSON(t *testing.T) {
+	catalog := `{
+  "category": "tests",
+  "catalog": "json-repo",
+  "rules": [
+    {
+      "name": "JSON rule",
+      "when": {"paired": true},
+      "then": [{"addLabelFilter": "paired"}]
+    }
+  ]
+}`
+	loaded, err := ParseCatalog("catalog.json", []byte(catalog))
+	assert.NoError(t, err)
+	assert.Equal(t, "json-repo", loaded.Name)
+
+	rctx := NewRuleCtx()
+	rctx.IsPaired = true
+	ok, err := loaded.Rules[0].Check(rctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "paired", rctx.LabelFilter)
+}
+
+func TestParseCatalogRepoGlobMatchesNestedDirs(t *testing.T) {
+	catalog := `{
+  "category": "tests",
+  "catalog": "glob-repo",
+  "rules": [
+    {
+      "name": "Glob rule",
+      "when": {"paired": true},
+      "then": [{"addFocusFiles": {"fromRepoGlob": "testdata/**/e2e-report.xml", "exclude": "**/run-2/*"}}]
+    }
+  ]
+}`
+	loaded, err := ParseCatalog("catalog.json", []byte(catalog))
+	assert.NoError(t, err)
+
+	rctx := NewRuleCtx()
+	rctx.IsPaired = true
+	ok, err := loaded.Rules[0].Check(rctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"testdata/history/run-1/e2e-report.xml", "testdata/history/run-3/e2e-report.xml"}, rctx.FocusFiles)
+}
+
+func TestParseCatalogErrors(t *testing.T) {
+	RegisterAction(RunTestsActionName, ActionFunc(func(rctx *RuleCtx) error { return nil }))
+
+	tests := []struct {
+		name     string
+		catalog  string
+		expected string
+	}{
+		{
+			name:     "unknown top level field",
+			catalog:  "category: tests\ncatalog: foo\nrulez: []\n",
+			expected: `bad.yaml:3:1: unknown field "rulez" in catalog`,
+		},
+		{
+			name:     "missing category",
+			catalog:  "catalog: foo\nrules:\n  - name: a\n    when: {repoName: foo}\n",
+			expected: `bad.yaml:1:1: catalog is missing the required field "category"`,
+		},
+		{
+			name:     "multiple clauses in a condition",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when:\n      repoName: foo\n      jobType: periodic\n",
+			expected: "bad.yaml:6:7: condition must declare exactly one of",
+		},
+		{
+			name:     "unknown condition",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when:\n      repo: foo\n",
+			expected: `bad.yaml:6:7: unknown field "repo" in condition`,
+		},
+		{
+			name:     "non boolean flag",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when:\n      paired: \"yes\"\n",
+			expected: "bad.yaml:6:15: paired must be a boolean",
+		},
+		{
+			name:     "invalid glob",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when:\n      diffFiles:\n        glob: \"tests/[\"\n",
+			expected: `bad.yaml:7:15: invalid glob "tests/["`,
+		},
+		{
+			name:     "undeclared rule reference",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when:\n      rule: b\n",
+			expected: `bad.yaml:6:13: rule "b" referenced in condition is not declared in this catalog`,
+		},
+		{
+			name:     "unregistered action",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when: {repoName: foo}\n    then:\n      - action: deploy\n",
+			expected: `bad.yaml:7:17: action "deploy" is not registered`,
+		},
+		{
+			name:     "invalid timeout",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when: {repoName: foo}\n    then:\n      - set: {timeout: forever}\n",
+			expected: `bad.yaml:7:24: invalid timeout "forever"`,
+		},
+		{
+			name:     "invalid mode",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    mode: last\n    when: {repoName: foo}\n",
+			expected: `bad.yaml:5:11: rule "a" mode must be one of "terminal", "additive"`,
+		},
+		{
+			name:     "non integer priority",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    priority: high\n    when: {repoName: foo}\n",
+			expected: `bad.yaml:5:15: rule "a" priority must be an integer`,
+		},
+		{
+			name:     "duplicated rule name",
+			catalog:  "category: tests\ncatalog: foo\nrules:\n  - name: a\n    when: {repoName: foo}\n  - name: a\n    when: {repoName: foo}\n",
+			expected: `bad.yaml:6:11: rule "a" is declared more than once`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCatalog("bad.yaml", []byte(tt.catalog))
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestRegisterCatalogRefusesOverride(t *testing.T) {
+	engine := RuleEngine{"tests": {"example-repo": RuleCatalog{}}}
+	err := engine.LoadCatalogFile("testdata/example_catalog.yaml")
+	assert.ErrorContains(t, err, "catalog example-repo is already registered in category tests")
+}
+
+func TestAddLabelFilterComparesWholeTerms(t *testing.T) {
+	rctx := &RuleCtx{}
+	rctx.LabelFilter = "!upgrade-create || build-service"
+
+	AddLabelFilter(rctx, "upgrade")
+	AddLabelFilter(rctx, "build")
+	AddLabelFilter(rctx, "build-service")
+	AddLabelFilter(rctx, "upgrade")
+
+	assert.Equal(t, "!upgrade-create || build-service,upgrade,build", rctx.LabelFilter)
+}
+
+func TestHasLabelFilterTerm(t *testing.T) {
+	assert.True(t, HasLabelFilterTerm("build-templates && (build || ec)", "build"))
+	assert.True(t, HasLabelFilterTerm("build-templates&&ec", "ec"))
+	assert.False(t, HasLabelFilterTerm("build-templates && !upgrade", "build"))
+	assert.False(t, HasLabelFilterTerm("build-templates && !upgrade", "upgrade"))
+	assert.False(t, HasLabelFilterTerm("", "build"))
+}
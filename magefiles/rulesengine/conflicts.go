@@ -0,0 +1,164 @@
+package rulesengine
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// Probe is a RuleCtx the rules of a catalog are checked for conflicts against,
+// i.e. the RuleCtx of a fixture case.
+type Probe struct {
+	Name    string
+	RuleCtx *RuleCtx
+}
+
+// Conflict is a pair of rules which both apply to the same probe with contradicting actions.
+type Conflict struct {
+	Probe  string
+	Rules  [2]string
+	Reason string
+}
+
+func (c Conflict) String() string {
+
+	return fmt.Sprintf("rules %q and %q conflict for %q: %s", c.Rules[0], c.Rules[1], c.Probe, c.Reason)
+}
+
+// ruleOutcome is what a rule does to a probe when it is applied on its own.
+type ruleOutcome struct {
+	rule       Rule
+	focusFiles []string
+	runsTests  bool
+}
+
+// CheckConflicts simulates the rules of the catalog against every probe and reports the
+// pairs of rules applied together with contradicting actions. Rules skipped because a
+// rule of higher priority is terminal are not reported, the priority resolves the overlap.
+// Conditions and actions of the rules are Go functions which cannot be analysed statically,
+// so only the overlaps the probes run into are found: a pair of rules conflicting for a
+// RuleCtx none of the probes resembles is not reported.
+func (e *RuleEngine) CheckConflicts(category, catalog string, probes []Probe) ([]Conflict, error) {
+
+	if !e.HasCatalog(category, catalog) {
+		return nil, fmt.Errorf("catalog %s is not registered in category %s", catalog, category)
+	}
+
+	return CheckCatalogConflicts((*e)[category][catalog], probes)
+}
+
+// CheckCatalogConflicts is CheckConflicts for a catalog which is not registered into an engine.
+func CheckCatalogConflicts(catalog RuleCatalog, probes []Probe) ([]Conflict, error) {
+
+	var conflicts []Conflict
+	rules := byPriority(catalog)
+	for _, probe := range probes {
+		var outcomes []ruleOutcome
+		for _, rule := range rules {
+			rctx := probe.RuleCtx.simulationCopy()
+			ok, err := rule.Check(rctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check rule %q for %q: %+v", rule.Name, probe.Name, err)
+			}
+			if !ok {
+				continue
+			}
+			outcomes = append(outcomes, ruleOutcome{rule: rule, focusFiles: addedFocusFiles(probe.RuleCtx, rctx), runsTests: rctx.Plan.runsTests()})
+			if rule.IsTerminal() {
+				break
+			}
+		}
+
+		for i := range outcomes {
+			for j := i + 1; j < len(outcomes); j++ {
+				reason, err := contradiction(probe.RuleCtx, outcomes[i], outcomes[j])
+				if err != nil {
+					return nil, fmt.Errorf("failed to check rules %q and %q for %q: %+v", outcomes[i].rule.Name, outcomes[j].rule.Name, probe.Name, err)
+				}
+				if reason != "" {
+					conflicts = append(conflicts, Conflict{Probe: probe.Name, Rules: [2]string{outcomes[i].rule.Name, outcomes[j].rule.Name}, Reason: reason})
+				}
+			}
+		}
+	}
+
+	return conflicts, nil
+}
+
+// contradiction returns why the actions of first and second contradict each other, if they do.
+// first is the rule applied first by the engine.
+func contradiction(probe *RuleCtx, first, second ruleOutcome) (string, error) {
+
+	if len(first.focusFiles) != 0 && second.runsTests && len(second.focusFiles) == 0 {
+		return fmt.Sprintf("%q focuses on %s while %q runs the full suite", first.rule.Name, strings.Join(first.focusFiles, ", "), second.rule.Name), nil
+	}
+	if len(second.focusFiles) != 0 && first.runsTests && len(first.focusFiles) == 0 {
+		return fmt.Sprintf("%q runs the full suite while %q focuses on %s", first.rule.Name, second.rule.Name, strings.Join(second.focusFiles, ", ")), nil
+	}
+	if first.runsTests && second.runsTests {
+		return "both rules run the tests", nil
+	}
+
+	// Label filters are usually extended by the rules, so only the overriding ones are reported
+	rctx := probe.simulationCopy()
+	if _, err := first.rule.Check(rctx); err != nil {
+		return "", err
+	}
+	label := rctx.LabelFilter
+	if _, err := second.rule.Check(rctx); err != nil {
+		return "", err
+	}
+	if label != probe.LabelFilter && !keepsLabelFilterTerms(rctx.LabelFilter, label) {
+		return fmt.Sprintf("%q overrides the label filter %q set by %q with %q", second.rule.Name, label, first.rule.Name, rctx.LabelFilter), nil
+	}
+
+	return "", nil
+}
+
+// keepsLabelFilterTerms tells whether every term of the previous label filter is still a term of filter.
+func keepsLabelFilterTerms(filter, previous string) bool {
+
+	for _, term := range labelFilterTerms(previous) {
+		if !HasLabelFilterTerm(filter, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// simulationCopy returns a copy of rctx which can be evaluated without modifying rctx nor reaching out of it.
+func (rctx *RuleCtx) simulationCopy() *RuleCtx {
+
+	c := *rctx
+	c.FocusFiles = append([]string{}, rctx.FocusFiles...)
+	c.RuleData = maps.Clone(rctx.RuleData)
+	c.Simulate = true
+	c.Plan = &SimulationPlan{}
+	c.SelectionReport = nil
+	c.trace = nil
+
+	return &c
+}
+
+func addedFocusFiles(before, after *RuleCtx) []string {
+
+	var added []string
+	for _, f := range after.FocusFiles {
+		if !contains(before.FocusFiles, f) {
+			added = append(added, f)
+		}
+	}
+
+	return added
+}
+
+func (p *SimulationPlan) runsTests() bool {
+
+	for _, e := range p.Effects {
+		if e.Kind == SideEffectGinkgo {
+			return true
+		}
+	}
+
+	return false
+}
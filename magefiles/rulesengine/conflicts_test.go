@@ -0,0 +1,104 @@
+package rulesengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func always(rctx *RuleCtx) (bool, error) { return true, nil }
+
+func setLabel(label string) Action {
+	return ActionFunc(func(rctx *RuleCtx) error {
+		rctx.LabelFilter = label
+		return nil
+	})
+}
+
+var runTests = ActionFunc(func(rctx *RuleCtx) error {
+	return rctx.Perform(SideEffectGinkgo, "ginkgo ./cmd", nil)
+})
+
+func TestRulePriorityAndMode(t *testing.T) {
+	low := Rule{Name: "Low", Condition: ConditionFunc(always), Actions: []Action{setLabel("low")}}
+	high := Rule{Name: "High", Condition: ConditionFunc(always), Actions: []Action{setLabel("high")}, Priority: 10}
+
+	// additive rules are all applied, in priority order
+	rctx := NewRuleCtx()
+	engine := RuleEngine{"tests": {"repo": {low, high}}}
+	assert.NoError(t, engine.RunRules(rctx, "tests", "repo"))
+	assert.Equal(t, []string{"High", "Low"}, rctx.SelectionReport.MatchedRules)
+	assert.Equal(t, "low", rctx.LabelFilter)
+
+	// a terminal rule stops the evaluation of the rules of lower priority
+	high.Mode = RuleModeTerminal
+	rctx = NewRuleCtx()
+	engine = RuleEngine{"tests": {"repo": {low, high}}}
+	assert.NoError(t, engine.RunRules(rctx, "tests", "repo"))
+	assert.Equal(t, []string{"High"}, rctx.SelectionReport.MatchedRules)
+	assert.Equal(t, []string{"Low"}, rctx.SelectionReport.SkippedRules)
+	assert.Equal(t, "high", rctx.LabelFilter)
+}
+
+func TestRuleChainIsTerminalByDefault(t *testing.T) {
+	chain := Rule{Name: "Chain", Condition: &Rule{Name: "Nested", Condition: ConditionFunc(always), Actions: []Action{setLabel("chain")}}}
+	after := Rule{Name: "After", Condition: ConditionFunc(always), Actions: []Action{setLabel("after")}}
+	assert.True(t, chain.IsTerminal())
+	assert.False(t, after.IsTerminal())
+
+	rctx := NewRuleCtx()
+	engine := RuleEngine{"ci": {"repo": {chain, after}}}
+	assert.NoError(t, engine.RunRules(rctx, "ci", "repo"))
+	assert.Equal(t, "chain", rctx.LabelFilter)
+
+	// an additive chain lets the next rules apply
+	chain.Mode = RuleModeAdditive
+	rctx = NewRuleCtx()
+	engine = RuleEngine{"ci": {"repo": {chain, after}}}
+	assert.NoError(t, engine.RunRules(rctx, "ci", "repo"))
+	assert.Equal(t, "after", rctx.LabelFilter)
+}
+
+func TestCheckConflicts(t *testing.T) {
+	testFiles := Rule{Name: "Test files", Condition: ConditionFunc(always), Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+		rctx.FocusFiles = append(rctx.FocusFiles, "tests/build/build.go")
+		return nil
+	}), runTests}}
+	fullSuite := Rule{Name: "Full suite", Condition: ConditionFunc(always), Actions: []Action{setLabel("!upgrade-create"), runTests}}
+	extendLabel := Rule{Name: "Extend label", Condition: ConditionFunc(always), Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+		rctx.LabelFilter += ",konflux"
+		return nil
+	})}}
+	overrideLabel := Rule{Name: "Override label", Condition: ConditionFunc(always), Actions: []Action{setLabel("build")}}
+	never := Rule{Name: "Never", Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return false, nil }), Actions: []Action{runTests}}
+
+	probe := []Probe{{Name: "build test changed", RuleCtx: NewRuleCtx()}}
+
+	conflicts, err := CheckCatalogConflicts(RuleCatalog{testFiles, fullSuite, never}, probe)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.Equal(t, `rules "Test files" and "Full suite" conflict for "build test changed": "Test files" focuses on tests/build/build.go while "Full suite" runs the full suite`, conflicts[0].String())
+
+	conflicts, err = CheckCatalogConflicts(RuleCatalog{fullSuite, extendLabel}, probe)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+
+	conflicts, err = CheckCatalogConflicts(RuleCatalog{fullSuite, overrideLabel}, probe)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.Contains(t, conflicts[0].Reason, `"Override label" overrides the label filter "!upgrade-create"`)
+
+	// a label filter is only kept when all its terms are, not when it is a substring of the new one
+	buildLabel := Rule{Name: "Build label", Condition: ConditionFunc(always), Actions: []Action{setLabel("build")}}
+	templatesLabel := Rule{Name: "Templates label", Condition: ConditionFunc(always), Actions: []Action{setLabel("build-templates")}}
+	conflicts, err = CheckCatalogConflicts(RuleCatalog{buildLabel, templatesLabel}, probe)
+	assert.NoError(t, err)
+	assert.Len(t, conflicts, 1)
+	assert.Contains(t, conflicts[0].Reason, `"Templates label" overrides the label filter "build"`)
+
+	// the priority of a terminal rule resolves the overlap
+	testFiles.Mode = RuleModeTerminal
+	conflicts, err = CheckCatalogConflicts(RuleCatalog{testFiles, fullSuite}, probe)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts)
+}
@@ -1,14 +1,18 @@
 package engine
 
 import (
+	"sync"
+
 	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
 	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine/repos"
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+	"k8s.io/klog"
 )
 
 var MageEngine = rulesengine.RuleEngine{
 	"tests": {
-		"e2e-repo":                repos.E2ETestRulesCatalog,
-		"infra-deployments":       repos.InfraDeploymentsRulesCatalog,
+		"e2e-repo":          repos.E2ETestRulesCatalog,
+		"infra-deployments": repos.InfraDeploymentsRulesCatalog,
 	},
 	"demo": {
 		"local-workflow": repos.DemoCatalog,
@@ -21,7 +25,26 @@ var MageEngine = rulesengine.RuleEngine{
 		"integration-service":     repos.IntegrationServiceCICatalog,
 		"image-controller":        repos.ImageControllerCICatalog,
 		"build-service":           repos.BuildServiceCICatalog,
-		// TODO: to be implemented in a follow-up PR
-		//"infra-deployments": repos.InfraDeploymentsCIChainCatalog,
+		"infra-deployments":       repos.InfraDeploymentsCIChainCatalog,
 	},
 }
+
+var loadCatalogsOnce sync.Once
+var loadCatalogsErr error
+
+// LoadDeclarativeCatalogs registers the YAML/JSON rule catalogs found in the
+// RULES_CATALOG_DIR directory into MageEngine, nothing is loaded when it is not set.
+// It is safe to call it multiple times, the catalogs are only loaded once.
+func LoadDeclarativeCatalogs() error {
+
+	loadCatalogsOnce.Do(func() {
+		dir := utils.GetEnv("RULES_CATALOG_DIR", "")
+		if dir == "" {
+			klog.Info("RULES_CATALOG_DIR is not set, no declarative rule catalogs are loaded")
+			return
+		}
+		loadCatalogsErr = MageEngine.LoadCatalogDir(dir)
+	})
+
+	return loadCatalogsErr
+}
@@ -0,0 +1,42 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every catalog of MageEngine has a fixture file in testdata/fixtures/<category>/<catalog>.yaml.
+// The catalogs are simulated, so the tests need neither git, the GitHub API nor a cluster.
+// The fixture cases are also used as probes to check the rules of the catalogs do not conflict.
+func TestRuleCatalogFixtures(t *testing.T) {
+	for category, catalogs := range MageEngine {
+		for catalog := range catalogs {
+			t.Run(category+"/"+catalog, func(t *testing.T) {
+				path := filepath.Join("testdata", "fixtures", category, catalog+".yaml")
+				if _, err := os.Stat(path); err != nil {
+					t.Fatalf("catalog %s/%s has no fixture file %s", category, catalog, path)
+				}
+				fixture, err := rulesengine.LoadFixtureFile(path)
+				assert.NoError(t, err)
+				assert.Equal(t, category, fixture.Category)
+				assert.Equal(t, catalog, fixture.Catalog)
+
+				for _, c := range fixture.Cases {
+					t.Run(c.Name, func(t *testing.T) {
+						rctx, err := MageEngine.RunFixture(fixture, c, t.Setenv)
+						assert.NoError(t, err)
+						assert.Empty(t, c.Expect.Diff(rctx))
+
+						conflicts, err := MageEngine.CheckConflicts(category, catalog, []rulesengine.Probe{{Name: c.Name, RuleCtx: c.RuleCtx()}})
+						assert.NoError(t, err)
+						assert.Empty(t, conflicts)
+					})
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package rulesengine
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FixtureFile is a set of offline test cases for one catalog of the engine.
+// Every case describes the PR/job a catalog is run for and the RuleCtx
+// the catalog is expected to produce.
+type FixtureFile struct {
+	Category string        `yaml:"category"`
+	Catalog  string        `yaml:"catalog"`
+	Cases    []FixtureCase `yaml:"cases"`
+}
+
+// FixtureCase is the input of a catalog run. DiffFiles are the names of
+// the files changed in the PR, Env the env vars set during the run.
+type FixtureCase struct {
+	Name            string             `yaml:"name"`
+	RepoName        string             `yaml:"repoName"`
+	JobName         string             `yaml:"jobName"`
+	JobType         string             `yaml:"jobType"`
+	TektonEventType string             `yaml:"tektonEventType"`
+	PrRemoteName    string             `yaml:"prRemoteName"`
+	PrBranchName    string             `yaml:"prBranchName"`
+	PrCommitSha     string             `yaml:"prCommitSha"`
+	IsPaired        bool               `yaml:"isPaired"`
+	DiffFiles       []string           `yaml:"diffFiles"`
+	Env             map[string]string  `yaml:"env"`
+	Expect          FixtureExpectation `yaml:"expect"`
+}
+
+// FixtureExpectation is the part of the RuleCtx asserted once the catalog has run.
+type FixtureExpectation struct {
+	FocusFiles                    []string `yaml:"focusFiles"`
+	LabelFilter                   string   `yaml:"labelFilter"`
+	RequiresMultiPlatformTests    bool     `yaml:"requiresMultiPlatformTests"`
+	RequiresSprayProxyRegistering bool     `yaml:"requiresSprayProxyRegistering"`
+}
+
+// LoadFixtureFile reads a YAML fixture file, unknown fields are rejected.
+func LoadFixtureFile(path string) (*FixtureFile, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file %s: %+v", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	fixture := &FixtureFile{}
+	if err := decoder.Decode(fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture file %s: %+v", path, err)
+	}
+	if fixture.Category == "" || fixture.Catalog == "" {
+		return nil, fmt.Errorf("fixture file %s must declare both category and catalog", path)
+	}
+
+	return fixture, nil
+}
+
+// RuleCtx returns the simulated RuleCtx the case is run with, so
+// neither git, the GitHub API nor a cluster are reached out to.
+func (c *FixtureCase) RuleCtx() *RuleCtx {
+
+	rctx := NewRuleCtx()
+	rctx.Simulate = true
+	rctx.RepoName = c.RepoName
+	rctx.JobName = c.JobName
+	rctx.JobType = c.JobType
+	rctx.TektonEventType = c.TektonEventType
+	rctx.PrRemoteName = c.PrRemoteName
+	rctx.PrBranchName = c.PrBranchName
+	rctx.PrCommitSha = c.PrCommitSha
+	rctx.IsPaired = c.IsPaired
+	rctx.DiffFiles = Files{}
+	for _, name := range c.DiffFiles {
+		rctx.DiffFiles = append(rctx.DiffFiles, File{Status: "M", Name: name})
+	}
+
+	return rctx
+}
+
+// RunFixture runs the catalog of the fixture file for the given case. The env vars of the case
+// are exported with setenv, which is expected to restore them afterwards (i.e. testing.T.Setenv).
+func (e *RuleEngine) RunFixture(fixture *FixtureFile, c FixtureCase, setenv func(key, value string)) (*RuleCtx, error) {
+
+	if !e.HasCatalog(fixture.Category, fixture.Catalog) {
+		return nil, fmt.Errorf("catalog %s is not registered in category %s", fixture.Catalog, fixture.Category)
+	}
+	for key, value := range c.Env {
+		setenv(key, value)
+	}
+	rctx := c.RuleCtx()
+
+	return rctx, e.RunRules(rctx, fixture.Category, fixture.Catalog)
+}
+
+// Diff returns a description of every field of rctx not matching the expectation.
+func (x FixtureExpectation) Diff(rctx *RuleCtx) []string {
+
+	var diffs []string
+	if expected, actual := strings.Join(x.FocusFiles, ", "), strings.Join(rctx.FocusFiles, ", "); expected != actual {
+		diffs = append(diffs, fmt.Sprintf("FocusFiles: expected [%s], got [%s]", expected, actual))
+	}
+	if x.LabelFilter != rctx.LabelFilter {
+		diffs = append(diffs, fmt.Sprintf("LabelFilter: expected %q, got %q", x.LabelFilter, rctx.LabelFilter))
+	}
+	if x.RequiresMultiPlatformTests != rctx.RequiresMultiPlatformTests {
+		diffs = append(diffs, fmt.Sprintf("RequiresMultiPlatformTests: expected %t, got %t", x.RequiresMultiPlatformTests, rctx.RequiresMultiPlatformTests))
+	}
+	if x.RequiresSprayProxyRegistering != rctx.RequiresSprayProxyRegistering {
+		diffs = append(diffs, fmt.Sprintf("RequiresSprayProxyRegistering: expected %t, got %t", x.RequiresSprayProxyRegistering, rctx.RequiresSprayProxyRegistering))
+	}
+
+	return diffs
+}
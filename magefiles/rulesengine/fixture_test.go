@@ -0,0 +1,47 @@
+package rulesengine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunFixture(t *testing.T) {
+	tests := []struct {
+		catalog string
+		fixture string
+	}{
+		{"testdata/example_catalog.yaml", "testdata/example_catalog_fixture.yaml"},
+		// An additive rule evaluated before a terminal rule chain is applied after it
+		{"testdata/rule_ordering_catalog.yaml", "testdata/rule_ordering_fixture.yaml"},
+	}
+
+	for _, tt := range tests {
+		engine := RuleEngine{}
+		assert.NoError(t, engine.LoadCatalogFile(tt.catalog))
+
+		fixture, err := LoadFixtureFile(tt.fixture)
+		assert.NoError(t, err)
+
+		for _, c := range fixture.Cases {
+			t.Run(fixture.Catalog+"/"+c.Name, func(t *testing.T) {
+				rctx, err := engine.RunFixture(fixture, c, t.Setenv)
+				assert.NoError(t, err)
+				assert.Empty(t, c.Expect.Diff(rctx))
+			})
+		}
+	}
+}
+
+func TestFixtureExpectationDiff(t *testing.T) {
+	rctx := NewRuleCtx()
+	rctx.FocusFiles = []string{"tests/build/build.go"}
+	rctx.RequiresSprayProxyRegistering = true
+
+	diffs := FixtureExpectation{LabelFilter: "build"}.Diff(rctx)
+	assert.Equal(t, []string{
+		"FocusFiles: expected [], got [tests/build/build.go]",
+		`LabelFilter: expected "build", got ""`,
+		"RequiresSprayProxyRegistering: expected false, got true",
+	}, diffs)
+}
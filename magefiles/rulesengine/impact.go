@@ -0,0 +1,382 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/onsi/ginkgo/v2/reporters"
+	"k8s.io/klog"
+)
+
+const (
+	// DefaultImpactBudget is the maximum number of specs HistoricalImpact pulls in when TEST_IMPACT_BUDGET is not set.
+	DefaultImpactBudget = 5
+	impactRuleDataKey   = "historicalImpact"
+)
+
+// DefaultImpactPackages are the packages whose changes HistoricalImpact looks up in the history.
+var DefaultImpactPackages = []string{"pkg/clients/*", "pkg/utils/*", "pkg/utils/*/*"}
+
+// SpecResult is the outcome of a spec in a previous run.
+type SpecResult struct {
+	Name   string
+	Labels []string
+	// Files are the test files found in the failure location and stack trace of the spec
+	Files  []string
+	Failed bool
+}
+
+// TestRun is a previous run: the files changed in the PR and the results of its specs.
+type TestRun struct {
+	Dir          string
+	ChangedFiles []string
+	Results      []SpecResult
+}
+
+var (
+	junitLabelsRegex   = regexp.MustCompile(`^(.*) \[([^\[\]]*)\]$`)
+	testFileLocRegex   = regexp.MustCompile(`(?:^|/|\s)(tests/[\w\-./]+\.go):\d+`)
+	invalidLabelsRegex = regexp.MustCompile(`[&|!,()/\s]`)
+)
+
+// ParseJUnitReport returns the results of the specs ([It] nodes) of a JUnit report written by ginkgo.
+// Skipped and pending specs are left out.
+func ParseJUnitReport(data []byte) ([]SpecResult, error) {
+
+	var suites reporters.JUnitTestSuites
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		return nil, err
+	}
+
+	var results []SpecResult
+	for _, suite := range suites.TestSuites {
+		for _, tc := range suite.TestCases {
+			if !strings.HasPrefix(tc.Name, "[It] ") || tc.Skipped != nil {
+				continue
+			}
+			result := SpecResult{Name: strings.TrimPrefix(tc.Name, "[It] ")}
+			if m := junitLabelsRegex.FindStringSubmatch(result.Name); m != nil {
+				result.Name = m[1]
+				for _, label := range strings.Split(m[2], ", ") {
+					result.Labels = append(result.Labels, strings.TrimSpace(label))
+				}
+			}
+			var details string
+			if tc.Failure != nil {
+				result.Failed = true
+				details += tc.Failure.Description
+			}
+			if tc.Error != nil {
+				result.Failed = true
+				details += tc.Error.Description
+			}
+			for _, m := range testFileLocRegex.FindAllStringSubmatch(details, -1) {
+				result.Files = dedupeAppend(result.Files, m[1])
+			}
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// LoadTestHistory loads the previous runs stored in dir. Every directory containing
+// JUnit reports (*.xml) is a run, the artifact directory of a CI job. Its changed files
+// are read from the test selection report stored next to the JUnit reports, if any.
+func LoadTestHistory(dir string) ([]TestRun, error) {
+
+	runs := map[string]*TestRun{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || filepath.Ext(p) != ".xml" {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to read JUnit report %s: %+v", p, err)
+		}
+		results, err := ParseJUnitReport(data)
+		if err != nil {
+			klog.Warningf("skipping %s, not a JUnit report: %+v", p, err)
+			return nil
+		}
+		runDir := filepath.Dir(p)
+		if _, ok := runs[runDir]; !ok {
+			runs[runDir] = &TestRun{Dir: runDir}
+		}
+		runs[runDir].Results = append(runs[runDir].Results, results...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the test history from %s: %+v", dir, err)
+	}
+
+	var history []TestRun
+	for runDir, run := range runs {
+		data, err := os.ReadFile(filepath.Join(runDir, SelectionReportJSONFile))
+		if err == nil {
+			var report SelectionReport
+			if err := json.Unmarshal(data, &report); err != nil {
+				return nil, fmt.Errorf("failed to parse the test selection report of %s: %+v", runDir, err)
+			}
+			run.ChangedFiles = report.DiffFiles
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read the test selection report of %s: %+v", runDir, err)
+		}
+		history = append(history, *run)
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Dir < history[j].Dir })
+
+	return history, nil
+}
+
+// SpecStats aggregates the results of a spec over the history.
+type SpecStats struct {
+	Name     string
+	Labels   []string
+	Files    []string
+	Runs     int
+	Failures int
+}
+
+// Flakiness is the failure rate of the spec over the whole history.
+func (s *SpecStats) Flakiness() float64 {
+
+	if s.Runs == 0 {
+		return 0
+	}
+
+	return float64(s.Failures) / float64(s.Runs)
+}
+
+// specCounts are the runs/failures of a spec in the runs where a package changed.
+type specCounts struct {
+	runs     int
+	failures int
+}
+
+// ImpactMap maps the packages changed in the previous runs to the specs which ran, and failed, in those runs.
+type ImpactMap struct {
+	Specs    map[string]*SpecStats
+	packages map[string]map[string]*specCounts
+}
+
+// ImpactedSpec is a spec historically failing when a changed package was changed.
+type ImpactedSpec struct {
+	Name      string
+	Labels    []string
+	Files     []string
+	Package   string
+	Score     float64
+	Flakiness float64
+}
+
+// BuildImpactMap builds the impact map of the packages matching the given globs.
+func BuildImpactMap(history []TestRun, packages []string) *ImpactMap {
+
+	m := &ImpactMap{Specs: map[string]*SpecStats{}, packages: map[string]map[string]*specCounts{}}
+	for _, run := range history {
+		changed := changedPackages(run.ChangedFiles, packages)
+		for _, result := range run.Results {
+			stats, ok := m.Specs[result.Name]
+			if !ok {
+				stats = &SpecStats{Name: result.Name, Labels: result.Labels}
+				m.Specs[result.Name] = stats
+			}
+			stats.Runs++
+			if result.Failed {
+				stats.Failures++
+			}
+			for _, f := range result.Files {
+				stats.Files = dedupeAppend(stats.Files, f)
+			}
+			for _, pkg := range changed {
+				if m.packages[pkg] == nil {
+					m.packages[pkg] = map[string]*specCounts{}
+				}
+				counts, ok := m.packages[pkg][result.Name]
+				if !ok {
+					counts = &specCounts{}
+					m.packages[pkg][result.Name] = counts
+				}
+				counts.runs++
+				if result.Failed {
+					counts.failures++
+				}
+			}
+		}
+	}
+
+	return m
+}
+
+// Impacted returns at most budget specs which historically failed more often when one of the packages
+// of the changed files changed than when it did not. The score of a spec is the difference between
+// both failure rates, so flaky specs failing regardless of the changes are not pulled in.
+func (m *ImpactMap) Impacted(changedFiles, packages []string, budget int) []ImpactedSpec {
+
+	best := map[string]ImpactedSpec{}
+	for _, pkg := range changedPackages(changedFiles, packages) {
+		for name, counts := range m.packages[pkg] {
+			stats := m.Specs[name]
+			if counts.failures == 0 || (len(stats.Files) == 0 && len(stats.Labels) == 0) {
+				continue
+			}
+			score := float64(counts.failures) / float64(counts.runs)
+			if otherRuns := stats.Runs - counts.runs; otherRuns > 0 {
+				score -= float64(stats.Failures-counts.failures) / float64(otherRuns)
+			}
+			if score <= 0 {
+				continue
+			}
+			if current, ok := best[name]; !ok || score > current.Score {
+				best[name] = ImpactedSpec{Name: name, Labels: stats.Labels, Files: stats.Files, Package: pkg, Score: score, Flakiness: stats.Flakiness()}
+			}
+		}
+	}
+
+	var impacted []ImpactedSpec
+	for _, spec := range best {
+		impacted = append(impacted, spec)
+	}
+	sort.Slice(impacted, func(i, j int) bool {
+		if impacted[i].Score != impacted[j].Score {
+			return impacted[i].Score > impacted[j].Score
+		}
+		return impacted[i].Name < impacted[j].Name
+	})
+	if budget >= 0 && len(impacted) > budget {
+		impacted = impacted[:budget]
+	}
+
+	return impacted
+}
+
+func changedPackages(files, packages []string) []string {
+
+	var changed []string
+	for _, f := range files {
+		dir := path.Dir(f)
+		for _, glob := range packages {
+			if matched, _ := doublestar.Match(glob, dir); matched {
+				changed = dedupeAppend(changed, dir)
+				break
+			}
+		}
+	}
+
+	return changed
+}
+
+// HistoricalImpact is a conditional/action pair pulling in the specs which historically failed when
+// the packages changed in the PR were changed. As a conditional it is true when there is any such spec.
+// As an action it adds the test files of those specs to the focus files of a focused run, or their
+// labels to the label filter of a run selected by labels. A run of the full suite is left untouched.
+type HistoricalImpact struct {
+	// HistoryDir contains the artifact directories of previous runs, defaults to TEST_IMPACT_HISTORY_DIR
+	HistoryDir string
+	// Packages are the globs of the packages looked up, defaults to DefaultImpactPackages
+	Packages []string
+	// Budget is the maximum number of specs pulled in, defaults to TEST_IMPACT_BUDGET or DefaultImpactBudget
+	Budget int
+}
+
+func (h *HistoricalImpact) String() string {
+
+	return "historical test impact"
+}
+
+func (h *HistoricalImpact) Check(rctx *RuleCtx) (bool, error) {
+
+	return len(h.impacted(rctx)) != 0, nil
+}
+
+func (h *HistoricalImpact) Execute(rctx *RuleCtx) error {
+
+	specs := h.impacted(rctx)
+	if len(specs) == 0 {
+		return nil
+	}
+
+	switch {
+	case len(rctx.FocusFiles) != 0:
+		for _, spec := range specs {
+			klog.Infof("pulling in %q (score %.2f, flakiness %.2f) historically failing on %s changes", spec.Name, spec.Score, spec.Flakiness, spec.Package)
+			for _, f := range spec.Files {
+				rctx.FocusFiles = dedupeAppend(rctx.FocusFiles, f)
+			}
+		}
+	case rctx.LabelFilter != "":
+		var terms []string
+		for _, spec := range specs {
+			if len(spec.Labels) == 0 || invalidLabelsRegex.MatchString(strings.Join(spec.Labels, "")) {
+				continue
+			}
+			klog.Infof("pulling in %q (score %.2f, flakiness %.2f) historically failing on %s changes", spec.Name, spec.Score, spec.Flakiness, spec.Package)
+			terms = dedupeAppend(terms, "("+strings.Join(spec.Labels, " && ")+")")
+		}
+		if len(terms) != 0 {
+			rctx.LabelFilter = fmt.Sprintf("(%s) || %s", rctx.LabelFilter, strings.Join(terms, " || "))
+		}
+	default:
+		klog.Info("the full suite is selected, no spec to pull in from the test history")
+	}
+
+	return nil
+}
+
+// impacted returns the impacted specs, they are computed once per RuleCtx.
+func (h *HistoricalImpact) impacted(rctx *RuleCtx) []ImpactedSpec {
+
+	if specs, ok := rctx.GetRuleData(impactRuleDataKey).([]ImpactedSpec); ok {
+		return specs
+	}
+
+	var specs []ImpactedSpec
+	dir := h.HistoryDir
+	if dir == "" {
+		dir = os.Getenv("TEST_IMPACT_HISTORY_DIR")
+	}
+	if dir != "" {
+		packages := h.Packages
+		if len(packages) == 0 {
+			packages = DefaultImpactPackages
+		}
+		budget := h.Budget
+		if budget == 0 {
+			budget = DefaultImpactBudget
+			if env := os.Getenv("TEST_IMPACT_BUDGET"); env != "" {
+				var err error
+				if budget, err = strconv.Atoi(env); err != nil {
+					klog.Errorf("invalid TEST_IMPACT_BUDGET %q, using %d", env, DefaultImpactBudget)
+					budget = DefaultImpactBudget
+				}
+			}
+		}
+		// The test history only improves the selection, it must not fail the job
+		if history, err := LoadTestHistory(dir); err != nil {
+			klog.Errorf("ignoring the test history: %+v", err)
+		} else {
+			var changed []string
+			for _, f := range rctx.DiffFiles {
+				changed = append(changed, f.Name)
+			}
+			specs = BuildImpactMap(history, packages).Impacted(changed, packages, budget)
+		}
+	}
+	if rctx.RuleData == nil {
+		rctx.RuleData = map[string]any{}
+	}
+	_ = rctx.AddRuleData(impactRuleDataKey, specs)
+
+	return specs
+}
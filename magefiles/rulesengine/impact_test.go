@@ -0,0 +1,87 @@
+package rulesengine
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJUnitReport(t *testing.T) {
+	data, err := os.ReadFile("testdata/history/run-1/e2e-report.xml")
+	assert.NoError(t, err)
+
+	results, err := ParseJUnitReport(data)
+	assert.NoError(t, err)
+	assert.Equal(t, []SpecResult{
+		{
+			Name:   "[build-service-suite Build service E2E tests] creates a PipelineRun",
+			Labels: []string{"build-service", "github"},
+			Files:  []string{"tests/build/build.go"},
+			Failed: true,
+		},
+		{
+			Name:   "[konflux-demo-suite] deploys the application",
+			Labels: []string{"konflux", "demo"},
+			Files:  []string{"tests/konflux-demo/konflux-demo.go"},
+			Failed: true,
+		},
+	}, results)
+}
+
+func TestImpactMap(t *testing.T) {
+	history, err := LoadTestHistory("testdata/history")
+	assert.NoError(t, err)
+	assert.Len(t, history, 3)
+	assert.Equal(t, []string{"pkg/clients/tekton/pipelineruns.go"}, history[0].ChangedFiles)
+
+	m := BuildImpactMap(history, DefaultImpactPackages)
+	assert.InDelta(t, 2.0/3.0, m.Specs["[konflux-demo-suite] deploys the application"].Flakiness(), 0.001)
+
+	// the konflux-demo spec fails as often without tekton changes, it is flaky and not pulled in
+	impacted := m.Impacted([]string{"pkg/clients/tekton/taskruns.go"}, DefaultImpactPackages, 5)
+	assert.Len(t, impacted, 1)
+	assert.Equal(t, "[build-service-suite Build service E2E tests] creates a PipelineRun", impacted[0].Name)
+	assert.Equal(t, "pkg/clients/tekton", impacted[0].Package)
+	assert.Equal(t, 1.0, impacted[0].Score)
+
+	assert.Empty(t, m.Impacted([]string{"pkg/clients/tekton/taskruns.go"}, DefaultImpactPackages, 0))
+	assert.Empty(t, m.Impacted([]string{"pkg/clients/github/repositories.go"}, DefaultImpactPackages, 5))
+}
+
+func TestHistoricalImpact(t *testing.T) {
+	impact := &HistoricalImpact{HistoryDir: "testdata/history"}
+
+	newRuleCtx := func() *RuleCtx {
+		rctx := NewRuleCtx()
+		rctx.DiffFiles = Files{{Name: "pkg/clients/tekton/taskruns.go", Status: "M"}}
+		return rctx
+	}
+
+	// focused run
+	rctx := newRuleCtx()
+	ok, err := impact.Check(rctx)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	rctx.FocusFiles = []string{"tests/integration-service/integration.go"}
+	assert.NoError(t, impact.Execute(rctx))
+	assert.Equal(t, []string{"tests/integration-service/integration.go", "tests/build/build.go"}, rctx.FocusFiles)
+
+	// run selected by labels
+	rctx = newRuleCtx()
+	rctx.LabelFilter = "!upgrade-create && !release-pipelines"
+	assert.NoError(t, impact.Execute(rctx))
+	assert.Equal(t, "(!upgrade-create && !release-pipelines) || (build-service && github)", rctx.LabelFilter)
+
+	// full suite
+	rctx = newRuleCtx()
+	assert.NoError(t, impact.Execute(rctx))
+	assert.Empty(t, rctx.FocusFiles)
+	assert.Empty(t, rctx.LabelFilter)
+
+	// without history
+	rctx = newRuleCtx()
+	ok, err = (&HistoricalImpact{}).Check(rctx)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
@@ -0,0 +1,299 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+const (
+	// SelectionReportJSONFile is the name of the JSON test selection report written by WriteFiles.
+	SelectionReportJSONFile = "test-selection-report.json"
+	// SelectionReportTreeFile is the name of the human readable test selection report written by WriteFiles.
+	SelectionReportTreeFile = "test-selection-report.txt"
+)
+
+// EvalNode is a rule or a conditional evaluated by the engine together with
+// its result, the changed files it looked at and, for rules, the RuleCtx
+// mutations done by its actions.
+type EvalNode struct {
+	Kind         string      `json:"kind"`
+	Name         string      `json:"name"`
+	Result       bool        `json:"result"`
+	Error        string      `json:"error,omitempty"`
+	Applied      bool        `json:"applied,omitempty"`
+	MatchedFiles []string    `json:"matchedFiles,omitempty"`
+	Mutations    []Mutation  `json:"mutations,omitempty"`
+	Children     []*EvalNode `json:"children,omitempty"`
+}
+
+// Mutation describes the change of a RuleCtx field done by the actions of a rule.
+type Mutation struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// SelectionReport is the evaluation trace of a RunRules/RunRulesOfCategory call.
+// It explains why a given set of suites was (or was not) selected.
+type SelectionReport struct {
+	Catalogs     []string    `json:"catalogs"`
+	RepoName     string      `json:"repoName,omitempty"`
+	JobType      string      `json:"jobType,omitempty"`
+	DiffFiles    []string    `json:"diffFiles"`
+	Rules        []*EvalNode `json:"rules"`
+	MatchedRules []string    `json:"matchedRules"`
+	SkippedRules []string    `json:"skippedRules,omitempty"`
+	FocusFiles   []string    `json:"focusFiles"`
+	LabelFilter  string      `json:"labelFilter"`
+}
+
+// evalTrace keeps the stack of nodes being evaluated while the engine runs.
+type evalTrace struct {
+	report *SelectionReport
+	stack  []*EvalNode
+}
+
+// Files filters have no access to the RuleCtx, so the engine points this
+// to the trace of the running evaluation to collect the matched files.
+// The engine is not meant to evaluate catalogs concurrently.
+var activeTrace *evalTrace
+
+func newSelectionReport(rctx *RuleCtx, catalogs []string) *SelectionReport {
+
+	report := &SelectionReport{Catalogs: catalogs, RepoName: rctx.RepoName, JobType: rctx.JobType, DiffFiles: []string{}}
+	for _, f := range rctx.DiffFiles {
+		report.DiffFiles = append(report.DiffFiles, f.Name)
+	}
+
+	return report
+}
+
+func (rctx *RuleCtx) startTrace(catalogs []string) {
+
+	rctx.SelectionReport = newSelectionReport(rctx, catalogs)
+	rctx.trace = &evalTrace{report: rctx.SelectionReport}
+	activeTrace = rctx.trace
+}
+
+func (rctx *RuleCtx) finishTrace() {
+
+	if rctx.SelectionReport != nil {
+		rctx.SelectionReport.FocusFiles = append([]string{}, rctx.FocusFiles...)
+		rctx.SelectionReport.LabelFilter = rctx.LabelFilter
+	}
+	if activeTrace == rctx.trace {
+		activeTrace = nil
+	}
+	rctx.trace = nil
+}
+
+// traceEnter records a new node as a child of the node being evaluated. It is a noop
+// when the evaluation is not traced, i.e. when a Rule is checked outside of the engine.
+func (rctx *RuleCtx) traceEnter(kind, name string) *EvalNode {
+
+	if rctx.trace == nil {
+		return nil
+	}
+	node := &EvalNode{Kind: kind, Name: name}
+	if len(rctx.trace.stack) == 0 {
+		rctx.trace.report.Rules = append(rctx.trace.report.Rules, node)
+	} else {
+		parent := rctx.trace.stack[len(rctx.trace.stack)-1]
+		parent.Children = append(parent.Children, node)
+	}
+	rctx.trace.stack = append(rctx.trace.stack, node)
+
+	return node
+}
+
+// traceResume makes an already recorded node the one being evaluated again.
+func (rctx *RuleCtx) traceResume(node *EvalNode) {
+
+	if rctx.trace == nil || node == nil {
+		return
+	}
+	rctx.trace.stack = append(rctx.trace.stack, node)
+}
+
+func (rctx *RuleCtx) traceExit(node *EvalNode, result bool, err error) {
+
+	if rctx.trace == nil || node == nil {
+		return
+	}
+	node.Result = result
+	if err != nil {
+		node.Error = err.Error()
+	}
+	rctx.trace.stack = rctx.trace.stack[:len(rctx.trace.stack)-1]
+}
+
+func (rctx *RuleCtx) traceMutations(before ruleCtxSnapshot) {
+
+	if rctx.trace == nil || len(rctx.trace.stack) == 0 {
+		return
+	}
+	node := rctx.trace.stack[len(rctx.trace.stack)-1]
+	node.Applied = true
+	node.Mutations = append(node.Mutations, before.diff(snapshotRuleCtx(rctx))...)
+}
+
+func traceMatchedFiles(files Files) {
+
+	if activeTrace == nil || len(activeTrace.stack) == 0 {
+		return
+	}
+	node := activeTrace.stack[len(activeTrace.stack)-1]
+	for _, f := range files {
+		node.MatchedFiles = dedupeAppend(node.MatchedFiles, f.Name)
+	}
+}
+
+type ruleCtxSnapshot struct {
+	focusFiles  []string
+	labelFilter string
+}
+
+func snapshotRuleCtx(rctx *RuleCtx) ruleCtxSnapshot {
+
+	return ruleCtxSnapshot{focusFiles: append([]string{}, rctx.FocusFiles...), labelFilter: rctx.LabelFilter}
+}
+
+func (s ruleCtxSnapshot) diff(after ruleCtxSnapshot) []Mutation {
+
+	var mutations []Mutation
+	if before, now := strings.Join(s.focusFiles, ","), strings.Join(after.focusFiles, ","); before != now {
+		mutations = append(mutations, Mutation{Field: "FocusFiles", Before: before, After: now})
+	}
+	if s.labelFilter != after.labelFilter {
+		mutations = append(mutations, Mutation{Field: "LabelFilter", Before: s.labelFilter, After: after.labelFilter})
+	}
+
+	return mutations
+}
+
+// conditionName returns a readable name for conditionals that do not implement fmt.Stringer.
+func conditionName(c any) string {
+
+	if s, ok := c.(fmt.Stringer); ok {
+		return s.String()
+	}
+	v := reflect.ValueOf(c)
+	if v.Kind() == reflect.Func {
+		if f := runtime.FuncForPC(v.Pointer()); f != nil {
+			name := f.Name()
+			return name[strings.LastIndex(name, "/")+1:]
+		}
+	}
+
+	return reflect.TypeOf(c).String()
+}
+
+// DescribedCondition attaches a human readable description to a conditional.
+// The description is what the selection report shows for it.
+type DescribedCondition struct {
+	Description string
+	Conditional Conditional
+}
+
+// Describe wraps c so the selection report shows desc instead of the function name.
+func Describe(desc string, c Conditional) Conditional {
+
+	return &DescribedCondition{Description: desc, Conditional: c}
+}
+
+func (d *DescribedCondition) String() string {
+
+	return d.Description
+}
+
+func (d *DescribedCondition) Check(rctx *RuleCtx) (bool, error) {
+
+	node := rctx.traceEnter("condition", d.Description)
+	var ok bool
+	var err error
+	// Call plain functions directly so they are not traced twice
+	if cf, isFunc := d.Conditional.(ConditionFunc); isFunc {
+		ok, err = cf(rctx)
+	} else {
+		ok, err = d.Conditional.Check(rctx)
+	}
+	rctx.traceExit(node, ok, err)
+
+	return ok, err
+}
+
+// JSON returns the report indented as JSON.
+func (r *SelectionReport) JSON() ([]byte, error) {
+
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// Tree returns the report as a human readable tree.
+func (r *SelectionReport) Tree() string {
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Test selection for catalogs: %s\n", strings.Join(r.Catalogs, ", "))
+	fmt.Fprintf(&b, "Changed files: %s\n", strings.Join(r.DiffFiles, ", "))
+	for _, rule := range r.Rules {
+		writeNode(&b, rule, 0)
+	}
+	fmt.Fprintf(&b, "Matched rules: %s\n", strings.Join(r.MatchedRules, ", "))
+	if len(r.SkippedRules) != 0 {
+		fmt.Fprintf(&b, "Skipped rules: %s\n", strings.Join(r.SkippedRules, ", "))
+	}
+	fmt.Fprintf(&b, "Focus files: %s\n", strings.Join(r.FocusFiles, ", "))
+	fmt.Fprintf(&b, "Label filter: %s\n", r.LabelFilter)
+
+	return b.String()
+}
+
+func writeNode(b *strings.Builder, node *EvalNode, depth int) {
+
+	indent := strings.Repeat("    ", depth)
+	mark := "✗"
+	if node.Result {
+		mark = "✓"
+	}
+	fmt.Fprintf(b, "%s[%s] %s", indent, mark, node.Kind)
+	if node.Name != "" {
+		fmt.Fprintf(b, ": %s", node.Name)
+	}
+	if node.Applied {
+		b.WriteString(" (applied)")
+	}
+	if node.Error != "" {
+		fmt.Fprintf(b, " error: %s", node.Error)
+	}
+	b.WriteString("\n")
+	if len(node.MatchedFiles) != 0 {
+		fmt.Fprintf(b, "%s    files: %s\n", indent, strings.Join(node.MatchedFiles, ", "))
+	}
+	for _, m := range node.Mutations {
+		fmt.Fprintf(b, "%s    %s: %q -> %q\n", indent, m.Field, m.Before, m.After)
+	}
+	for _, child := range node.Children {
+		writeNode(b, child, depth+1)
+	}
+}
+
+// WriteFiles writes the JSON and the tree versions of the report into dir.
+func (r *SelectionReport) WriteFiles(dir string) error {
+
+	data, err := r.JSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal the test selection report: %+v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SelectionReportJSONFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write the test selection report: %+v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, SelectionReportTreeFile), []byte(r.Tree()), 0644); err != nil {
+		return fmt.Errorf("failed to write the test selection report: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package rulesengine
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectionReport(t *testing.T) {
+	buildFilesRule := Rule{Name: "Build test files",
+		Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return len(rctx.DiffFiles.FilterByDirGlob("tests/build/*.go")) != 0, nil
+		}),
+		Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+			for _, f := range rctx.DiffFiles.FilterByDirGlob("tests/build/*.go") {
+				rctx.FocusFiles = append(rctx.FocusFiles, f.Name)
+			}
+			return nil
+		})},
+	}
+	engine := RuleEngine{"tests": {"repo": RuleCatalog{
+		{Name: "Pkg files", Condition: Describe("pkg files changed", ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return len(rctx.DiffFiles.FilterByDirString("pkg/")) != 0, nil
+		})), Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+			rctx.LabelFilter = "all"
+			return nil
+		})}},
+		{Name: "Test files only", Condition: All{None{Describe("pkg files changed", ConditionFunc(func(rctx *RuleCtx) (bool, error) {
+			return len(rctx.DiffFiles.FilterByDirString("pkg/")) != 0, nil
+		}))}, Any{&buildFilesRule}}, Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+			rctx.LabelFilter = "build"
+			return nil
+		})}},
+	}}}
+
+	rctx := NewRuleCtx()
+	rctx.DiffFiles = Files{{Name: "tests/build/build.go", Status: "M"}}
+	assert.NoError(t, engine.RunRules(rctx, "tests", "repo"))
+
+	report := rctx.SelectionReport
+	assert.Equal(t, []string{"tests/repo"}, report.Catalogs)
+	assert.Equal(t, []string{"Test files only"}, report.MatchedRules)
+	assert.Equal(t, []string{"tests/build/build.go"}, report.FocusFiles)
+	assert.Equal(t, "build", report.LabelFilter)
+
+	assert.Len(t, report.Rules, 2)
+	assert.False(t, report.Rules[0].Result)
+	assert.Equal(t, "pkg files changed", report.Rules[0].Children[0].Name)
+
+	matched := report.Rules[1]
+	assert.True(t, matched.Result)
+	assert.True(t, matched.Applied)
+	assert.Equal(t, []Mutation{{Field: "LabelFilter", Before: "", After: "build"}}, matched.Mutations)
+
+	chained := matched.Children[0].Children[1].Children[0]
+	assert.Equal(t, "rule", chained.Kind)
+	assert.Equal(t, "Build test files", chained.Name)
+	assert.Equal(t, []string{"tests/build/build.go"}, chained.MatchedFiles)
+	assert.Equal(t, []Mutation{{Field: "FocusFiles", Before: "", After: "tests/build/build.go"}}, chained.Mutations)
+
+	tree := report.Tree()
+	assert.Contains(t, tree, "[✗] rule: Pkg files\n")
+	assert.Contains(t, tree, "[✓] rule: Test files only (applied)\n")
+	assert.Contains(t, tree, "        [✓] any\n")
+	assert.Contains(t, tree, "files: tests/build/build.go\n")
+	assert.Contains(t, tree, `LabelFilter: "" -> "build"`)
+
+	dir := t.TempDir()
+	assert.NoError(t, report.WriteFiles(dir))
+	data, err := os.ReadFile(filepath.Join(dir, SelectionReportJSONFile))
+	assert.NoError(t, err)
+	var decoded SelectionReport
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, report.MatchedRules, decoded.MatchedRules)
+	assert.FileExists(t, filepath.Join(dir, SelectionReportTreeFile))
+}
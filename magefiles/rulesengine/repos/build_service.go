@@ -33,16 +33,12 @@ var BuildServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Req
 		rctx.LabelFilter = BuildLabelFilter("build-service")
 		klog.Infof("setting test label filter: '%s'", rctx.LabelFilter)
 
-		if rctx.DryRun {
-			klog.Info("setting up env vars for deploying component image")
-			return nil
-		}
 		rctx.ComponentEnvVarPrefix = "BUILD_SERVICE"
 		// Option to execute the tests in Openshift CI
 		if os.Getenv("KONFLUX_CI") != "true" {
 			rctx.ComponentImageTag = "redhat-appstudio-build-service-image"
 		}
-		return SetEnvVarsForComponentImageDeployment(rctx)
+		return ExportComponentImageEnvVars(rctx)
 	})},
 }
 
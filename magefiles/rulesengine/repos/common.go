@@ -91,7 +91,9 @@ func ExecuteTestAction(rctx *rulesengine.RuleCtx) error {
 		klog.Error(err)
 	}
 	argsToRun = append(argsToRun, "./cmd", "--")
-	return sh.RunV("ginkgo", argsToRun...)
+	return rctx.Perform(rulesengine.SideEffectGinkgo, "ginkgo "+strings.Join(argsToRun, " "), func() error {
+		return sh.RunV("ginkgo", argsToRun...)
+	})
 
 }
 
@@ -144,7 +146,7 @@ func IsLoadTestJob(rctx *rulesengine.RuleCtx) (bool, error) {
 
 func IsSprayProxyHostSet(rctx *rulesengine.RuleCtx) (bool, error) {
 
-	if rctx.DryRun {
+	if rctx.NoSideEffects() {
 		klog.Info("checking if env var QE_SPRAYPROXY_HOST is set")
 		return true, nil
 	}
@@ -158,7 +160,7 @@ func IsSprayProxyHostSet(rctx *rulesengine.RuleCtx) (bool, error) {
 
 func IsSprayProxyTokenSet(rctx *rulesengine.RuleCtx) (bool, error) {
 
-	if rctx.DryRun {
+	if rctx.NoSideEffects() {
 		klog.Info("checking if env var QE_SPRAYPROXY_TOKEN is set")
 		return true, nil
 	}
@@ -171,15 +173,14 @@ func IsSprayProxyTokenSet(rctx *rulesengine.RuleCtx) (bool, error) {
 	return true, nil
 }
 
-func GitCheckoutRemoteBranch(remoteName, branchName string) error {
-	var git = sh.RunCmd("git")
+func GitCheckoutRemoteBranch(rctx *rulesengine.RuleCtx, remoteName, branchName string) error {
 	for _, arg := range [][]string{
 		{"remote", "add", remoteName, fmt.Sprintf("https://github.com/%s/e2e-tests.git", remoteName)},
 		{"fetch", remoteName},
 		{"checkout", branchName},
 		{"pull", "--rebase", "upstream", "main"},
 	} {
-		if err := git(arg...); err != nil {
+		if err := rctx.RunCmd("git", arg...); err != nil {
 			return fmt.Errorf("error when checkout out remote branch %s from remote %s: %v", branchName, remoteName, err)
 		}
 	}
@@ -206,7 +207,7 @@ func IsPRPairingRequired(repoForPairing string, remoteName string, branchName st
 
 func IsPrelightChecked(rctx *rulesengine.RuleCtx) (bool, error) {
 
-	if rctx.DryRun {
+	if rctx.NoSideEffects() {
 
 		klog.Info("All Environment Variables have been set!")
 		klog.Info("All tools and commands have been found!")
@@ -293,13 +294,37 @@ func retry(f func() error, attempts int, delay time.Duration) error {
 	return fmt.Errorf("reached maximum number of attempts (%d). error: %+v", attempts, err)
 }
 
+// Register the common conditionals, actions and rule chains so declarative
+// catalogs can reference them by name through `condition:` and `action:`.
+func init() {
+	rulesengine.RegisterAction(rulesengine.RunTestsActionName, rulesengine.ActionFunc(ExecuteTestAction))
+	rulesengine.RegisterAction("focus-historical-impact", &E2EHistoricalImpact)
+
+	rulesengine.RegisterConditional("periodic-job", rulesengine.ConditionFunc(IsPeriodicJob))
+	rulesengine.RegisterConditional("rehearse-job", rulesengine.ConditionFunc(IsRehearseJob))
+	rulesengine.RegisterConditional("load-test-job", rulesengine.ConditionFunc(IsLoadTestJob))
+	rulesengine.RegisterConditional("tekton-push-event", rulesengine.ConditionFunc(IsTektonPushEventType))
+	rulesengine.RegisterConditional("sprayproxy-required", rulesengine.ConditionFunc(IsSprayProxyRequired))
+	rulesengine.RegisterConditional("multi-platform-required", rulesengine.ConditionFunc(IsMultiPlatformConfigRequired))
+	rulesengine.RegisterConditional("historical-impact", &E2EHistoricalImpact)
+
+	rulesengine.RegisterConditional("prepare-e2e-branch", &PrepareBranchRule)
+	rulesengine.RegisterConditional("preflight-install-ginkgo", &PreflightInstallGinkgoRule)
+	rulesengine.RegisterConditional("install-konflux", &InstallKonfluxRule)
+	rulesengine.RegisterConditional("register-sprayproxy", &RegisterKonfluxToSprayProxyRule)
+	rulesengine.RegisterConditional("setup-multi-platform-tests", &SetupMultiPlatformTestsRule)
+	rulesengine.RegisterConditional("bootstrap-cluster", &BootstrapClusterRuleChain)
+	rulesengine.RegisterConditional("bootstrap-cluster-with-sprayproxy", &BootstrapClusterWithSprayProxyRuleChain)
+	rulesengine.RegisterConditional("infra-deployments-pr-pairing", &InfraDeploymentsPRPairingRule)
+}
+
 //Common Rules that can be used to chain into more specific repo rules
 
 var PrepareBranchRule = rulesengine.Rule{Name: "Prepare E2E branch for CI",
 	Description: "Checkout the e2e-tests repo for CI when the PR is paired with e2e-tests repo",
 	Condition: rulesengine.All{rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
 
-		if rctx.DryRun {
+		if rctx.NoSideEffects() {
 
 			if true {
 				klog.Infof("Found e2e-tests branch %s for author %s", rctx.PrBranchName, rctx.PrRemoteName)
@@ -312,21 +337,7 @@ var PrepareBranchRule = rulesengine.Rule{Name: "Prepare E2E branch for CI",
 		rulesengine.ConditionFunc(IsRehearseJob)}},
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
-		if rctx.DryRun {
-
-			for _, arg := range [][]string{
-				{"remote", "add", rctx.PrRemoteName, fmt.Sprintf("https://github.com/%s/e2e-tests.git", rctx.PrRemoteName)},
-				{"fetch", rctx.PrRemoteName},
-				{"checkout", rctx.PrCommitSha},
-				{"pull", "--rebase", "upstream", "main"},
-			} {
-				klog.Infof("git %s", arg)
-			}
-
-			return nil
-		}
-
-		return GitCheckoutRemoteBranch(rctx.PrRemoteName, rctx.PrBranchName)
+		return GitCheckoutRemoteBranch(rctx, rctx.PrRemoteName, rctx.PrBranchName)
 	})}}
 
 var PreflightInstallGinkgoRule = rulesengine.Rule{Name: "Preflight Check",
@@ -334,13 +345,7 @@ var PreflightInstallGinkgoRule = rulesengine.Rule{Name: "Preflight Check",
 	Condition:   rulesengine.ConditionFunc(IsPrelightChecked),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
-		if rctx.DryRun {
-			klog.Info("Installing Ginkgo Test Runner")
-			klog.Info("Running command: go install -mod=mod github.com/onsi/ginkgo/v2/ginkgo")
-			klog.Info("Ginkgo Installation Complete.")
-			return nil
-		}
-		return sh.RunV("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo")
+		return rctx.RunCmd("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo")
 	}),
 	},
 }
@@ -352,12 +357,9 @@ var InstallKonfluxRule = rulesengine.Rule{Name: "Install Konflux",
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
-		if rctx.DryRun {
-			klog.Info("Installing Konflux in Preview mode.")
-			klog.Info("Konflux Installation Complete.")
-			return nil
-		}
-		return retry(InstallKonflux, 2, 10*time.Second)
+		return rctx.Perform(rulesengine.SideEffectCluster, "install Konflux in preview mode", func() error {
+			return retry(InstallKonflux, 2, 10*time.Second)
+		})
 	}),
 	},
 }
@@ -369,20 +371,16 @@ var RegisterKonfluxToSprayProxyRule = rulesengine.Rule{Name: "Register SprayProx
 	},
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
-		if rctx.DryRun {
-			klog.Info("Registering Konflux to SprayProxy.")
-			klog.Info("Registration Complete.")
-			return nil
-		}
-
-		err := registerPacServer()
-		if err != nil {
-			os.Setenv(constants.SKIP_PAC_TESTS_ENV, "true")
-			if alertErr := HandleErrorWithAlert(fmt.Errorf("failed to register SprayProxy: %+v", err), slack.ErrorSeverityLevelError); alertErr != nil {
-				return alertErr
+		return rctx.Perform(rulesengine.SideEffectSprayProxy, "register the PaC server to SprayProxy", func() error {
+			err := registerPacServer()
+			if err != nil {
+				os.Setenv(constants.SKIP_PAC_TESTS_ENV, "true")
+				if alertErr := HandleErrorWithAlert(fmt.Errorf("failed to register SprayProxy: %+v", err), slack.ErrorSeverityLevelError); alertErr != nil {
+					return alertErr
+				}
 			}
-		}
-		return nil
+			return nil
+		})
 	}),
 	},
 }
@@ -394,13 +392,8 @@ var SetupMultiPlatformTestsRule = rulesengine.Rule{Name: "Setup multi-platform t
 	},
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
-		if rctx.DryRun {
-			klog.Info("Setting up multi platform tests.")
-			klog.Info("Multi platform tests configured.")
-			return nil
-		}
-
-		return SetupMultiPlatformTests()
+		return rctx.Perform(rulesengine.SideEffectRegistry, fmt.Sprintf("push buildah-remote pipeline bundles for %s and export %s_<PLATFORM>",
+			strings.Join(multiPlatforms, ", "), constants.CUSTOM_BUILDAH_REMOTE_PIPELINE_BUILD_BUNDLE_ENV), SetupMultiPlatformTests)
 	}),
 	},
 }
@@ -469,8 +462,10 @@ func HandleErrorWithAlert(err error, errLevel slack.ErrorSeverityLevel) error {
 	return nil
 }
 
+var multiPlatforms = []string{"linux/arm64", "linux/s390x", "linux/ppc64le"}
+
 func SetupMultiPlatformTests() error {
-	var platforms = []string{"linux/arm64", "linux/s390x", "linux/ppc64le"}
+	var platforms = multiPlatforms
 
 	klog.Infof("going to create new Tekton bundle remote-build for the purpose of testing multi-platform-controller PR")
 	var err error
@@ -546,6 +541,15 @@ func SetupMultiPlatformTests() error {
 	return nil
 }
 
+// ExportComponentImageEnvVars declares the export of the env vars used to deploy the component image built in the PR
+func ExportComponentImageEnvVars(rctx *rulesengine.RuleCtx) error {
+	prefix := rctx.ComponentEnvVarPrefix
+	return rctx.Perform(rulesengine.SideEffectEnv,
+		fmt.Sprintf("%[1]s_IMAGE_REPO, %[1]s_IMAGE_TAG, %[1]s_PR_OWNER, %[1]s_PR_SHA from COMPONENT_IMAGE", prefix), func() error {
+			return SetEnvVarsForComponentImageDeployment(rctx)
+		})
+}
+
 func SetEnvVarsForComponentImageDeployment(rctx *rulesengine.RuleCtx) error {
 	componentImage := os.Getenv("COMPONENT_IMAGE")
 
@@ -2,7 +2,6 @@ package repos
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -64,7 +63,7 @@ var TestFilesOnlyRule = rulesengine.Rule{Name: "E2E PR Test File Diff Execution"
 			&EcTestFileChangeRule,
 		},
 	},
-	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteTestAction)}}
+	Actions: []rulesengine.Action{rulesengine.ActionFunc(ExecuteFocusedTestAction)}}
 
 func CheckReleasePipelinesTestsChanged(rctx *rulesengine.RuleCtx) (bool, error) {
 
@@ -284,7 +283,7 @@ var EcTestFileChangeRule = rulesengine.Rule{Name: "E2E PR EC Test File Change Ru
 var InfraDeploymentsPRPairingRule = rulesengine.Rule{Name: "Set Required Settings for E2E Repo PR Paired Job",
 	Description: "Set up required infra-deployments variables for e2e-tests repo PR paired job before bootstrap ",
 	Condition: rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
-		if rctx.DryRun {
+		if rctx.NoSideEffects() {
 
 			if true {
 				klog.Infof("Found infra deployments branch %s for author %s", rctx.PrBranchName, rctx.PrRemoteName)
@@ -298,17 +297,11 @@ var InfraDeploymentsPRPairingRule = rulesengine.Rule{Name: "Set Required Setting
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 
-		if rctx.DryRun {
-
-			klog.Infof("Set INFRA_DEPLOYMENTS_ORG: %s", rctx.PrRemoteName)
-			klog.Infof("Set INFRA_DEPLOYMENTS_BRANCH: %s", rctx.PrBranchName)
-			return nil
-		}
-
 		klog.Infof("pairing with infra-deployments org %q and branch %q", rctx.PrRemoteName, rctx.PrBranchName)
-		os.Setenv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName)
-		os.Setenv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName)
-		return nil
+		if err := rctx.Setenv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName); err != nil {
+			return err
+		}
+		return rctx.Setenv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName)
 	})},
 }
 
@@ -334,6 +327,10 @@ var E2ERepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Set
 		rctx.RequiresSprayProxyRegistering = true
 		klog.Info("multi-platform tests and require sprayproxy registering are set to TRUE")
 
+		// In simulation the changed files are provided by the caller
+		if rctx.Simulate {
+			return nil
+		}
 		rctx.DiffFiles, err = utils.GetChangedFiles(rctx.RepoName)
 		return err
 	})},
@@ -371,6 +368,20 @@ func CheckCmdFilesChanged(rctx *rulesengine.RuleCtx) (bool, error) {
 
 }
 
+// E2EHistoricalImpact pulls in the specs which historically failed when the same pkg/clients or pkg/utils packages,
+// or the same test suite directories, changed
+var E2EHistoricalImpact = rulesengine.HistoricalImpact{Packages: append([]string{"tests/*"}, rulesengine.DefaultImpactPackages...)}
+
+// ExecuteFocusedTestAction runs the focused test files together with the specs historically failing on the same changes.
+// The default actions select nearly the full suite by negated labels, there the historical impact would add nothing.
+func ExecuteFocusedTestAction(rctx *rulesengine.RuleCtx) error {
+	if err := E2EHistoricalImpact.Execute(rctx); err != nil {
+		return err
+	}
+	return ExecuteTestAction(rctx)
+
+}
+
 func ExecuteDefaultTestAction(rctx *rulesengine.RuleCtx) error {
 	rctx.LabelFilter = "!upgrade-create && !upgrade-verify && !upgrade-cleanup && !release-pipelines"
 	return ExecuteTestAction(rctx)
@@ -32,16 +32,12 @@ var ImageControllerRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General
 		rctx.LabelFilter = "image-controller"
 		klog.Info("setting 'image-controller' test label")
 
-		if rctx.DryRun {
-			klog.Info("setting up env vars for deploying component image")
-			return nil
-		}
 		rctx.ComponentEnvVarPrefix = "IMAGE_CONTROLLER"
 		// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
 		if os.Getenv("KONFLUX_CI") != "true" {
 			rctx.ComponentImageTag = "redhat-appstudio-image-controller-image"
 		}
-		return SetEnvVarsForComponentImageDeployment(rctx)
+		return ExportComponentImageEnvVars(rctx)
 	})},
 }
 
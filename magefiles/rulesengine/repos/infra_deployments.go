@@ -1,10 +1,9 @@
 package repos
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/konflux-ci/e2e-tests/magefiles/rulesengine"
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+	"k8s.io/klog"
 )
 
 // Default Rule of repo infra-deployments running konflux-demo suite.
@@ -45,7 +44,7 @@ var InfraDeploymentsComponentsRule = rulesengine.Rule{Name: "Infra-deployments P
 		&InfraDeploymentsJVMComponentChangeRule},
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		// Adding "konflux" to the label filter when component is updated
-		AddLabelToLabelFilter(rctx, "konflux")
+		rulesengine.AddLabelFilter(rctx, "konflux")
 		return nil
 
 	}),
@@ -59,7 +58,7 @@ var InfraDeploymentsIntegrationComponentChangeRule = rulesengine.Rule{Name: "Inf
 
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-		AddLabelToLabelFilter(rctx, "integration-service")
+		rulesengine.AddLabelFilter(rctx, "integration-service")
 		return nil
 	})}}
 
@@ -70,7 +69,7 @@ var InfraDeploymentsEnterpriseControllerComponentChangeRule = rulesengine.Rule{N
 		return len(rctx.DiffFiles.FilterByDirGlob("components/enterprise-contract/**/*")) != 0, nil
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-		AddLabelToLabelFilter(rctx, "ec")
+		rulesengine.AddLabelFilter(rctx, "ec")
 		return nil
 	})}}
 
@@ -81,7 +80,7 @@ var InfraDeploymentsJVMComponentChangeRule = rulesengine.Rule{Name: "Infra-deplo
 		return len(rctx.DiffFiles.FilterByDirGlob("components/jvm-build-service/**/*")) != 0, nil
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-		AddLabelToLabelFilter(rctx, "jvm-build-service")
+		rulesengine.AddLabelFilter(rctx, "jvm-build-service")
 		return nil
 	})}}
 
@@ -92,7 +91,7 @@ var InfraDeploymentsImageControllerComponentChangeRule = rulesengine.Rule{Name:
 		return len(rctx.DiffFiles.FilterByDirGlob("components/image-controller/**/*")) != 0, nil
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-		AddLabelToLabelFilter(rctx, "image-controller")
+		rulesengine.AddLabelFilter(rctx, "image-controller")
 		return nil
 	})}}
 
@@ -103,7 +102,7 @@ var InfraDeploymentsMultiPlatformComponentChangeRule = rulesengine.Rule{Name: "I
 		return len(rctx.DiffFiles.FilterByDirGlob("components/multi-platform-controller/**/*")) != 0, nil
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-		AddLabelToLabelFilter(rctx, "multi-platform")
+		rulesengine.AddLabelFilter(rctx, "multi-platform")
 		return nil
 	})}}
 
@@ -114,7 +113,7 @@ var InfraDeploymentsBuildTemplatesComponentChangeRule = rulesengine.Rule{Name: "
 	}),
 	Actions: []rulesengine.Action{
 		rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-			AddLabelToLabelFilter(rctx, "build-templates")
+			rulesengine.AddLabelFilter(rctx, "build-templates")
 			return nil
 		}),
 	},
@@ -128,7 +127,7 @@ var InfraDeploymentsBuildServiceComponentChangeRule = rulesengine.Rule{Name: "In
 	}),
 	Actions: []rulesengine.Action{
 		rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-			AddLabelToLabelFilter(rctx, "build-service")
+			rulesengine.AddLabelFilter(rctx, "build-service")
 			return nil
 		}),
 	},
@@ -141,7 +140,7 @@ var InfraDeploymentsPipelineServiceComponentChangeRule = rulesengine.Rule{Name:
 	}),
 	Actions: []rulesengine.Action{
 		rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-			AddLabelToLabelFilter(rctx, "pipeline-service")
+			rulesengine.AddLabelFilter(rctx, "pipeline-service")
 			return nil
 		}),
 	},
@@ -154,19 +153,84 @@ var InfraDeploymentsReleaseServiceComponentChangeRule = rulesengine.Rule{Name: "
 		return len(rctx.DiffFiles.FilterByDirGlob("components/release/**/*")) != 0, nil
 	}),
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
-		AddLabelToLabelFilter(rctx, "release-service")
+		rulesengine.AddLabelFilter(rctx, "release-service")
 		return nil
 	})}}
 
 var InfraDeploymentsRulesCatalog = rulesengine.RuleCatalog{InfraDeploymentsDefaultRule, InfraDeploymentsComponentsRule}
 
-// AddLabelToLabelFilter ensures the given label is added to the LabelFilter of rctx
-func AddLabelToLabelFilter(rctx *rulesengine.RuleCtx, label string) {
-	if !strings.Contains(rctx.LabelFilter, label) {
-		if rctx.LabelFilter == "" {
-			rctx.LabelFilter = label
-		} else {
-			rctx.LabelFilter = fmt.Sprintf("%s,%s", rctx.LabelFilter, label)
+var InfraDeploymentsCIChainCatalog = rulesengine.RuleCatalog{InfraDeploymentsCIRuleChain}
+
+// InfraDeploymentsCIRuleChain bootstraps the cluster from the infra-deployments PR and runs the test suites of the changed components
+var InfraDeploymentsCIRuleChain = rulesengine.Rule{Name: "Infra-deployments repo CI Workflow Rule Chain",
+	Description: "Execute the full workflow for infra-deployments repo in CI",
+	Condition: rulesengine.All{
+		&InfraDeploymentsRepoSetDefaultSettingsRule,
+		&PreflightInstallGinkgoRule,
+		&BootstrapClusterRuleChain,
+		rulesengine.Any{&InfraDeploymentsDefaultRule, &InfraDeploymentsComponentsRule},
+	},
+}
+
+var InfraDeploymentsRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General Required Settings for infra-deployments repository jobs",
+	Description: "Install Konflux from the infra-deployments PR branch and set multiplatform and SprayProxy settings from the changed components before bootstrap",
+	Condition: rulesengine.Any{
+		IsInfraDeploymentsRepoPR,
+	},
+	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
+		// Konflux gets installed with the overlays of the PR
+		klog.Infof("installing Konflux from infra-deployments org %q and branch %q", rctx.PrRemoteName, rctx.PrBranchName)
+		if err := rctx.Setenv("INFRA_DEPLOYMENTS_ORG", rctx.PrRemoteName); err != nil {
+			return err
+		}
+		if err := rctx.Setenv("INFRA_DEPLOYMENTS_BRANCH", rctx.PrBranchName); err != nil {
+			return err
+		}
+
+		// In simulation the changed files are provided by the caller
+		if !rctx.Simulate {
+			diffFiles, err := utils.GetChangedFiles(rctx.RepoName)
+			if err != nil {
+				return err
+			}
+			rctx.DiffFiles = diffFiles
+		}
+
+		rctx.RequiresMultiPlatformTests = changesAnyDir(rctx, infraDeploymentsMultiPlatformDirs)
+		// The konflux-demo suite run for the files outside of the components needs SprayProxy too
+		rctx.RequiresSprayProxyRegistering = changesAnyDir(rctx, infraDeploymentsSprayProxyDirs) ||
+			len(rctx.DiffFiles.FilterByDirGlob("components/**/*")) < len(rctx.DiffFiles)
+		klog.Infof("multi-platform tests set to %t and require sprayproxy registering set to %t", rctx.RequiresMultiPlatformTests, rctx.RequiresSprayProxyRegistering)
+		return nil
+	})},
+}
+
+// infraDeploymentsMultiPlatformDirs are the components whose test suites build on the multi-platform controller
+var infraDeploymentsMultiPlatformDirs = []string{
+	"components/multi-platform-controller/**/*",
+	"components/build-service/**/*",
+}
+
+// infraDeploymentsSprayProxyDirs are the components whose test suites get PipelineRuns triggered by PaC webhooks
+var infraDeploymentsSprayProxyDirs = []string{
+	"components/build-service/**/*",
+	"components/image-controller/**/*",
+	"components/integration/**/*",
+	"components/pipeline-service/**/*",
+	"components/release/**/*",
+}
+
+// changesAnyDir tells whether any of the changed files matches one of the directory globs
+func changesAnyDir(rctx *rulesengine.RuleCtx, dirs []string) bool {
+	for _, dir := range dirs {
+		if len(rctx.DiffFiles.FilterByDirGlob(dir)) != 0 {
+			return true
 		}
 	}
+	return false
 }
+
+var IsInfraDeploymentsRepoPR = rulesengine.ConditionFunc(func(rctx *rulesengine.RuleCtx) (bool, error) {
+	klog.Info("checking if repository is infra-deployments")
+	return rctx.RepoName == "infra-deployments", nil
+})
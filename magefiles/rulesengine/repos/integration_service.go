@@ -32,16 +32,12 @@ var IntegrationServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "Gener
 		rctx.LabelFilter = "integration-service"
 		klog.Info("setting 'integration-service' test label")
 
-		if rctx.DryRun {
-			klog.Info("setting up env vars for deploying component image")
-			return nil
-		}
 		rctx.ComponentEnvVarPrefix = "INTEGRATION_SERVICE"
 		// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
 		if os.Getenv("KONFLUX_CI") != "true" {
 			rctx.ComponentImageTag = "redhat-appstudio-integration-service-image"
 		}
-		return SetEnvVarsForComponentImageDeployment(rctx)
+		return ExportComponentImageEnvVars(rctx)
 	})},
 }
 
@@ -30,10 +30,6 @@ var ReleaseServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General R
 		rctx.LabelFilter = "release-service"
 		klog.Info("setting 'release-service' test label")
 
-		if rctx.DryRun {
-			klog.Info("setting up env vars for deploying component image")
-			return nil
-		}
 		rctx.ComponentEnvVarPrefix = "RELEASE_SERVICE"
 		// TODO keep only "KONFLUX_CI" option once we migrate off openshift-ci
 
@@ -41,8 +37,10 @@ var ReleaseServiceRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "General R
 			rctx.ComponentImageTag = "redhat-appstudio-release-service-image"
 		}
 		//This is env variable is specified for release service
-		os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), "development")
-		return SetEnvVarsForComponentImageDeployment(rctx)
+		if err := rctx.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), "development"); err != nil {
+			return err
+		}
+		return ExportComponentImageEnvVars(rctx)
 	})},
 }
 
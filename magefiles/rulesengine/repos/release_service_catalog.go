@@ -2,7 +2,6 @@ package repos
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -51,7 +50,7 @@ var ReleaseServiceCatalogRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "Ge
 	Actions: []rulesengine.Action{rulesengine.ActionFunc(func(rctx *rulesengine.RuleCtx) error {
 		// The job may come from different ITS
 		if strings.Contains(rctx.JobName, "konflux-e2e-tests-catalog") {
-			testcases, err := selectReleasePipelinesTestCases(rctx.PrNum)
+			testcases, err := selectReleasePipelinesTestCases(rctx)
 			if err != nil {
 				rctx.LabelFilter = "release-pipelines"
 				klog.Errorf("an error occurred in selectReleasePipelinesTestCases: %s", err)
@@ -69,32 +68,37 @@ var ReleaseServiceCatalogRepoSetDefaultSettingsRule = rulesengine.Rule{Name: "Ge
 		}
 		klog.Info("setting test label for release-pipelines: ", rctx.LabelFilter)
 
-		if rctx.DryRun {
-			klog.Info("setting up env vars for deploying component image")
-			return nil
-		}
 		rctx.ComponentEnvVarPrefix = "RELEASE_SERVICE"
 
 		//This is env variable is specified for release service catalog
-		os.Setenv(fmt.Sprintf("%s_CATALOG_URL", rctx.ComponentEnvVarPrefix), fmt.Sprintf("https://github.com/%s/%s", rctx.PrRemoteName, rctx.RepoName))
+		var envs [][2]string
+		envs = append(envs, [2]string{fmt.Sprintf("%s_CATALOG_URL", rctx.ComponentEnvVarPrefix), fmt.Sprintf("https://github.com/%s/%s", rctx.PrRemoteName, rctx.RepoName)})
 		if rctx.PrRemoteName == "konflux-ci" {
-			os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrBranchName)
+			envs = append(envs, [2]string{fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrBranchName})
 		} else {
-			os.Setenv(fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrCommitSha)
+			envs = append(envs, [2]string{fmt.Sprintf("%s_CATALOG_REVISION", rctx.ComponentEnvVarPrefix), rctx.PrCommitSha})
 		}
 
 		// Failed at https://github.com/redhat-appstudio/infra-deployments/blob/2228e063a7fd8af4a95b24bb13ce7360cdc229f0/hack/preview.sh#L293C16-L293C38
 		//os.Setenv("DEPLOY_ONLY", "application-api dev-sso enterprise-contract has pipeline-service integration internal-services release")
 
 		if rctx.IsPaired && !strings.Contains(rctx.JobName, "rehearse") {
-			os.Setenv(fmt.Sprintf("%s_IMAGE_REPO", rctx.ComponentEnvVarPrefix),
-				"quay.io/redhat-user-workloads/rhtap-release-2-tenant/release-service/release-service")
-			pairedSha := GetPairedCommitSha("release-service", rctx)
+			envs = append(envs, [2]string{fmt.Sprintf("%s_IMAGE_REPO", rctx.ComponentEnvVarPrefix),
+				"quay.io/redhat-user-workloads/rhtap-release-2-tenant/release-service/release-service"})
+			var pairedSha string
+			if !rctx.NoSideEffects() {
+				pairedSha = GetPairedCommitSha("release-service", rctx)
+			}
 			if pairedSha != "" {
-				os.Setenv(fmt.Sprintf("%s_IMAGE_TAG", rctx.ComponentEnvVarPrefix), fmt.Sprintf("on-pr-%s", pairedSha))
+				envs = append(envs, [2]string{fmt.Sprintf("%s_IMAGE_TAG", rctx.ComponentEnvVarPrefix), fmt.Sprintf("on-pr-%s", pairedSha)})
+			}
+			envs = append(envs, [2]string{fmt.Sprintf("%s_PR_OWNER", rctx.ComponentEnvVarPrefix), rctx.PrRemoteName})
+			envs = append(envs, [2]string{fmt.Sprintf("%s_PR_SHA", rctx.ComponentEnvVarPrefix), pairedSha})
+		}
+		for _, env := range envs {
+			if err := rctx.Setenv(env[0], env[1]); err != nil {
+				return err
 			}
-			os.Setenv(fmt.Sprintf("%s_PR_OWNER", rctx.ComponentEnvVarPrefix), rctx.PrRemoteName)
-			os.Setenv(fmt.Sprintf("%s_PR_SHA", rctx.ComponentEnvVarPrefix), pairedSha)
 		}
 		return nil
 	})},
@@ -111,6 +115,9 @@ var isRehearse = func(rctx *rulesengine.RuleCtx) (bool, error) {
 }
 
 var isPaired = func(rctx *rulesengine.RuleCtx) (bool, error) {
+	if rctx.NoSideEffects() {
+		return rctx.IsPaired, nil
+	}
 	rctx.IsPaired = IsPRPairingRequired("release-service", rctx.PrRemoteName, rctx.PrBranchName)
 	return rctx.IsPaired, nil
 }
@@ -125,7 +132,13 @@ func ExecuteReleaseCatalogAction(rctx *rulesengine.RuleCtx) error {
 	return ExecuteTestAction(rctx)
 }
 
-func selectReleasePipelinesTestCases(prNum int) (string, error) {
+func selectReleasePipelinesTestCases(rctx *rulesengine.RuleCtx) (string, error) {
+	prNum := rctx.PrNum
+	// The lookup runs a script calling the GitHub API, which a simulation must not reach out to,
+	// so in simulation no test cases are selected and the caller gets an error instead
+	if rctx.Simulate {
+		return "", fmt.Errorf("the release pipelines changed in PR %d are not looked up in simulation", prNum)
+	}
 	command := fmt.Sprintf("%s %s %d", "magefiles/rulesengine/scripts/find_release_pipelines_from_pr.sh", "konflux-ci/release-service-catalog", prNum)
 	cmd := exec.Command("bash", "-c", command)
 	output, err := cmd.CombinedOutput()
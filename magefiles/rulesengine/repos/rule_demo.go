@@ -14,7 +14,7 @@ import (
 // But really we would actually register the real function with minor tweaks if we opt into framework
 var isPreflightCheck = func(rctx *rulesengine.RuleCtx) (bool, error) {
 
-	if rctx.DryRun {
+	if rctx.NoSideEffects() {
 
 		klog.Info("All Environment Variables have been set!")
 		klog.Info("All tools and commands have been found!")
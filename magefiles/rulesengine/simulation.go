@@ -0,0 +1,92 @@
+package rulesengine
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/magefile/mage/sh"
+	"k8s.io/klog"
+)
+
+type SideEffectKind string
+
+const (
+	SideEffectCommand    SideEffectKind = "command"
+	SideEffectEnv        SideEffectKind = "env"
+	SideEffectSprayProxy SideEffectKind = "sprayproxy"
+	SideEffectGinkgo     SideEffectKind = "ginkgo"
+	SideEffectCluster    SideEffectKind = "cluster"
+	SideEffectRegistry   SideEffectKind = "registry"
+)
+
+// SideEffect is something an action does outside of the RuleCtx,
+// i.e. running a command, exporting an env var or registering to SprayProxy.
+type SideEffect struct {
+	Rule        string         `json:"rule"`
+	Kind        SideEffectKind `json:"kind"`
+	Description string         `json:"description"`
+}
+
+// SimulationPlan is the ordered list of side effects the matched rules would have
+// performed. It is recorded instead of executing them when RuleCtx.Simulate is set.
+type SimulationPlan struct {
+	Effects []SideEffect `json:"effects"`
+}
+
+// String returns the plan in a stable line based format suited to be diffed against a golden file.
+func (p *SimulationPlan) String() string {
+
+	var b strings.Builder
+	for _, e := range p.Effects {
+		fmt.Fprintf(&b, "%s | %s | %s\n", e.Rule, e.Kind, e.Description)
+	}
+
+	return b.String()
+}
+
+// NoSideEffects reports whether conditions and actions must not reach out
+// to external systems (GitHub, the cluster, ...), which is the case in dry run and simulation.
+func (rctx *RuleCtx) NoSideEffects() bool {
+
+	return rctx.DryRun || rctx.Simulate
+}
+
+// Perform declares a side effect of the running action. In simulation the side effect
+// is recorded into the plan, in dry run it is only logged, otherwise fn is executed.
+func (rctx *RuleCtx) Perform(kind SideEffectKind, description string, fn func() error) error {
+
+	if rctx.Simulate {
+		if rctx.Plan == nil {
+			rctx.Plan = &SimulationPlan{}
+		}
+		rctx.Plan.Effects = append(rctx.Plan.Effects, SideEffect{Rule: rctx.currentRule, Kind: kind, Description: description})
+		klog.Infof("Simulation: %s %s", kind, description)
+		return nil
+	}
+	if rctx.DryRun {
+		klog.Infof("DryRun: %s %s", kind, description)
+		return nil
+	}
+	if fn == nil {
+		return nil
+	}
+
+	return fn()
+}
+
+// RunCmd runs the command with its output sent to stdout, unless simulating or in dry run.
+func (rctx *RuleCtx) RunCmd(cmd string, args ...string) error {
+
+	return rctx.Perform(SideEffectCommand, strings.TrimSpace(cmd+" "+strings.Join(args, " ")), func() error {
+		return sh.RunV(cmd, args...)
+	})
+}
+
+// Setenv exports the env var, unless simulating or in dry run.
+func (rctx *RuleCtx) Setenv(key, value string) error {
+
+	return rctx.Perform(SideEffectEnv, fmt.Sprintf("%s=%s", key, value), func() error {
+		return os.Setenv(key, value)
+	})
+}
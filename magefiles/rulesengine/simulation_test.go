@@ -0,0 +1,104 @@
+package rulesengine
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+// assertGolden compares got with the content of the golden file, run the tests with -update to regenerate it.
+func assertGolden(t *testing.T, golden, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", golden)
+	if *updateGolden {
+		assert.NoError(t, os.WriteFile(path, []byte(got), 0644))
+	}
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), got)
+}
+
+func simulationCatalog() RuleCatalog {
+	install := Rule{Name: "Install",
+		Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return true, nil }),
+		Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+			return rctx.Perform(SideEffectCluster, "install Konflux in preview mode", func() error {
+				return os.ErrPermission
+			})
+		})},
+	}
+	register := Rule{Name: "Register SprayProxy",
+		Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return rctx.RequiresSprayProxyRegistering, nil }),
+		Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+			return rctx.Perform(SideEffectSprayProxy, "register the PaC server to SprayProxy", nil)
+		})},
+	}
+
+	return RuleCatalog{
+		{Name: "Settings",
+			Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return rctx.RepoName == "repo", nil }),
+			Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+				return rctx.Setenv("SIMULATION_TEST_ORG", rctx.PrRemoteName)
+			})},
+		},
+		{Name: "Bootstrap",
+			Condition: All{&install, &register},
+			Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+				return rctx.RunCmd("go", "install", "-mod=mod", "github.com/onsi/ginkgo/v2/ginkgo")
+			})},
+		},
+		{Name: "Run tests",
+			Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return true, nil }),
+			Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+				return rctx.Perform(SideEffectGinkgo, "ginkgo --label-filter=build ./cmd --", nil)
+			})},
+		},
+	}
+}
+
+func TestSimulationPlan(t *testing.T) {
+	engine := RuleEngine{"ci": {"repo": simulationCatalog()}}
+
+	rctx := NewRuleCtx()
+	rctx.Simulate = true
+	rctx.RepoName = "repo"
+	rctx.PrRemoteName = "author"
+	rctx.RequiresSprayProxyRegistering = true
+
+	assert.NoError(t, engine.RunRules(rctx, "ci", "repo"))
+	assert.Empty(t, os.Getenv("SIMULATION_TEST_ORG"))
+	assert.Equal(t, []string{"Settings", "Bootstrap", "Run tests"}, rctx.SelectionReport.MatchedRules)
+	assertGolden(t, "simulation_plan.golden", rctx.Plan.String())
+}
+
+func TestDryRunAppliesAllMatchedRules(t *testing.T) {
+	applied := []string{}
+	rule := func(name string) Rule {
+		return Rule{Name: name,
+			Condition: ConditionFunc(func(rctx *RuleCtx) (bool, error) { return true, nil }),
+			Actions: []Action{ActionFunc(func(rctx *RuleCtx) error {
+				applied = append(applied, name)
+				rctx.FocusFiles = append(rctx.FocusFiles, name+".go")
+				if err := rctx.Perform(SideEffectGinkgo, "run "+name, func() error { return fmt.Errorf("%s executed in dry run", name) }); err != nil {
+					return err
+				}
+				return rctx.Setenv("DRY_RUN_TEST_ENV", name)
+			})},
+		}
+	}
+	engine := RuleEngine{"tests": {"repo": RuleCatalog{rule("first"), rule("second")}}}
+
+	rctx := NewRuleCtx()
+	rctx.DryRun = true
+	assert.NoError(t, engine.RunRules(rctx, "tests", "repo"))
+	assert.Equal(t, []string{"first", "second"}, applied)
+	assert.Equal(t, []string{"first.go", "second.go"}, rctx.FocusFiles)
+	assert.Empty(t, os.Getenv("DRY_RUN_TEST_ENV"))
+	assert.Nil(t, rctx.Plan)
+}
@@ -2,6 +2,7 @@ package rulesengine
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -51,6 +52,7 @@ func (e *RuleEngine) ListCatalogsByCategory(cat string) (string, error) {
 func (e *RuleEngine) RunRules(rctx *RuleCtx, args ...string) error {
 
 	var fullCatalogs RuleCatalog
+	var catalogs []string
 	foundCat := false
 	foundCtl := false
 	for k, v := range *e {
@@ -68,6 +70,7 @@ func (e *RuleEngine) RunRules(rctx *RuleCtx, args ...string) error {
 						continue
 					}
 					fullCatalogs = append(fullCatalogs, v...)
+					catalogs = append(catalogs, fmt.Sprintf("%s/%s", args[0], k))
 					foundCtl = true
 					klog.Infof("Loading the catalog for, %s, from category, %s", args[1], args[0])
 					break
@@ -75,14 +78,16 @@ func (e *RuleEngine) RunRules(rctx *RuleCtx, args ...string) error {
 				} else {
 					klog.Infof("Loading the catalogs for category %s", args[0])
 					fullCatalogs = append(fullCatalogs, v...)
+					catalogs = append(catalogs, fmt.Sprintf("%s/%s", args[0], k))
 
 				}
 
 			}
 		} else {
-			for _, v := range v {
+			for n, c := range v {
 
-				fullCatalogs = append(fullCatalogs, v...)
+				fullCatalogs = append(fullCatalogs, c...)
+				catalogs = append(catalogs, fmt.Sprintf("%s/%s", k, n))
 			}
 		}
 	}
@@ -95,21 +100,23 @@ func (e *RuleEngine) RunRules(rctx *RuleCtx, args ...string) error {
 		return fmt.Errorf("%s is not a catalog registered in the engine", args[1])
 	}
 
-	return e.runLoadedCatalog(fullCatalogs, rctx)
+	return e.runLoadedCatalog(fullCatalogs, rctx, catalogs)
 
 }
 
 func (e *RuleEngine) RunRulesOfCategory(cat string, rctx *RuleCtx) error {
 
 	var fullCatalogs RuleCatalog
+	var catalogs []string
 	found := false
 	for k, v := range *e {
 
 		if k == cat {
 			found = true
-			for _, v := range v {
+			for n, c := range v {
 
-				fullCatalogs = append(fullCatalogs, v...)
+				fullCatalogs = append(fullCatalogs, c...)
+				catalogs = append(catalogs, fmt.Sprintf("%s/%s", k, n))
 			}
 		}
 	}
@@ -118,32 +125,50 @@ func (e *RuleEngine) RunRulesOfCategory(cat string, rctx *RuleCtx) error {
 		return fmt.Errorf("%s is not a category registered in the engine", cat)
 	}
 
-	return e.runLoadedCatalog(fullCatalogs, rctx)
+	return e.runLoadedCatalog(fullCatalogs, rctx, catalogs)
 
 }
 
-func (e *RuleEngine) runLoadedCatalog(loaded RuleCatalog, rctx *RuleCtx) error {
+// runLoadedCatalog evaluates and applies the loaded rules. The evaluation trace of every
+// rule is recorded into rctx.SelectionReport, which is available once the run returns.
+func (e *RuleEngine) runLoadedCatalog(loaded RuleCatalog, rctx *RuleCtx, catalogs []string) error {
+
+	sort.Strings(catalogs)
+	rctx.startTrace(catalogs)
+	defer rctx.finishTrace()
+	if rctx.Simulate {
+		rctx.Plan = &SimulationPlan{}
+	}
 
 	var matched RuleCatalog
-	for _, rule := range loaded {
+	var matchedNodes []*EvalNode
+	rules := byPriority(loaded)
+	for i, rule := range rules {
+		node := rctx.traceEnter("rule", rule.Name)
 		ok, err := rule.Eval(rctx)
+		rctx.traceExit(node, ok, err)
 		if err != nil {
 			return err
 		}
+		if !ok {
+			continue
+		}
+		rctx.SelectionReport.MatchedRules = append(rctx.SelectionReport.MatchedRules, rule.Name)
 		// In most cases, a rule chain has no action to execute
 		// since a majority of the actions are encapsulated
 		// within the rules that compose the chain.
-		if len(rule.Actions) == 0 {
-			// In case the rule chain condition was evaluated to true,
-			// it means that the rule was applied, so stop iterating over next catalog rules.
-			// Otherwise continue
-			if ok {
-				return nil
-			}
-			continue
-		}
-		if ok {
+		if len(rule.Actions) != 0 {
 			matched = append(matched, rule)
+			matchedNodes = append(matchedNodes, node)
+		}
+		if rule.IsTerminal() {
+			for _, skipped := range rules[i+1:] {
+				rctx.SelectionReport.SkippedRules = append(rctx.SelectionReport.SkippedRules, skipped.Name)
+			}
+			if len(rules[i+1:]) != 0 {
+				klog.Infof("Rule %q is terminal, skipping the evaluation of the next %d rules.", rule.Name, len(rules[i+1:]))
+			}
+			break
 		}
 	}
 
@@ -155,32 +180,41 @@ func (e *RuleEngine) runLoadedCatalog(loaded RuleCatalog, rctx *RuleCtx) error {
 	klog.Infof("The following rules have matched %s.", matched.String())
 	if rctx.DryRun {
 
-		return e.dryRun(matched, rctx)
+		return e.dryRun(matched, matchedNodes, rctx)
 
 	}
 
-	return e.run(matched, rctx)
+	return e.run(matched, matchedNodes, rctx)
 
 }
 
-func (e *RuleEngine) dryRun(matched RuleCatalog, rctx *RuleCtx) error {
+func (e *RuleEngine) dryRun(matched RuleCatalog, nodes []*EvalNode, rctx *RuleCtx) error {
 
 	klog.Info("DryRun has been enabled will apply them in dry run mode")
-	for _, rule := range matched {
+	for i, rule := range matched {
 
-		return rule.DryRun(rctx)
+		rctx.traceResume(nodes[i])
+		err := rule.DryRun(rctx)
+		rctx.traceExit(nodes[i], true, err)
+
+		if err != nil {
+			klog.Errorf("Failed to dry run rule: %s", rule.String())
+			return err
+		}
 
 	}
 
 	return nil
 }
 
-func (e *RuleEngine) run(matched RuleCatalog, rctx *RuleCtx) error {
+func (e *RuleEngine) run(matched RuleCatalog, nodes []*EvalNode, rctx *RuleCtx) error {
 
 	klog.Info("Will apply rules")
-	for _, rule := range matched {
+	for i, rule := range matched {
 
+		rctx.traceResume(nodes[i])
 		err := rule.Apply(rctx)
+		rctx.traceExit(nodes[i], true, err)
 
 		if err != nil {
 			klog.Errorf("Failed to execute rule: %s", rule.String())
@@ -192,6 +226,10 @@ func (e *RuleEngine) run(matched RuleCatalog, rctx *RuleCtx) error {
 	return nil
 }
 
+// RuleCatalog is a set of rules run together. The rules are evaluated by descending Priority
+// until a matching terminal rule. A rule chain applies its sub-rules while it is evaluated, the
+// actions of the other matching rules are applied once the evaluation ended, in evaluation order.
+// So an additive rule evaluated before a terminal rule chain has its actions applied after the chain.
 type RuleCatalog []Rule
 
 func (rc *RuleCatalog) String() string {
@@ -217,6 +255,15 @@ type Any []Conditional
 
 func (a Any) Check(rctx *RuleCtx) (bool, error) {
 
+	node := rctx.traceEnter("any", "")
+	ok, err := a.check(rctx)
+	rctx.traceExit(node, ok, err)
+
+	return ok, err
+}
+
+func (a Any) check(rctx *RuleCtx) (bool, error) {
+
 	// Initial logic was to pass on the first
 	// eval to true but that might not be the
 	// case. So not eval all and as long as any
@@ -240,6 +287,15 @@ type All []Conditional
 
 func (a All) Check(rctx *RuleCtx) (bool, error) {
 
+	node := rctx.traceEnter("all", "")
+	ok, err := a.check(rctx)
+	rctx.traceExit(node, ok, err)
+
+	return ok, err
+}
+
+func (a All) check(rctx *RuleCtx) (bool, error) {
+
 	for _, c := range a {
 
 		ok, err := c.Check(rctx)
@@ -258,6 +314,15 @@ type None []Conditional
 
 func (a None) Check(rctx *RuleCtx) (bool, error) {
 
+	node := rctx.traceEnter("none", "")
+	ok, err := a.check(rctx)
+	rctx.traceExit(node, ok, err)
+
+	return ok, err
+}
+
+func (a None) check(rctx *RuleCtx) (bool, error) {
+
 	for _, c := range a {
 
 		ok, err := c.Check(rctx)
@@ -282,7 +347,12 @@ func (af ActionFunc) Execute(rctx *RuleCtx) error {
 type ConditionFunc func(rctx *RuleCtx) (bool, error)
 
 func (cf ConditionFunc) Check(rctx *RuleCtx) (bool, error) {
-	return cf(rctx)
+
+	node := rctx.traceEnter("condition", conditionName(cf))
+	ok, err := cf(rctx)
+	rctx.traceExit(node, ok, err)
+
+	return ok, err
 }
 
 type Rule struct {
@@ -290,6 +360,45 @@ type Rule struct {
 	Description string
 	Condition   Conditional
 	Actions     []Action
+	// Priority orders the evaluation of the rules of a catalog, higher first.
+	// Rules of the same priority are evaluated in declaration order.
+	Priority int
+	// Mode tells whether the catalog evaluation stops once the rule matched,
+	// see IsTerminal for the default.
+	Mode RuleMode
+}
+
+// RuleMode declares how a matching rule affects the evaluation of the next rules of a catalog.
+type RuleMode string
+
+const (
+	// RuleModeTerminal stops the evaluation of the catalog once the rule matched.
+	RuleModeTerminal RuleMode = "terminal"
+	// RuleModeAdditive lets the next rules of the catalog be evaluated and applied as well.
+	RuleModeAdditive RuleMode = "additive"
+)
+
+// IsTerminal reports whether the catalog evaluation stops once the rule matched.
+// Without a declared Mode, rule chains (rules without actions) are terminal
+// and rules with actions are additive.
+func (r *Rule) IsTerminal() bool {
+
+	if r.Mode == "" {
+		return len(r.Actions) == 0
+	}
+
+	return r.Mode == RuleModeTerminal
+}
+
+// byPriority returns the rules of the catalog in evaluation order.
+func byPriority(catalog RuleCatalog) RuleCatalog {
+
+	sorted := append(RuleCatalog{}, catalog...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority > sorted[j].Priority
+	})
+
+	return sorted
 }
 
 func (r *Rule) String() string {
@@ -310,9 +419,12 @@ func (r *Rule) Eval(rctx *RuleCtx) (bool, error) {
 
 func (r *Rule) Apply(rctx *RuleCtx) error {
 
+	defer rctx.enterRule(r.Name)()
 	for _, action := range r.Actions {
 
+		before := snapshotRuleCtx(rctx)
 		err := action.Execute(rctx)
+		rctx.traceMutations(before)
 		if err != nil {
 			return err
 		}
@@ -321,12 +433,18 @@ func (r *Rule) Apply(rctx *RuleCtx) error {
 	return nil
 }
 
+// DryRun executes the actions of the rule with rctx.DryRun set: they update the RuleCtx (LabelFilter,
+// FocusFiles, ...) exactly like Apply does, while the side effects they declare through RuleCtx.Perform,
+// RunCmd and Setenv are only logged.
 func (r *Rule) DryRun(rctx *RuleCtx) error {
 
 	rctx.DryRun = true
+	defer rctx.enterRule(r.Name)()
 	for _, action := range r.Actions {
 
+		before := snapshotRuleCtx(rctx)
 		err := action.Execute(rctx)
+		rctx.traceMutations(before)
 		if err != nil {
 			return err
 		}
@@ -337,6 +455,15 @@ func (r *Rule) DryRun(rctx *RuleCtx) error {
 
 func (r *Rule) Check(rctx *RuleCtx) (bool, error) {
 
+	node := rctx.traceEnter("rule", r.Name)
+	ok, err := r.check(rctx)
+	rctx.traceExit(node, ok, err)
+
+	return ok, err
+}
+
+func (r *Rule) check(rctx *RuleCtx) (bool, error) {
+
 	ok, err := r.Eval(rctx)
 	if err != nil {
 		return false, err
@@ -373,6 +500,7 @@ func (cfs *Files) FilterByDirString(filter string) Files {
 
 		subfiles = append(subfiles, file)
 	}
+	traceMatchedFiles(subfiles)
 
 	return subfiles
 
@@ -391,6 +519,7 @@ func (cfs *Files) FilterByDirGlob(filter string) Files {
 
 		subfiles = append(subfiles, file)
 	}
+	traceMatchedFiles(subfiles)
 
 	return subfiles
 
@@ -409,6 +538,7 @@ func (cfs *Files) FilterByStatus(filter string) Files {
 		subfiles = append(subfiles, file)
 
 	}
+	traceMatchedFiles(subfiles)
 
 	return subfiles
 
@@ -451,6 +581,13 @@ type RuleCtx struct {
 	TektonEventType               string
 	RequiresMultiPlatformTests    bool
 	RequiresSprayProxyRegistering bool
+	// SelectionReport is the evaluation trace of the last engine run
+	SelectionReport *SelectionReport
+	// Simulate records the side effects of the matched rules into Plan instead of executing them
+	Simulate    bool
+	Plan        *SimulationPlan
+	trace       *evalTrace
+	currentRule string
 }
 
 func NewRuleCtx() *RuleCtx {
@@ -480,7 +617,12 @@ func NewRuleCtx() *RuleCtx {
 		0,
 		"",
 		false,
-		false}
+		false,
+		nil,
+		false,
+		nil,
+		nil,
+		""}
 
 	//init defaults we've used so far
 	t, _ := time.ParseDuration("90m")
@@ -491,6 +633,15 @@ func NewRuleCtx() *RuleCtx {
 
 }
 
+// enterRule marks name as the rule whose actions are running and returns the func restoring the previous one.
+func (gca *RuleCtx) enterRule(name string) func() {
+
+	previous := gca.currentRule
+	gca.currentRule = name
+
+	return func() { gca.currentRule = previous }
+}
+
 func (gca *RuleCtx) AddRuleData(key string, obj any) error {
 
 	gca.RuleData[key] = obj
@@ -0,0 +1,129 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds every API call, so a hung Bitbucket API does not block the tests forever
+const requestTimeout = 2 * time.Minute
+
+// BitbucketClient is a client of the Bitbucket Cloud REST API (2.0)
+// Bitbucket Server and Data Center expose a different API (1.0) and are not supported.
+type BitbucketClient struct {
+	baseURL    string
+	username   string
+	token      string
+	workspace  string
+	httpClient *http.Client
+}
+
+// NewBitbucketClient creates a new Bitbucket client.
+// The token is sent as a bearer token (workspace/repository access tokens), unless username is set
+// in which case it is used as an app password of the user.
+func NewBitbucketClient(accessToken, username, baseURL, workspace string) (*BitbucketClient, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("bitbucket API URL is empty")
+	}
+	return &BitbucketClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		username:   username,
+		token:      accessToken,
+		workspace:  workspace,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// GetWorkspace returns the workspace name
+func (bc *BitbucketClient) GetWorkspace() string {
+	return bc.workspace
+}
+
+// ResponseError is returned when the API responds with an unexpected status code
+type ResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// isNotFound tells whether the API responded with 404 Not Found
+func isNotFound(err error) bool {
+	respErr, ok := err.(*ResponseError)
+	return ok && respErr.StatusCode == http.StatusNotFound
+}
+
+// page is a page of a paginated API response
+type page[T any] struct {
+	Values []*T   `json:"values"`
+	Next   string `json:"next"`
+}
+
+// listAll follows the pagination of the API endpoint at path and returns the values of all the pages
+func listAll[T any](bc *BitbucketClient, path string) ([]*T, error) {
+	var values []*T
+	next := bc.baseURL + path
+	for next != "" {
+		var p page[T]
+		if err := bc.sendRequest(http.MethodGet, next, nil, http.StatusOK, &p); err != nil {
+			return nil, err
+		}
+		values = append(values, p.Values...)
+		next = p.Next
+	}
+	return values, nil
+}
+
+// sendRequest sends a JSON request to the API endpoint at path (relative to the base URL, or an absolute
+// URL as found in the pagination links) and decodes the response into out unless it is nil.
+func (bc *BitbucketClient) sendRequest(method, path string, in any, expectedStatus int, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	resp, err := bc.do(method, path, body, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != expectedStatus {
+		data, _ := io.ReadAll(resp.Body)
+		return &ResponseError{StatusCode: resp.StatusCode, Body: string(data)}
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// do sends an authenticated request, the caller is responsible for closing the response body
+func (bc *BitbucketClient) do(method, path string, body io.Reader, contentType string) (*http.Response, error) {
+	url := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		url = bc.baseURL + path
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if bc.username != "" {
+		req.SetBasicAuth(bc.username, bc.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+bc.token)
+	}
+	return bc.httpClient.Do(req)
+}
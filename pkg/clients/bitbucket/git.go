@@ -0,0 +1,390 @@
+package bitbucket
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+	ginkgo "github.com/onsi/ginkgo/v2"
+)
+
+// Commit is a reference to a commit, hashes embedded in pull requests are abbreviated
+type Commit struct {
+	Hash string `json:"hash"`
+}
+
+// Branch is a branch of a repository
+type Branch struct {
+	Name   string `json:"name"`
+	Target Commit `json:"target"`
+}
+
+// PullRequestEndpoint is the source or destination of a pull request
+type PullRequestEndpoint struct {
+	Branch struct {
+		Name string `json:"name"`
+	} `json:"branch"`
+	Commit *Commit `json:"commit,omitempty"`
+}
+
+// PullRequest is a pull request of a repository
+type PullRequest struct {
+	ID          int                 `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	State       string              `json:"state"`
+	Source      PullRequestEndpoint `json:"source"`
+	Destination PullRequestEndpoint `json:"destination"`
+	MergeCommit *Commit             `json:"merge_commit,omitempty"`
+}
+
+// User is the author of a comment
+type User struct {
+	DisplayName string `json:"display_name"`
+	Nickname    string `json:"nickname"`
+}
+
+// Comment is a comment of a pull request
+type Comment struct {
+	ID      int64 `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	User      *User     `json:"user"`
+	CreatedOn time.Time `json:"created_on"`
+	Deleted   bool      `json:"deleted"`
+}
+
+// CommitStatus is a build status reported for a commit
+type CommitStatus struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	State       string `json:"state"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+// Hook is a webhook of a repository, hooks are identified by UUID
+type Hook struct {
+	UUID        string   `json:"uuid,omitempty"`
+	URL         string   `json:"url"`
+	Description string   `json:"description"`
+	Active      bool     `json:"active"`
+	Events      []string `json:"events"`
+}
+
+// Repository is a Bitbucket repository
+type Repository struct {
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+}
+
+// CreateBranch creates a new branch pointing to revision, or to the head of baseBranch when revision is empty
+// projectID should be in format "workspace/repo"
+func (bc *BitbucketClient) CreateBranch(projectID, newBranchName, baseBranch, revision string) error {
+	if revision == "" {
+		base, err := bc.GetBranch(projectID, baseBranch)
+		if err != nil {
+			return fmt.Errorf("failed to get base branch %s: %w", baseBranch, err)
+		}
+		revision = base.Target.Hash
+	}
+
+	opts := Branch{Name: newBranchName, Target: Commit{Hash: revision}}
+	err := bc.sendRequest(http.MethodPost, repoPath(projectID, "refs/branches"), opts, http.StatusCreated, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create branch %s in project %s: %w", newBranchName, projectID, err)
+	}
+	return nil
+}
+
+// GetBranch returns the branch with the full hash of its head commit
+func (bc *BitbucketClient) GetBranch(projectID, branchName string) (*Branch, error) {
+	branch := &Branch{}
+	err := bc.sendRequest(http.MethodGet, repoPath(projectID, "refs/branches/"+url.PathEscape(branchName)), nil, http.StatusOK, branch)
+	if err != nil {
+		return nil, err
+	}
+	return branch, nil
+}
+
+// ExistsBranch checks if a branch exists in a Bitbucket repository
+func (bc *BitbucketClient) ExistsBranch(projectID, branchName string) (bool, error) {
+	_, err := bc.GetBranch(projectID, branchName)
+	if err != nil {
+		if isNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// DeleteBranch deletes a branch from a Bitbucket repository
+func (bc *BitbucketClient) DeleteBranch(projectID, branchName string) error {
+	err := bc.sendRequest(http.MethodDelete, repoPath(projectID, "refs/branches/"+url.PathEscape(branchName)), nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete branch %s: %w", branchName, err)
+	}
+	return nil
+}
+
+// GetCommit returns the commit the revision (an abbreviated hash, branch or tag) points to
+func (bc *BitbucketClient) GetCommit(projectID, revision string) (*Commit, error) {
+	commit := &Commit{}
+	err := bc.sendRequest(http.MethodGet, repoPath(projectID, "commit/"+url.PathEscape(revision)), nil, http.StatusOK, commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", revision, err)
+	}
+	return commit, nil
+}
+
+// GetPullRequests returns a list of all open pull requests in a repository
+func (bc *BitbucketClient) GetPullRequests(projectID string) ([]*PullRequest, error) {
+	prs, err := listAll[PullRequest](bc, repoPath(projectID, "pullrequests?state=OPEN"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+	return prs, nil
+}
+
+// GetPullRequest returns the pull request with the given ID
+func (bc *BitbucketClient) GetPullRequest(projectID string, prID int) (*PullRequest, error) {
+	pr := &PullRequest{}
+	err := bc.sendRequest(http.MethodGet, repoPath(projectID, fmt.Sprintf("pullrequests/%d", prID)), nil, http.StatusOK, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request %d: %w", prID, err)
+	}
+	return pr, nil
+}
+
+// CreatePullRequest creates a pull request from the head branch to the base branch
+func (bc *BitbucketClient) CreatePullRequest(projectID, title, body, head, base string) (*PullRequest, error) {
+	opts := &PullRequest{Title: title, Description: body}
+	opts.Source.Branch.Name = head
+	opts.Destination.Branch.Name = base
+
+	pr := &PullRequest{}
+	if err := bc.sendRequest(http.MethodPost, repoPath(projectID, "pullrequests"), opts, http.StatusCreated, pr); err != nil {
+		return nil, fmt.Errorf("failed to create pull request: %w", err)
+	}
+	return pr, nil
+}
+
+// MergePullRequest merges the pull request using a merge commit
+func (bc *BitbucketClient) MergePullRequest(projectID string, prID int) (*PullRequest, error) {
+	opts := map[string]any{
+		"merge_strategy":      "merge_commit",
+		"close_source_branch": false,
+	}
+	pr := &PullRequest{}
+	if err := bc.sendRequest(http.MethodPost, repoPath(projectID, fmt.Sprintf("pullrequests/%d/merge", prID)), opts, http.StatusOK, pr); err != nil {
+		return nil, fmt.Errorf("failed to merge pull request %d: %w", prID, err)
+	}
+	return pr, nil
+}
+
+// DeclinePullRequest declines (closes without merging) the pull request
+func (bc *BitbucketClient) DeclinePullRequest(projectID string, prID int) error {
+	if err := bc.sendRequest(http.MethodPost, repoPath(projectID, fmt.Sprintf("pullrequests/%d/decline", prID)), nil, http.StatusOK, nil); err != nil {
+		return fmt.Errorf("failed to decline pull request %d: %w", prID, err)
+	}
+	return nil
+}
+
+// ListPullRequestComments lists the comments of the pull request, deleted comments excluded
+func (bc *BitbucketClient) ListPullRequestComments(projectID string, prID int) ([]*Comment, error) {
+	comments, err := listAll[Comment](bc, repoPath(projectID, fmt.Sprintf("pullrequests/%d/comments?sort=created_on", prID)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments of pull request %d: %w", prID, err)
+	}
+	var result []*Comment
+	for _, c := range comments {
+		if !c.Deleted {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// GetFile returns the content of the file on the branch together with the hash of the head commit of the branch
+func (bc *BitbucketClient) GetFile(projectID, pathToFile, branchName string) (string, string, error) {
+	branch, err := bc.GetBranch(projectID, branchName)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get branch %s: %w", branchName, err)
+	}
+
+	resp, err := bc.do(http.MethodGet, repoPath(projectID, path.Join("src", branch.Target.Hash, pathToFile)), nil, "")
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("failed to get file %s: %w", pathToFile, &ResponseError{StatusCode: resp.StatusCode, Body: string(data)})
+	}
+	return string(data), branch.Target.Hash, nil
+}
+
+// CommitFiles creates a single commit on top of the branch which sets the content of the given files
+// (path -> content) and removes the deleted ones. Returns the hash of the commit.
+func (bc *BitbucketClient) CommitFiles(projectID, branchName, message string, files map[string]string, deleted []string) (string, error) {
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	fields := map[string]string{"message": message, "branch": branchName}
+	for name, value := range fields {
+		if err := form.WriteField(name, value); err != nil {
+			return "", err
+		}
+	}
+	for filePath, content := range files {
+		if err := form.WriteField(filePath, content); err != nil {
+			return "", err
+		}
+	}
+	for _, filePath := range deleted {
+		if err := form.WriteField("files", filePath); err != nil {
+			return "", err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	resp, err := bc.do(http.MethodPost, repoPath(projectID, "src"), body, form.FormDataContentType())
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		data, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("failed to commit files to branch %s: %w", branchName, &ResponseError{StatusCode: resp.StatusCode, Body: string(data)})
+	}
+
+	// The Location header points to the created commit
+	if location := resp.Header.Get("Location"); location != "" {
+		return path.Base(location), nil
+	}
+	branch, err := bc.GetBranch(projectID, branchName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get branch %s: %w", branchName, err)
+	}
+	return branch.Target.Hash, nil
+}
+
+// GetCommitStatuses returns the build statuses reported for the commit
+func (bc *BitbucketClient) GetCommitStatuses(projectID, commitSHA string) ([]*CommitStatus, error) {
+	statuses, err := listAll[CommitStatus](bc, repoPath(projectID, fmt.Sprintf("commit/%s/statuses", commitSHA)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statuses of commit %s: %w", commitSHA, err)
+	}
+	return statuses, nil
+}
+
+// ListWebhooks returns the webhooks of the repository
+func (bc *BitbucketClient) ListWebhooks(projectID string) ([]*Hook, error) {
+	hooks, err := listAll[Hook](bc, repoPath(projectID, "hooks"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	return hooks, nil
+}
+
+// CreateWebhook creates an active webhook to the url triggered by the given events (e.g. "repo:push")
+func (bc *BitbucketClient) CreateWebhook(projectID, url string, events []string) (*Hook, error) {
+	opts := &Hook{
+		URL:         url,
+		Description: "e2e-tests webhook",
+		Active:      true,
+		Events:      events,
+	}
+	hook := &Hook{}
+	if err := bc.sendRequest(http.MethodPost, repoPath(projectID, "hooks"), opts, http.StatusCreated, hook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	return hook, nil
+}
+
+// DeleteWebhooks deletes all the webhooks matching the cluster app domain
+func (bc *BitbucketClient) DeleteWebhooks(projectID, clusterAppDomain string) error {
+	if clusterAppDomain == "" {
+		return fmt.Errorf("clusterAppDomain is empty")
+	}
+
+	hooks, err := bc.ListWebhooks(projectID)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		if strings.Contains(hook.URL, clusterAppDomain) {
+			err := bc.sendRequest(http.MethodDelete, repoPath(projectID, "hooks/"+url.PathEscape(hook.UUID)), nil, http.StatusNoContent, nil)
+			if err != nil {
+				return fmt.Errorf("failed to delete webhook (UUID: %s): %w", hook.UUID, err)
+			}
+			ginkgo.GinkgoWriter.Printf("Deleted webhook with URL: %s\n", hook.URL)
+		}
+	}
+	return nil
+}
+
+// ForkRepository forks a repository, targetProjectID should be in format "workspace/repo"
+func (bc *BitbucketClient) ForkRepository(sourceProjectID, targetProjectID string) (*Repository, error) {
+	targetWorkspace, targetRepo := splitProjectID(targetProjectID)
+	opts := map[string]any{
+		"name":      targetRepo,
+		"workspace": map[string]string{"slug": targetWorkspace},
+	}
+
+	forkedRepo := &Repository{}
+	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
+		err = bc.sendRequest(http.MethodPost, repoPath(sourceProjectID, "forks"), opts, http.StatusCreated, forkedRepo)
+		if err != nil {
+			ginkgo.GinkgoWriter.Printf("Failed to fork %s, trying again: %v\n", sourceProjectID, err)
+			return false, nil
+		}
+		return true, nil
+	}, time.Second*10, time.Minute*5)
+	if err != nil {
+		return nil, fmt.Errorf("error forking project %s to %s: %w", sourceProjectID, targetProjectID, err)
+	}
+	return forkedRepo, nil
+}
+
+// DeleteRepositoryIfExists deletes a repository if it exists, no error if not found
+func (bc *BitbucketClient) DeleteRepositoryIfExists(projectID string) error {
+	err := bc.sendRequest(http.MethodDelete, repoPath(projectID, ""), nil, http.StatusNoContent, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("failed to delete repository %s: %w", projectID, err)
+	}
+	return nil
+}
+
+// repoPath returns the API path of the resource of the repository
+func repoPath(projectID, resource string) string {
+	workspace, repo := splitProjectID(projectID)
+	p := fmt.Sprintf("/repositories/%s/%s", workspace, repo)
+	if resource != "" {
+		p += "/" + resource
+	}
+	return p
+}
+
+// splitProjectID splits a projectID in format "workspace/repo" into workspace and repo
+func splitProjectID(projectID string) (string, string) {
+	parts := strings.SplitN(projectID, "/", 2)
+	if len(parts) != 2 {
+		return projectID, ""
+	}
+	return parts[0], parts[1]
+}
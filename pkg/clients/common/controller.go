@@ -1,8 +1,10 @@
 package common
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/konflux-ci/e2e-tests/pkg/clients/bitbucket"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/forgejo"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/github"
@@ -23,6 +25,18 @@ type SuiteController struct {
 	Gitlab *gitlab.GitlabClient
 	// Forgejo client to interact with Forgejo/Codeberg APIs
 	Forgejo *forgejo.ForgejoClient
+	// Bitbucket client to interact with Bitbucket Cloud APIs
+	Bitbucket *bitbucket.BitbucketClient
+}
+
+// WithContext returns a copy of the controller whose kubernetes and GitHub API calls and polling are cancelled once ctx is done.
+func (s *SuiteController) WithContext(ctx context.Context) *SuiteController {
+	s2 := *s
+	s2.CustomClient = s.CustomClient.WithContext(ctx)
+	if s.Github != nil {
+		s2.Github = s.Github.WithContext(ctx)
+	}
+	return &s2
 }
 
 /*
@@ -54,10 +68,26 @@ func NewSuiteController(kubeC *kubeCl.CustomClient) (*SuiteController, error) {
 		}
 	}
 
+	// Initialize Bitbucket client
+	var bb *bitbucket.BitbucketClient
+	bitbucketToken := utils.GetEnv(constants.BITBUCKET_BOT_TOKEN_ENV, "")
+	if bitbucketToken != "" {
+		bb, err = bitbucket.NewBitbucketClient(
+			bitbucketToken,
+			utils.GetEnv(constants.BITBUCKET_USERNAME_ENV, ""),
+			utils.GetEnv(constants.BITBUCKET_API_URL_ENV, constants.DefaultBitbucketAPIURL),
+			utils.GetEnv(constants.BITBUCKET_QE_WORKSPACE_ENV, constants.DefaultBitbucketQEWorkspace),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Bitbucket client: %w", err)
+		}
+	}
+
 	return &SuiteController{
 		CustomClient: kubeC,
 		Github:       gh,
 		Gitlab:       gl,
 		Forgejo:      fj,
+		Bitbucket:    bb,
 	}, nil
 }
@@ -1,8 +1,6 @@
 package common
 
 import (
-	"context"
-
 	appsv1 "k8s.io/api/apps/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -17,7 +15,7 @@ func (h *SuiteController) GetDeployment(deploymentName string, namespace string)
 	}
 
 	deployment := &appsv1.Deployment{}
-	err := h.KubeRest().Get(context.Background(), namespacedName, deployment)
+	err := h.KubeRest().Get(h.Context(), namespacedName, deployment)
 	if err != nil {
 		return &appsv1.Deployment{}, err
 	}
@@ -33,7 +31,7 @@ func (h *SuiteController) DeploymentIsCompleted(deploymentName, namespace string
 		}
 
 		deployment := &appsv1.Deployment{}
-		err := h.KubeRest().Get(context.Background(), namespacedName, deployment)
+		err := h.KubeRest().Get(h.Context(), namespacedName, deployment)
 		if err != nil && !k8sErrors.IsNotFound(err) {
 			return false, err
 		}
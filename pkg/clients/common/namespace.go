@@ -1,7 +1,6 @@
 package common
 
 import (
-	"context"
 	"fmt"
 	"maps"
 	"os"
@@ -21,20 +20,20 @@ import (
 
 // DeleteNamespace deletes the give namespace.
 func (s *SuiteController) DeleteNamespace(namespace string) error {
-	_, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	_, err := s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), namespace, metav1.GetOptions{})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return fmt.Errorf("could not check for namespace '%s' existence: %v", namespace, err)
 	}
 
-	if err := s.KubeInterface().CoreV1().Namespaces().Delete(context.Background(), namespace, metav1.DeleteOptions{}); err != nil {
+	if err := s.KubeInterface().CoreV1().Namespaces().Delete(s.Context(), namespace, metav1.DeleteOptions{}); err != nil {
 		return fmt.Errorf("unable to delete namespace '%s': %v", namespace, err)
 	}
 
 	// Wait for the namespace to no longer exist. The namespace may remain stuck in 'Terminating' state
 	// if it contains with finalizers that are not handled. We detect this case here, and report any resources still
 	// in the Namespace.
-	if err := utils.WaitUntil(s.namespaceDoesNotExist(namespace), time.Minute*10); err != nil {
+	if err := utils.WaitUntilContext(s.Context(), s.namespaceDoesNotExist(namespace), time.Minute*10); err != nil {
 
 		// On failure to delete, list all namespace-scoped resources still in the namespace.
 		resourcesInNamespace := s.ListNamespaceScopedResourcesAsString(namespace, s.KubeInterface(), s.DynamicClient())
@@ -91,7 +90,7 @@ func (s *SuiteController) ListNamespaceScopedResourcesAsString(namespace string,
 				Resource: apiResource.Name,
 			}
 
-			unstructuredList, err := dynamicInterface.Resource(gvr).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+			unstructuredList, err := dynamicInterface.Resource(gvr).Namespace(namespace).List(s.Context(), metav1.ListOptions{})
 			if err != nil {
 				// Ignore errors: this function is for diagnostic purposes only.
 				continue
@@ -114,7 +113,7 @@ func (s *SuiteController) ListNamespaceScopedResourcesAsString(namespace string,
 // CreateTestNamespace creates a namespace where Application and Component CR will be created
 func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, error) {
 	// Check if the E2E test namespace already exists
-	ns, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+	ns, err := s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), name, metav1.GetOptions{})
 	requiredLabels := map[string]string{
 		constants.ArgoCDLabelKey:    constants.ArgoCDLabelValue,
 		constants.TenantLabelKey:    constants.TenantLabelValue,
@@ -129,13 +128,13 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 					Name:   name,
 					Labels: requiredLabels,
 				}}
-			ns, err = s.KubeInterface().CoreV1().Namespaces().Create(context.Background(), &nsTemplate, metav1.CreateOptions{})
+			ns, err = s.KubeInterface().CoreV1().Namespaces().Create(s.Context(), &nsTemplate, metav1.CreateOptions{})
 			if err != nil {
 				return nil, fmt.Errorf("error when creating %s namespace: %v", name, err)
 			}
 			// Wait for namespace to be active
-			err = utils.WaitUntil(func() (bool, error) {
-				fetchedNs, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+			err = utils.WaitUntilContext(s.Context(), func() (bool, error) {
+				fetchedNs, err := s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), name, metav1.GetOptions{})
 				if err != nil {
 					return false, err
 				}
@@ -157,8 +156,8 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 		}
 	}
 	// Wait for konflux-integration-runner sa to be created
-	err = utils.WaitUntil(func() (bool, error) {
-		_, err := s.KubeInterface().CoreV1().ServiceAccounts(name).Get(context.Background(), constants.DefaultPipelineServiceAccount, metav1.GetOptions{})
+	err = utils.WaitUntilContext(s.Context(), func() (bool, error) {
+		_, err := s.KubeInterface().CoreV1().ServiceAccounts(name).Get(s.Context(), constants.DefaultPipelineServiceAccount, metav1.GetOptions{})
 		if err != nil {
 			if k8sErrors.IsNotFound(err) {
 				return false, nil
@@ -174,7 +173,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 	// Create a rolebinding to allow default konflux-ci user
 	// to access test namespaces in konflux-ci cluster
 	if os.Getenv(constants.TEST_ENVIRONMENT_ENV) == constants.UpstreamTestEnvironment {
-		_, err = s.KubeInterface().RbacV1().RoleBindings(name).Get(context.Background(), constants.DefaultKonfluxAdminRoleBindingName, metav1.GetOptions{})
+		_, err = s.KubeInterface().RbacV1().RoleBindings(name).Get(s.Context(), constants.DefaultKonfluxAdminRoleBindingName, metav1.GetOptions{})
 		if err != nil {
 			if k8sErrors.IsNotFound(err) {
 				roleBindingTemplate := rbacv1.RoleBinding{
@@ -191,7 +190,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 						Name: constants.KonfluxAdminUserActionsClusterRoleName,
 					},
 				}
-				_, err = s.KubeInterface().RbacV1().RoleBindings(name).Create(context.Background(), &roleBindingTemplate, metav1.CreateOptions{})
+				_, err = s.KubeInterface().RbacV1().RoleBindings(name).Create(s.Context(), &roleBindingTemplate, metav1.CreateOptions{})
 				if err != nil {
 					if k8sErrors.IsAlreadyExists(err) {
 						// This is fine - the rolebinding already exists, which is what we wanted
@@ -214,7 +213,7 @@ func (s *SuiteController) CreateTestNamespace(name string) (*corev1.Namespace, e
 func (s *SuiteController) namespaceDoesNotExist(namespace string) wait.ConditionFunc {
 	return func() (bool, error) {
 
-		_, err := s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+		_, err := s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), namespace, metav1.GetOptions{})
 
 		return err != nil && k8sErrors.IsNotFound(err), nil
 	}
@@ -222,7 +221,7 @@ func (s *SuiteController) namespaceDoesNotExist(namespace string) wait.Condition
 
 // GetNamespace returns the requested Namespace object
 func (s *SuiteController) GetNamespace(namespace string) (*corev1.Namespace, error) {
-	return s.KubeInterface().CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	return s.KubeInterface().CoreV1().Namespaces().Get(s.Context(), namespace, metav1.GetOptions{})
 }
 
 // Ensure that the labels provided in `requiredLabels` (including their values) exists on namespace `ns`
@@ -239,7 +238,7 @@ func (s *SuiteController) ensureLabelsExist(ns *corev1.Namespace, requiredLabels
 
 	maps.Copy(ns.Labels, requiredLabels)
 
-	ns, err := s.KubeInterface().CoreV1().Namespaces().Update(context.Background(), ns, metav1.UpdateOptions{})
+	ns, err := s.KubeInterface().CoreV1().Namespaces().Update(s.Context(), ns, metav1.UpdateOptions{})
 	if err != nil {
 		return false, fmt.Errorf("error when updating labels in '%s' namespace: %v", ns.Name, err)
 	}
@@ -1,7 +1,6 @@
 package common
 
 import (
-	"context"
 	"fmt"
 	"time"
 
@@ -16,7 +15,7 @@ import (
 
 // GetPod returns the pod object from a given namespace and pod name
 func (s *SuiteController) GetPod(namespace, podName string) (*corev1.Pod, error) {
-	return s.KubeInterface().CoreV1().Pods(namespace).Get(context.Background(), podName, metav1.GetOptions{})
+	return s.KubeInterface().CoreV1().Pods(namespace).Get(s.Context(), podName, metav1.GetOptions{})
 }
 
 func (s *SuiteController) IsPodRunning(podName, namespace string) wait.ConditionFunc {
@@ -59,12 +58,12 @@ func (s *SuiteController) ListPods(namespace, labelKey, labelValue string, selec
 		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 		Limit:         selectionLimit,
 	}
-	return s.KubeInterface().CoreV1().Pods(namespace).List(context.Background(), listOptions)
+	return s.KubeInterface().CoreV1().Pods(namespace).List(s.Context(), listOptions)
 }
 
 // wait for a pod based on a condition. cond can be IsPodSuccessful for example
 func (s *SuiteController) WaitForPod(cond wait.ConditionFunc, timeout int) error {
-	if err := utils.WaitUntil(cond, time.Duration(timeout)*time.Second); err != nil {
+	if err := utils.WaitUntilContext(s.Context(), cond, time.Duration(timeout)*time.Second); err != nil {
 		return err
 	}
 	return nil
@@ -83,7 +82,7 @@ func (s *SuiteController) WaitForPodSelector(
 	}
 
 	for i := range podList.Items {
-		if err := utils.WaitUntil(fn(podList.Items[i].Name, namespace), time.Duration(timeout)*time.Second); err != nil {
+		if err := utils.WaitUntilContext(s.Context(), fn(podList.Items[i].Name, namespace), time.Duration(timeout)*time.Second); err != nil {
 			return err
 		}
 	}
@@ -92,7 +91,7 @@ func (s *SuiteController) WaitForPodSelector(
 
 // ListAllPods returns a list of all pods in a namespace.
 func (s *SuiteController) ListAllPods(namespace string) (*corev1.PodList, error) {
-	return s.KubeInterface().CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+	return s.KubeInterface().CoreV1().Pods(namespace).List(s.Context(), metav1.ListOptions{})
 }
 
 func (s *SuiteController) GetPodLogs(pod *corev1.Pod) map[string][]byte {
@@ -136,14 +135,14 @@ func (s *SuiteController) StoreAllPods(namespace string) error {
 }
 
 func (s *SuiteController) DeletePod(podName string, namespace string) error {
-	if err := s.KubeInterface().CoreV1().Pods(namespace).Delete(context.Background(), podName, metav1.DeleteOptions{}); err != nil {
+	if err := s.KubeInterface().CoreV1().Pods(namespace).Delete(s.Context(), podName, metav1.DeleteOptions{}); err != nil {
 		return fmt.Errorf("failed to restart pod '%s' in '%s' namespace: %+v", podName, namespace, err)
 	}
 	return nil
 }
 
 func (s *SuiteController) CreatePod(pod *corev1.Pod, namespace string) (*corev1.Pod, error) {
-	return s.KubeInterface().CoreV1().Pods(namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+	return s.KubeInterface().CoreV1().Pods(namespace).Create(s.Context(), pod, metav1.CreateOptions{})
 }
 
 func (s *SuiteController) GetPodLogsByName(podName, namespace string) (map[string][]byte, error) {
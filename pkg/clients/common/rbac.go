@@ -1,28 +1,26 @@
 package common
 
 import (
-	"context"
-
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (s *SuiteController) ListRoles(namespace string) (*rbacv1.RoleList, error) {
 	listOptions := metav1.ListOptions{}
-	return s.KubeInterface().RbacV1().Roles(namespace).List(context.Background(), listOptions)
+	return s.KubeInterface().RbacV1().Roles(namespace).List(s.Context(), listOptions)
 }
 
 func (s *SuiteController) ListRoleBindings(namespace string) (*rbacv1.RoleBindingList, error) {
 	listOptions := metav1.ListOptions{}
-	return s.KubeInterface().RbacV1().RoleBindings(namespace).List(context.Background(), listOptions)
+	return s.KubeInterface().RbacV1().RoleBindings(namespace).List(s.Context(), listOptions)
 }
 
 func (s *SuiteController) GetRole(roleName, namespace string) (*rbacv1.Role, error) {
-	return s.KubeInterface().RbacV1().Roles(namespace).Get(context.Background(), roleName, metav1.GetOptions{})
+	return s.KubeInterface().RbacV1().Roles(namespace).Get(s.Context(), roleName, metav1.GetOptions{})
 }
 
 func (s *SuiteController) GetRoleBinding(rolebindingName, namespace string) (*rbacv1.RoleBinding, error) {
-	return s.KubeInterface().RbacV1().RoleBindings(namespace).Get(context.Background(), rolebindingName, metav1.GetOptions{})
+	return s.KubeInterface().RbacV1().RoleBindings(namespace).Get(s.Context(), rolebindingName, metav1.GetOptions{})
 }
 
 // CreateRole creates a role with the provided name and namespace using the given list of rules
@@ -41,7 +39,7 @@ func (s *SuiteController) CreateRole(roleName, namespace string, roleRules map[s
 			*rules,
 		},
 	}
-	createdRole, err := s.KubeInterface().RbacV1().Roles(namespace).Create(context.Background(), role, metav1.CreateOptions{})
+	createdRole, err := s.KubeInterface().RbacV1().Roles(namespace).Create(s.Context(), role, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +71,7 @@ func (s *SuiteController) CreateRoleBinding(roleBindingName, namespace, subjectK
 		RoleRef:  roleBindingRoleRef,
 	}
 
-	createdRoleBinding, err := s.KubeInterface().RbacV1().RoleBindings(namespace).Create(context.Background(), roleBinding, metav1.CreateOptions{})
+	createdRoleBinding, err := s.KubeInterface().RbacV1().RoleBindings(namespace).Create(s.Context(), roleBinding, metav1.CreateOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -19,22 +19,22 @@ import (
 
 // Creates a new secret in a specified namespace
 func (s *SuiteController) CreateSecret(ns string, secret *corev1.Secret) (*corev1.Secret, error) {
-	return s.KubeInterface().CoreV1().Secrets(ns).Create(context.Background(), secret, metav1.CreateOptions{})
+	return s.KubeInterface().CoreV1().Secrets(ns).Create(s.Context(), secret, metav1.CreateOptions{})
 }
 
 // Check if a secret exists, return secret and error
 func (s *SuiteController) GetSecret(ns string, name string) (*corev1.Secret, error) {
-	return s.KubeInterface().CoreV1().Secrets(ns).Get(context.Background(), name, metav1.GetOptions{})
+	return s.KubeInterface().CoreV1().Secrets(ns).Get(s.Context(), name, metav1.GetOptions{})
 }
 
 // Update a secret in a specified namespace
 func (s *SuiteController) UpdateSecret(ns string, secret *corev1.Secret) (*corev1.Secret, error) {
-	return s.KubeInterface().CoreV1().Secrets(ns).Update(context.Background(), secret, metav1.UpdateOptions{})
+	return s.KubeInterface().CoreV1().Secrets(ns).Update(s.Context(), secret, metav1.UpdateOptions{})
 }
 
 // Delete a secret in a specified namespace
 func (s *SuiteController) DeleteSecret(ns string, name string) error {
-	return s.KubeInterface().CoreV1().Secrets(ns).Delete(context.Background(), name, metav1.DeleteOptions{})
+	return s.KubeInterface().CoreV1().Secrets(ns).Delete(s.Context(), name, metav1.DeleteOptions{})
 }
 
 // ListSecrets return a list of secrets from a namespace by label and selection limits
@@ -44,7 +44,7 @@ func (s *SuiteController) ListSecrets(ns string, labelKey string, labelValue str
 		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 		Limit:         selectionLimit,
 	}
-	return s.KubeInterface().CoreV1().Secrets(ns).List(context.Background(), listOptions)
+	return s.KubeInterface().CoreV1().Secrets(ns).List(s.Context(), listOptions)
 }
 
 // Delete all secrets in a specified namespace matching to label
@@ -66,8 +66,8 @@ func (s *SuiteController) DeleteSecretsByLabel(ns string, labelKey string, label
 // Links a secret to a specified serviceaccount, if argument addImagePullSecrets is true secret will be added also to ImagePullSecrets of SA.
 func (s *SuiteController) LinkSecretToServiceAccount(ns, secret, serviceaccount string, addImagePullSecrets bool) error {
 	timeout := 20 * time.Second
-	return wait.PollUntilContextTimeout(context.Background(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(ns).Get(context.Background(), serviceaccount, metav1.GetOptions{})
+	return wait.PollUntilContextTimeout(s.Context(), time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(ns).Get(s.Context(), serviceaccount, metav1.GetOptions{})
 		if err != nil {
 			return false, err
 		}
@@ -81,7 +81,7 @@ func (s *SuiteController) LinkSecretToServiceAccount(ns, secret, serviceaccount
 		if addImagePullSecrets {
 			serviceAccountObject.ImagePullSecrets = append(serviceAccountObject.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
 		}
-		_, err = s.KubeInterface().CoreV1().ServiceAccounts(ns).Update(context.Background(), serviceAccountObject, metav1.UpdateOptions{})
+		_, err = s.KubeInterface().CoreV1().ServiceAccounts(ns).Update(s.Context(), serviceAccountObject, metav1.UpdateOptions{})
 		if err != nil {
 			return false, nil
 		}
@@ -91,7 +91,7 @@ func (s *SuiteController) LinkSecretToServiceAccount(ns, secret, serviceaccount
 
 // UnlinkSecretFromServiceAccount unlinks secret from service account
 func (s *SuiteController) UnlinkSecretFromServiceAccount(namespace, secretName, serviceAccount string, rmImagePullSecrets bool) error {
-	serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(context.Background(), serviceAccount, metav1.GetOptions{})
+	serviceAccountObject, err := s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(s.Context(), serviceAccount, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
@@ -111,7 +111,7 @@ func (s *SuiteController) UnlinkSecretFromServiceAccount(namespace, secretName,
 			}
 		}
 	}
-	_, err = s.KubeInterface().CoreV1().ServiceAccounts(namespace).Update(context.Background(), serviceAccountObject, metav1.UpdateOptions{})
+	_, err = s.KubeInterface().CoreV1().ServiceAccounts(namespace).Update(s.Context(), serviceAccountObject, metav1.UpdateOptions{})
 	if err != nil {
 		return err
 	}
@@ -134,7 +134,7 @@ func (s *SuiteController) CreateRegistryAuthSecret(secretName, namespace, secret
 		Type:       corev1.SecretTypeDockerConfigJson,
 		StringData: map[string]string{corev1.DockerConfigJsonKey: string(rawDecodedTextStringData)},
 	}
-	er := s.KubeRest().Create(context.Background(), secret)
+	er := s.KubeRest().Create(s.Context(), secret)
 	if er != nil {
 		return nil, er
 	}
@@ -148,7 +148,7 @@ func (s *SuiteController) CreateRegistryJsonSecret(name, namespace, authKey, key
 		Type:       corev1.SecretTypeDockerConfigJson,
 		Data:       map[string][]byte{".dockerconfigjson": []byte(fmt.Sprintf("{\"auths\":{\"quay.io\":{\"username\":\"%s\",\"password\":\"%s\",\"auth\":\"dGVzdDp0ZXN0\",\"email\":\"\"}}}", keyName, authKey))},
 	}
-	err := s.KubeRest().Create(context.Background(), secret)
+	err := s.KubeRest().Create(s.Context(), secret)
 	if err != nil {
 		return nil, err
 	}
@@ -1,17 +1,15 @@
 package common
 
 import (
-	"context"
-
+	ginkgo "github.com/onsi/ginkgo/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	ginkgo "github.com/onsi/ginkgo/v2"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func (s *SuiteController) GetServiceAccount(saName, namespace string) (*corev1.ServiceAccount, error) {
-	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(context.Background(), saName, metav1.GetOptions{})
+	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Get(s.Context(), saName, metav1.GetOptions{})
 }
 
 func (s *SuiteController) ServiceAccountPresent(saName, namespace string) wait.ConditionFunc {
@@ -35,10 +33,10 @@ func (s *SuiteController) CreateServiceAccount(name, namespace string, serviceAc
 		},
 		Secrets: serviceAccountSecretList,
 	}
-	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Create(context.Background(), serviceAccount, metav1.CreateOptions{})
+	return s.KubeInterface().CoreV1().ServiceAccounts(namespace).Create(s.Context(), serviceAccount, metav1.CreateOptions{})
 }
 
 // DeleteAllServiceAccountsInASpecificNamespace deletes all ServiceAccount from a given namespace
 func (h *SuiteController) DeleteAllServiceAccountsInASpecificNamespace(namespace string) error {
-	return h.KubeRest().DeleteAllOf(context.Background(), &corev1.ServiceAccount{}, client.InNamespace(namespace))
+	return h.KubeRest().DeleteAllOf(h.Context(), &corev1.ServiceAccount{}, client.InNamespace(namespace))
 }
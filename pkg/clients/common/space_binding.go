@@ -1,7 +1,6 @@
 package common
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -31,7 +30,7 @@ func (s *SuiteController) CreateSpaceBinding(murName, spaceName, spaceRole strin
 		},
 	}
 
-	err := s.KubeRest().Create(context.Background(), spaceBinding)
+	err := s.KubeRest().Create(s.Context(), spaceBinding)
 	if err != nil {
 		return &toolchainApi.SpaceBinding{}, err
 	}
@@ -1,6 +1,9 @@
 package forgejo
 
 import (
+	"net/http"
+	"strings"
+
 	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
 )
 
@@ -8,6 +11,10 @@ import (
 type ForgejoClient struct {
 	client *forgejo.Client
 	org    string
+	// baseURL and token are kept for the API endpoints the SDK does not cover
+	baseURL    string
+	token      string
+	httpClient *http.Client
 }
 
 // NewForgejoClient creates a new Forgejo client
@@ -18,8 +25,11 @@ func NewForgejoClient(accessToken, baseURL, org string) (*ForgejoClient, error)
 	}
 
 	return &ForgejoClient{
-		client: client,
-		org:    org,
+		client:     client,
+		org:        org,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      accessToken,
+		httpClient: http.DefaultClient,
 	}, nil
 }
 
@@ -1,8 +1,11 @@
 package forgejo
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -158,7 +161,7 @@ func (fc *ForgejoClient) CreateFile(projectID, pathToFile, content, branchName s
 			Message:    "e2e test commit message",
 			BranchName: branchName,
 		},
-		Content: content,
+		Content: base64.StdEncoding.EncodeToString([]byte(content)),
 	}
 
 	fileResp, _, err := fc.client.CreateFile(owner, repo, pathToFile, opts)
@@ -191,6 +194,117 @@ func (fc *ForgejoClient) GetFile(projectID, pathToFile, branchName string) (stri
 	return string(decoded), content, nil
 }
 
+// UpdateFile replaces the content of a file in a repository, the file is created when it does not exist
+func (fc *ForgejoClient) UpdateFile(projectID, pathToFile, content, branchName string) (*forgejo.FileResponse, error) {
+	owner, repo := splitProjectID(projectID)
+
+	existing, resp, err := fc.client.GetContents(owner, repo, branchName, pathToFile)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return fc.CreateFile(projectID, pathToFile, content, branchName)
+		}
+		return nil, fmt.Errorf("failed to get file %s: %w", pathToFile, err)
+	}
+
+	opts := forgejo.UpdateFileOptions{
+		FileOptions: forgejo.FileOptions{
+			Message:    "e2e test commit message",
+			BranchName: branchName,
+		},
+		SHA:     existing.SHA,
+		Content: base64.StdEncoding.EncodeToString([]byte(content)),
+	}
+
+	fileResp, _, err := fc.client.UpdateFile(owner, repo, pathToFile, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update file %s: %w", pathToFile, err)
+	}
+
+	return fileResp, nil
+}
+
+// DeleteFile deletes a file from a repository
+func (fc *ForgejoClient) DeleteFile(projectID, pathToFile, branchName string) error {
+	owner, repo := splitProjectID(projectID)
+
+	existing, _, err := fc.client.GetContents(owner, repo, branchName, pathToFile)
+	if err != nil {
+		return fmt.Errorf("failed to get file %s: %w", pathToFile, err)
+	}
+
+	opts := forgejo.DeleteFileOptions{
+		FileOptions: forgejo.FileOptions{
+			Message:    "delete test files",
+			BranchName: branchName,
+		},
+		SHA: existing.SHA,
+	}
+
+	if _, err := fc.client.DeleteFile(owner, repo, pathToFile, opts); err != nil {
+		return fmt.Errorf("failed to delete file %s: %w", pathToFile, err)
+	}
+
+	return nil
+}
+
+// ListWebhooks lists the webhooks of a repository
+func (fc *ForgejoClient) ListWebhooks(projectID string) ([]*forgejo.Hook, error) {
+	owner, repo := splitProjectID(projectID)
+
+	hooks, _, err := fc.client.ListRepoHooks(owner, repo, forgejo.ListHooksOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return hooks, nil
+}
+
+// CreateWebhook creates a webhook to the url triggered by the given events
+func (fc *ForgejoClient) CreateWebhook(projectID, url string, events []string) (*forgejo.Hook, error) {
+	owner, repo := splitProjectID(projectID)
+
+	opts := forgejo.CreateHookOption{
+		Type: forgejo.HookTypeForgejo,
+		Config: map[string]string{
+			"url":          url,
+			"content_type": "json",
+		},
+		Events: events,
+		Active: true,
+	}
+
+	hook, _, err := fc.client.CreateRepoHook(owner, repo, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return hook, nil
+}
+
+// ListPullRequestComments lists the comments of a pull request
+func (fc *ForgejoClient) ListPullRequestComments(projectID string, prNumber int64) ([]*forgejo.Comment, error) {
+	owner, repo := splitProjectID(projectID)
+
+	comments, _, err := fc.client.ListIssueComments(owner, repo, prNumber, forgejo.ListIssueCommentOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments of pull request %d: %w", prNumber, err)
+	}
+
+	return comments, nil
+}
+
+// GetCommitStatuses lists the latest commit statuses of a commit, one per status context
+func (fc *ForgejoClient) GetCommitStatuses(projectID, commitSHA string) ([]*forgejo.Status, error) {
+	owner, repo := splitProjectID(projectID)
+
+	combinedStatus, _, err := fc.client.GetCombinedStatus(owner, repo, commitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit statuses of %s: %w", commitSHA, err)
+	}
+
+	return combinedStatus.Statuses, nil
+}
+
 // DeleteWebhooks deletes webhooks matching the cluster app domain
 func (fc *ForgejoClient) DeleteWebhooks(projectID, clusterAppDomain string) error {
 	if clusterAppDomain == "" {
@@ -341,3 +455,100 @@ func splitProjectID(projectID string) (string, string) {
 	}
 	return parts[0], parts[1]
 }
+
+// changeFileOperation is one file change of the change files API request
+type changeFileOperation struct {
+	Operation string `json:"operation"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	SHA       string `json:"sha,omitempty"`
+}
+
+// changeFilesOptions is the body of the change files API request
+type changeFilesOptions struct {
+	Message    string                 `json:"message"`
+	BranchName string                 `json:"branch"`
+	Files      []*changeFileOperation `json:"files"`
+}
+
+// ChangeFiles creates a single commit on top of the branch which sets the content of the given files
+// (path -> content), creating those which do not exist yet. Returns the SHA of the commit.
+// The SDK does not cover the change files API, so the request is sent directly.
+func (fc *ForgejoClient) ChangeFiles(projectID, branchName, message string, files map[string]string) (string, error) {
+	owner, repo := splitProjectID(projectID)
+
+	opts := changeFilesOptions{Message: message, BranchName: branchName}
+	for path, content := range files {
+		operation := &changeFileOperation{
+			Operation: "create",
+			Path:      path,
+			Content:   base64.StdEncoding.EncodeToString([]byte(content)),
+		}
+		existing, resp, err := fc.client.GetContents(owner, repo, branchName, path)
+		switch {
+		case err == nil:
+			operation.Operation = "update"
+			operation.SHA = existing.SHA
+		case resp == nil || resp.StatusCode != http.StatusNotFound:
+			return "", fmt.Errorf("failed to get file %s: %w", path, err)
+		}
+		opts.Files = append(opts.Files, operation)
+	}
+
+	var filesResp struct {
+		Commit *forgejo.FileCommitResponse `json:"commit"`
+	}
+	err := fc.sendRequest(http.MethodPost, fmt.Sprintf("/repos/%s/%s/contents", owner, repo), opts, http.StatusCreated, &filesResp)
+	if err != nil {
+		return "", fmt.Errorf("failed to change files in project %s: %w", projectID, err)
+	}
+	if filesResp.Commit == nil {
+		return "", fmt.Errorf("change files response has no commit")
+	}
+	return filesResp.Commit.SHA, nil
+}
+
+// UpdatePullRequestBranch merges the base branch into the head branch of the pull request
+// The SDK does not cover the update pull request API, so the request is sent directly.
+func (fc *ForgejoClient) UpdatePullRequestBranch(projectID string, prNumber int64) error {
+	owner, repo := splitProjectID(projectID)
+
+	err := fc.sendRequest(http.MethodPost, fmt.Sprintf("/repos/%s/%s/pulls/%d/update", owner, repo, prNumber), nil, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update branch of pull request %d: %w", prNumber, err)
+	}
+	return nil
+}
+
+// sendRequest sends a JSON request to the API endpoint at path (relative to /api/v1)
+// and decodes the response into out unless it is nil.
+func (fc *ForgejoClient) sendRequest(method, path string, in any, expectedStatus int, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, fc.baseURL+"/api/v1"+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "token "+fc.token)
+
+	resp, err := fc.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != expectedStatus {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, string(data))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,199 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/bitbucket"
+)
+
+type BitbucketClient struct {
+	*bitbucket.BitbucketClient
+}
+
+func NewBitbucketClient(bc *bitbucket.BitbucketClient) *BitbucketClient {
+	return &BitbucketClient{bc}
+}
+
+func (b *BitbucketClient) CreateBranch(repository, baseBranchName, revision, branchName string) error {
+	return b.BitbucketClient.CreateBranch(repository, branchName, baseBranchName, revision)
+}
+
+func (b *BitbucketClient) BranchExists(repository, branchName string) (bool, error) {
+	return b.ExistsBranch(repository, branchName)
+}
+
+func (b *BitbucketClient) ListPullRequests(repository string) ([]*PullRequest, error) {
+	prs, err := b.GetPullRequests(repository)
+	if err != nil {
+		return nil, err
+	}
+	var pullRequests []*PullRequest
+	for _, pr := range prs {
+		pullRequest, err := b.toPullRequest(repository, pr)
+		if err != nil {
+			return nil, err
+		}
+		pullRequests = append(pullRequests, pullRequest)
+	}
+	return pullRequests, nil
+}
+
+func (b *BitbucketClient) CreateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	sha, err := b.CommitFiles(repository, branchName, "e2e test commit message", map[string]string{pathToFile: content}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{CommitSHA: sha}, nil
+}
+
+func (b *BitbucketClient) GetFile(repository, pathToFile, branchName string) (*RepositoryFile, error) {
+	content, sha, err := b.BitbucketClient.GetFile(repository, pathToFile, branchName)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{CommitSHA: sha, Content: content}, nil
+}
+
+func (b *BitbucketClient) UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	sha, err := b.CommitFiles(repository, branchName, "e2e test commit message", map[string]string{pathToFile: content}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{CommitSHA: sha, Content: content}, nil
+}
+
+func (b *BitbucketClient) DeleteFile(repository, pathToFile, branchName string) error {
+	_, err := b.CommitFiles(repository, branchName, "delete test files", nil, []string{pathToFile})
+	return err
+}
+
+func (b *BitbucketClient) PushCommit(repository, branchName, message string, files map[string]string) (string, error) {
+	return b.CommitFiles(repository, branchName, message, files, nil)
+}
+
+func (b *BitbucketClient) CreatePullRequest(repository, title, body, head, base string) (*PullRequest, error) {
+	pr, err := b.BitbucketClient.CreatePullRequest(repository, title, body, head, base)
+	if err != nil {
+		return nil, err
+	}
+	return b.toPullRequest(repository, pr)
+}
+
+func (b *BitbucketClient) MergePullRequest(repository string, prNumber int) (*PullRequest, error) {
+	pr, err := b.BitbucketClient.MergePullRequest(repository, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	return b.toPullRequest(repository, pr)
+}
+
+// UpdatePullRequestBranch is not supported, Bitbucket has no API to merge the destination branch into the source branch
+func (b *BitbucketClient) UpdatePullRequestBranch(_ string, prNumber int) error {
+	return fmt.Errorf("updating the branch of pull request %d is not supported by Bitbucket", prNumber)
+}
+
+func (b *BitbucketClient) DeleteBranchAndClosePullRequest(repository string, prNumber int) error {
+	pr, err := b.GetPullRequest(repository, prNumber)
+	if err != nil {
+		return err
+	}
+	if err := b.DeclinePullRequest(repository, prNumber); err != nil {
+		return err
+	}
+	return b.DeleteBranch(repository, pr.Source.Branch.Name)
+}
+
+func (b *BitbucketClient) ListPullRequestComments(repository string, prNumber int) ([]*Comment, error) {
+	comments, err := b.BitbucketClient.ListPullRequestComments(repository, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	var result []*Comment
+	for _, c := range comments {
+		comment := &Comment{
+			ID:        c.ID,
+			Body:      c.Content.Raw,
+			CreatedAt: c.CreatedOn,
+		}
+		if c.User != nil {
+			comment.Author = c.User.Nickname
+		}
+		result = append(result, comment)
+	}
+	return result, nil
+}
+
+func (b *BitbucketClient) ListCommitStatuses(repository, commitSHA string) ([]*CommitStatus, error) {
+	statuses, err := b.GetCommitStatuses(repository, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+	var result []*CommitStatus
+	for _, s := range statuses {
+		name := s.Name
+		if name == "" {
+			name = s.Key
+		}
+		result = append(result, &CommitStatus{
+			Name:        name,
+			State:       bitbucketStatusState(s.State),
+			Description: s.Description,
+			TargetURL:   s.URL,
+		})
+	}
+	return result, nil
+}
+
+// ListWebhooks lists the webhooks of the repository, Bitbucket identifies webhooks by UUID so their ID is not set
+func (b *BitbucketClient) ListWebhooks(repository string) ([]*Webhook, error) {
+	hooks, err := b.BitbucketClient.ListWebhooks(repository)
+	if err != nil {
+		return nil, err
+	}
+	var result []*Webhook
+	for _, h := range hooks {
+		result = append(result, &Webhook{URL: h.URL})
+	}
+	return result, nil
+}
+
+func (b *BitbucketClient) CreateWebhook(repository, url string) (*Webhook, error) {
+	hook, err := b.BitbucketClient.CreateWebhook(repository, url, []string{"repo:push", "pullrequest:created", "pullrequest:updated", "pullrequest:comment_created"})
+	if err != nil {
+		return nil, err
+	}
+	return &Webhook{URL: hook.URL}, nil
+}
+
+func (b *BitbucketClient) CleanupWebhooks(repository, clusterAppDomain string) error {
+	return b.DeleteWebhooks(repository, clusterAppDomain)
+}
+
+func (b *BitbucketClient) ForkRepository(sourceRepoName, targetRepoName string) error {
+	_, err := b.BitbucketClient.ForkRepository(sourceRepoName, targetRepoName)
+	return err
+}
+
+// toPullRequest converts the Bitbucket pull request, resolving the abbreviated commit hashes it holds
+func (b *BitbucketClient) toPullRequest(repository string, pr *bitbucket.PullRequest) (*PullRequest, error) {
+	pullRequest := &PullRequest{
+		Number:       pr.ID,
+		SourceBranch: pr.Source.Branch.Name,
+		TargetBranch: pr.Destination.Branch.Name,
+	}
+	if pr.Source.Commit != nil {
+		commit, err := b.GetCommit(repository, pr.Source.Commit.Hash)
+		if err != nil {
+			return nil, err
+		}
+		pullRequest.HeadSHA = commit.Hash
+	}
+	if pr.MergeCommit != nil {
+		commit, err := b.GetCommit(repository, pr.MergeCommit.Hash)
+		if err != nil {
+			return nil, err
+		}
+		pullRequest.MergeCommitSHA = commit.Hash
+	}
+	return pullRequest, nil
+}
@@ -0,0 +1,77 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/bitbucket"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/forgejo"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git/gittest"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/github"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/gitlab"
+)
+
+const fakeToken = "fake-token"
+
+func TestGitHubConformance(t *testing.T) {
+	forge := gittest.NewFakeForge()
+	defer forge.Close()
+
+	gh, err := github.NewGithubClientWithBaseURL(fakeToken, "qe", forge.GitHubURL())
+	require.NoError(t, err)
+	gittest.RunConformance(t, git.NewGitHubClient(gh), forge, gittest.Fixture{
+		Repository:      "hello",
+		ForgeRepository: "qe/hello",
+		DefaultBranch:   "main",
+		ForkTarget:      "hello-fork",
+		ForgeForkTarget: "qe/hello-fork",
+	})
+}
+
+func TestGitLabConformance(t *testing.T) {
+	forge := gittest.NewFakeForge()
+	defer forge.Close()
+
+	gl, err := gitlab.NewGitlabClient(fakeToken, forge.GitLabURL(), "qe")
+	require.NoError(t, err)
+	gittest.RunConformance(t, git.NewGitlabClient(gl), forge, gittest.Fixture{
+		Repository:      "qe/hello",
+		ForgeRepository: "qe/hello",
+		DefaultBranch:   "main",
+		ForkTarget:      "qe-forks/hello",
+		ForgeForkTarget: "qe-forks/hello",
+	})
+}
+
+func TestForgejoConformance(t *testing.T) {
+	forge := gittest.NewFakeForge()
+	defer forge.Close()
+
+	fc, err := forgejo.NewForgejoClient(fakeToken, forge.ForgejoURL(), "qe")
+	require.NoError(t, err)
+	gittest.RunConformance(t, git.NewForgejoClient(fc), forge, gittest.Fixture{
+		Repository:      "qe/hello",
+		ForgeRepository: "qe/hello",
+		DefaultBranch:   "main",
+		ForkTarget:      "qe-forks/hello",
+		ForgeForkTarget: "qe-forks/hello",
+	})
+}
+
+func TestBitbucketConformance(t *testing.T) {
+	forge := gittest.NewFakeForge()
+	defer forge.Close()
+
+	bc, err := bitbucket.NewBitbucketClient(fakeToken, "qe-bot", forge.BitbucketURL(), "qe")
+	require.NoError(t, err)
+	gittest.RunConformance(t, git.NewBitbucketClient(bc), forge, gittest.Fixture{
+		Repository:      "qe/hello",
+		ForgeRepository: "qe/hello",
+		DefaultBranch:   "main",
+		ForkTarget:      "qe-forks/hello",
+		ForgeForkTarget: "qe-forks/hello",
+		Unsupported:     []string{"UpdatePullRequestBranch"},
+	})
+}
@@ -80,6 +80,10 @@ func (f *ForgejoClient) MergePullRequest(repository string, prNumber int) (*Pull
 	}, nil
 }
 
+func (f *ForgejoClient) UpdatePullRequestBranch(repository string, prNumber int) error {
+	return f.ForgejoClient.UpdatePullRequestBranch(repository, int64(prNumber))
+}
+
 func (f *ForgejoClient) CreatePullRequest(repository, title, body, head, base string) (*PullRequest, error) {
 	pr, err := f.ForgejoClient.CreatePullRequest(repository, title, body, head, base)
 	if err != nil {
@@ -141,3 +145,78 @@ func (f *ForgejoClient) GetCommitStatusConclusion(statusName, projectID, commitS
 	return f.ForgejoClient.GetCommitStatusConclusion(statusName, projectID, commitSHA, int64(prNumber))
 }
 
+func (f *ForgejoClient) ListPullRequestComments(repository string, prNumber int) ([]*Comment, error) {
+	comments, err := f.ForgejoClient.ListPullRequestComments(repository, int64(prNumber))
+	if err != nil {
+		return nil, err
+	}
+	var result []*Comment
+	for _, c := range comments {
+		comment := &Comment{
+			ID:        c.ID,
+			Body:      c.Body,
+			CreatedAt: c.Created,
+		}
+		if c.Poster != nil {
+			comment.Author = c.Poster.UserName
+		}
+		result = append(result, comment)
+	}
+	return result, nil
+}
+
+func (f *ForgejoClient) UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	fileResp, err := f.ForgejoClient.UpdateFile(repository, pathToFile, content, branchName)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{
+		CommitSHA: fileResp.Commit.SHA,
+		Content:   content,
+	}, nil
+}
+
+func (f *ForgejoClient) DeleteFile(repository, pathToFile, branchName string) error {
+	return f.ForgejoClient.DeleteFile(repository, pathToFile, branchName)
+}
+
+func (f *ForgejoClient) PushCommit(repository, branchName, message string, files map[string]string) (string, error) {
+	return f.ChangeFiles(repository, branchName, message, files)
+}
+
+func (f *ForgejoClient) ListCommitStatuses(repository, commitSHA string) ([]*CommitStatus, error) {
+	statuses, err := f.GetCommitStatuses(repository, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+	var result []*CommitStatus
+	for _, s := range statuses {
+		result = append(result, &CommitStatus{
+			Name:        s.Context,
+			State:       forgejoStatusState(string(s.State)),
+			Description: s.Description,
+			TargetURL:   s.TargetURL,
+		})
+	}
+	return result, nil
+}
+
+func (f *ForgejoClient) ListWebhooks(repository string) ([]*Webhook, error) {
+	hooks, err := f.ForgejoClient.ListWebhooks(repository)
+	if err != nil {
+		return nil, err
+	}
+	var result []*Webhook
+	for _, h := range hooks {
+		result = append(result, &Webhook{ID: h.ID, URL: h.Config["url"]})
+	}
+	return result, nil
+}
+
+func (f *ForgejoClient) CreateWebhook(repository, url string) (*Webhook, error) {
+	hook, err := f.ForgejoClient.CreateWebhook(repository, url, []string{"push", "pull_request"})
+	if err != nil {
+		return nil, err
+	}
+	return &Webhook{ID: hook.ID, URL: url}, nil
+}
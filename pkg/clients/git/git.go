@@ -1,5 +1,7 @@
 package git
 
+import "time"
+
 // GitProvider is an enum representing possible Git providers
 type GitProvider int
 
@@ -7,6 +9,7 @@ const (
 	GitHubProvider GitProvider = iota
 	GitLabProvider
 	ForgejoProvider
+	BitbucketProvider
 )
 
 // PullRequest represents a generic provider-agnostic pull/merge request
@@ -30,6 +33,48 @@ type RepositoryFile struct {
 	Content string
 }
 
+// CommitStatus represents a generic provider-agnostic commit status (GitHub check runs included)
+type CommitStatus struct {
+	// Name is the name of the check run or the context of the commit status
+	Name string
+	// State is one of the CommitStatus* constants
+	State string
+	// Description is the summary reported with the status
+	Description string
+	// TargetURL points to the details of the status
+	TargetURL string
+}
+
+// States of a CommitStatus, the provider specific states are mapped onto these
+const (
+	CommitStatusPending  = "pending"
+	CommitStatusRunning  = "running"
+	CommitStatusSuccess  = "success"
+	CommitStatusFailure  = "failure"
+	CommitStatusNeutral  = "neutral"
+	CommitStatusCanceled = "canceled"
+	CommitStatusSkipped  = "skipped"
+)
+
+// IsFinished tells whether the status will not change anymore
+func (s *CommitStatus) IsFinished() bool {
+	return s.State != CommitStatusPending && s.State != CommitStatusRunning
+}
+
+// Comment represents a generic provider-agnostic comment of a pull/merge request
+type Comment struct {
+	ID        int64
+	Author    string
+	Body      string
+	CreatedAt time.Time
+}
+
+// Webhook represents a generic provider-agnostic repository webhook
+type Webhook struct {
+	ID  int64
+	URL string
+}
+
 type Client interface {
 	CreateBranch(repository, baseBranchName, revision, branchName string) error
 	DeleteBranch(repository, branchName string) error
@@ -41,6 +86,19 @@ type Client interface {
 	MergePullRequest(repository string, prNumber int) (*PullRequest, error)
 	UpdatePullRequestBranch(repository string, prNumber int) error
 	DeleteBranchAndClosePullRequest(repository string, prNumber int) error
+	// ListPullRequestComments lists the comments of the pull request, oldest first
+	ListPullRequestComments(repository string, prNumber int) ([]*Comment, error)
+	// UpdateFile sets the content of the file on the branch, the file is created when it does not exist yet
+	UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error)
+	DeleteFile(repository, pathToFile, branchName string) error
+	// PushCommit creates a single commit on top of the branch setting the content of the files (path -> content)
+	// and returns its SHA
+	PushCommit(repository, branchName, message string, files map[string]string) (string, error)
+	// ListCommitStatuses lists the latest statuses (and check runs) reported for the commit
+	ListCommitStatuses(repository, commitSHA string) ([]*CommitStatus, error)
+	ListWebhooks(repository string) ([]*Webhook, error)
+	// CreateWebhook creates a webhook to the url triggered by push and pull request events
+	CreateWebhook(repository, url string) (*Webhook, error)
 	CleanupWebhooks(repository, clusterAppDomain string) error
 	ForkRepository(sourceRepoName, targetRepoName string) error
 	DeleteRepositoryIfExists(repoName string) error
@@ -167,3 +167,102 @@ func (g *GitHubClient) DeleteRepositoryIfExists(repoName string) error {
 	}
 	return nil
 }
+
+func (g *GitHubClient) ListPullRequestComments(repository string, prNumber int) ([]*Comment, error) {
+	comments, err := g.ListPullRequestCommentsSince(repository, prNumber, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	var result []*Comment
+	for _, c := range comments {
+		result = append(result, &Comment{
+			ID:        c.GetID(),
+			Author:    c.GetUser().GetLogin(),
+			Body:      c.GetBody(),
+			CreatedAt: c.GetCreatedAt(),
+		})
+	}
+	return result, nil
+}
+
+func (g *GitHubClient) UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	current, err := g.Github.GetFile(repository, pathToFile, branchName)
+	if err != nil {
+		if strings.Contains(err.Error(), "404 Not Found") {
+			return g.CreateFile(repository, pathToFile, content, branchName)
+		}
+		return nil, err
+	}
+	file, err := g.Github.UpdateFile(repository, pathToFile, content, branchName, current.GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{
+		CommitSHA: file.GetSHA(),
+		Content:   content,
+	}, nil
+}
+
+func (g *GitHubClient) DeleteFile(repository, pathToFile, branchName string) error {
+	return g.Github.DeleteFile(repository, pathToFile, branchName)
+}
+
+func (g *GitHubClient) PushCommit(repository, branchName, message string, files map[string]string) (string, error) {
+	commit, err := g.CreateCommit(repository, branchName, message, files)
+	if err != nil {
+		return "", err
+	}
+	return commit.GetSHA(), nil
+}
+
+// ListCommitStatuses lists both the check runs (reported by GitHub Apps) and the commit statuses of the commit
+func (g *GitHubClient) ListCommitStatuses(repository, commitSHA string) ([]*CommitStatus, error) {
+	checkRuns, err := g.ListCheckRuns(repository, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := g.Github.ListCommitStatuses(repository, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*CommitStatus
+	for _, cr := range checkRuns {
+		result = append(result, &CommitStatus{
+			Name:        cr.GetName(),
+			State:       gitHubCheckRunState(cr.GetStatus(), cr.GetConclusion()),
+			Description: cr.GetOutput().GetTitle(),
+			TargetURL:   cr.GetDetailsURL(),
+		})
+	}
+	for _, s := range statuses {
+		result = append(result, &CommitStatus{
+			Name:        s.GetContext(),
+			State:       gitHubStatusState(s.GetState()),
+			Description: s.GetDescription(),
+			TargetURL:   s.GetTargetURL(),
+		})
+	}
+	return result, nil
+}
+
+func (g *GitHubClient) ListWebhooks(repository string) ([]*Webhook, error) {
+	hooks, err := g.ListRepoWebhooks(repository)
+	if err != nil {
+		return nil, err
+	}
+	var result []*Webhook
+	for _, h := range hooks {
+		url, _ := h.Config["url"].(string)
+		result = append(result, &Webhook{ID: h.GetID(), URL: url})
+	}
+	return result, nil
+}
+
+func (g *GitHubClient) CreateWebhook(repository, url string) (*Webhook, error) {
+	hook, err := g.CreateWebhookForEvents(repository, url, []string{"push", "pull_request"})
+	if err != nil {
+		return nil, err
+	}
+	return &Webhook{ID: hook.GetID(), URL: url}, nil
+}
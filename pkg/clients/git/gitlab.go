@@ -3,6 +3,7 @@ package git
 import (
 	"encoding/base64"
 	"fmt"
+	"net/http"
 	"strings"
 
 	gitlab2 "github.com/xanzy/go-gitlab"
@@ -121,6 +122,10 @@ func (g *GitLabClient) CreatePullRequest(repository, title, body, head, base str
 
 func (g *GitLabClient) CleanupWebhooks(repository, clusterAppDomain string) error {
 	projectId := constants.GetGitLabProjectId(repository)
+	if projectId == "" {
+		// not one of the well known repositories, the path identifies the project as well
+		projectId = repository
+	}
 	return g.DeleteWebhooks(projectId, clusterAppDomain)
 }
 
@@ -159,3 +164,138 @@ func (g *GitLabClient) DeleteRepositoryIfExists(repoName string) error {
 	}
 	return nil
 }
+
+// ListPullRequestComments lists the comments of the merge request, the system notes are left out
+func (g *GitLabClient) ListPullRequestComments(repository string, prNumber int) ([]*Comment, error) {
+	opts := gitlab2.ListMergeRequestNotesOptions{
+		ListOptions: gitlab2.ListOptions{PerPage: 100},
+		OrderBy:     gitlab2.Ptr("created_at"),
+		Sort:        gitlab2.Ptr("asc"),
+	}
+	var result []*Comment
+	for {
+		notes, resp, err := g.GetClient().Notes.ListMergeRequestNotes(repository, prNumber, &opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list notes of merge request %d in project %s: %v", prNumber, repository, err)
+		}
+		for _, n := range notes {
+			if n.System {
+				continue
+			}
+			comment := &Comment{
+				ID:     int64(n.ID),
+				Author: n.Author.Username,
+				Body:   n.Body,
+			}
+			if n.CreatedAt != nil {
+				comment.CreatedAt = *n.CreatedAt
+			}
+			result = append(result, comment)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return result, nil
+}
+
+func (g *GitLabClient) UpdateFile(repository, pathToFile, content, branchName string) (*RepositoryFile, error) {
+	_, resp, err := g.GetClient().RepositoryFiles.GetFileMetaData(repository, pathToFile, &gitlab2.GetFileMetaDataOptions{Ref: gitlab2.Ptr(branchName)})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return g.CreateFile(repository, pathToFile, content, branchName)
+		}
+		return nil, fmt.Errorf("failed to get file %s in project %s: %v", pathToFile, repository, err)
+	}
+	commitSHA, err := g.GitlabClient.UpdateFile(repository, pathToFile, content, branchName)
+	if err != nil {
+		return nil, err
+	}
+	return &RepositoryFile{
+		CommitSHA: commitSHA,
+		Content:   content,
+	}, nil
+}
+
+func (g *GitLabClient) DeleteFile(repository, pathToFile, branchName string) error {
+	opts := gitlab2.DeleteFileOptions{
+		Branch:        gitlab2.Ptr(branchName),
+		CommitMessage: gitlab2.Ptr("delete test files"),
+	}
+	if _, err := g.GetClient().RepositoryFiles.DeleteFile(repository, pathToFile, &opts); err != nil {
+		return fmt.Errorf("failed to delete file %s in project %s: %v", pathToFile, repository, err)
+	}
+	return nil
+}
+
+func (g *GitLabClient) PushCommit(repository, branchName, message string, files map[string]string) (string, error) {
+	opts := gitlab2.CreateCommitOptions{
+		Branch:        gitlab2.Ptr(branchName),
+		CommitMessage: gitlab2.Ptr(message),
+	}
+	for path, content := range files {
+		action := gitlab2.FileCreate
+		_, resp, err := g.GetClient().RepositoryFiles.GetFileMetaData(repository, path, &gitlab2.GetFileMetaDataOptions{Ref: gitlab2.Ptr(branchName)})
+		switch {
+		case err == nil:
+			action = gitlab2.FileUpdate
+		case resp == nil || resp.StatusCode != http.StatusNotFound:
+			return "", fmt.Errorf("failed to get file %s in project %s: %v", path, repository, err)
+		}
+		opts.Actions = append(opts.Actions, &gitlab2.CommitActionOptions{
+			Action:   gitlab2.Ptr(action),
+			FilePath: gitlab2.Ptr(path),
+			Content:  gitlab2.Ptr(content),
+		})
+	}
+
+	commit, _, err := g.GetClient().Commits.CreateCommit(repository, &opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create commit in project %s: %v", repository, err)
+	}
+	return commit.ID, nil
+}
+
+func (g *GitLabClient) ListCommitStatuses(repository, commitSHA string) ([]*CommitStatus, error) {
+	statuses, _, err := g.GetClient().Commits.GetCommitStatuses(repository, commitSHA, &gitlab2.GetCommitStatusesOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commit statuses of %s in project %s: %v", commitSHA, repository, err)
+	}
+	var result []*CommitStatus
+	for _, s := range statuses {
+		result = append(result, &CommitStatus{
+			Name:        s.Name,
+			State:       gitLabStatusState(s.Status),
+			Description: s.Description,
+			TargetURL:   s.TargetURL,
+		})
+	}
+	return result, nil
+}
+
+func (g *GitLabClient) ListWebhooks(repository string) ([]*Webhook, error) {
+	hooks, _, err := g.GetClient().Projects.ListProjectHooks(repository, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project hooks for project id: %s with error: %v", repository, err)
+	}
+	var result []*Webhook
+	for _, h := range hooks {
+		result = append(result, &Webhook{ID: int64(h.ID), URL: h.URL})
+	}
+	return result, nil
+}
+
+func (g *GitLabClient) CreateWebhook(repository, url string) (*Webhook, error) {
+	opts := gitlab2.AddProjectHookOptions{
+		URL:                 gitlab2.Ptr(url),
+		PushEvents:          gitlab2.Ptr(true),
+		MergeRequestsEvents: gitlab2.Ptr(true),
+		NoteEvents:          gitlab2.Ptr(true),
+	}
+	hook, _, err := g.GetClient().Projects.AddProjectHook(repository, &opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project hook for project id: %s with error: %v", repository, err)
+	}
+	return &Webhook{ID: int64(hook.ID), URL: hook.URL}, nil
+}
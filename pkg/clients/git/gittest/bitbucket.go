@@ -0,0 +1,370 @@
+package gittest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/bitbucket"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+)
+
+// bitbucketHashLength is the length of the abbreviated commit hashes Bitbucket embeds in pull requests
+const bitbucketHashLength = 12
+
+// registerBitbucket registers the emulated Bitbucket Cloud REST API (2.0) endpoints under the prefix
+func (f *FakeForge) registerBitbucket(mux *http.ServeMux, prefix string) {
+	repo := prefix + "/repositories/{owner}/{repo}"
+
+	f.handle(mux, "DELETE "+repo, f.bitbucketRepository(func(w http.ResponseWriter, _ *http.Request, r *repository) {
+		f.deleteRepository(r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	f.handle(mux, "POST "+repo+"/forks", f.bitbucketRepository(f.bitbucketFork))
+
+	f.handle(mux, "POST "+repo+"/refs/branches", f.bitbucketRepository(bitbucketCreateBranch))
+	f.handle(mux, "GET "+repo+"/refs/branches/{branch}", f.bitbucketRepository(bitbucketGetBranch))
+	f.handle(mux, "DELETE "+repo+"/refs/branches/{branch}", f.bitbucketRepository(bitbucketDeleteBranch))
+	f.handle(mux, "GET "+repo+"/commit/{revision}", f.bitbucketRepository(bitbucketGetCommit))
+	f.handle(mux, "GET "+repo+"/commit/{revision}/statuses", f.bitbucketRepository(bitbucketListStatuses))
+
+	f.handle(mux, "GET "+repo+"/src/{revision}/{path...}", f.bitbucketRepository(bitbucketGetFile))
+	f.handle(mux, "POST "+repo+"/src", f.bitbucketRepository(f.bitbucketCommitFiles))
+
+	f.handle(mux, "GET "+repo+"/pullrequests", f.bitbucketRepository(bitbucketListPullRequests))
+	f.handle(mux, "POST "+repo+"/pullrequests", f.bitbucketRepository(f.bitbucketCreatePullRequest))
+	f.handle(mux, "GET "+repo+"/pullrequests/{id}", f.bitbucketPullRequest(func(w http.ResponseWriter, _ *http.Request, _ *repository, pr *pullRequest) {
+		writeJSON(w, http.StatusOK, bitbucketPullRequest(pr))
+	}))
+	f.handle(mux, "POST "+repo+"/pullrequests/{id}/merge", f.bitbucketPullRequest(bitbucketMergePullRequest))
+	f.handle(mux, "POST "+repo+"/pullrequests/{id}/decline", f.bitbucketPullRequest(bitbucketDeclinePullRequest))
+	f.handle(mux, "GET "+repo+"/pullrequests/{id}/comments", f.bitbucketPullRequest(bitbucketListComments))
+
+	f.handle(mux, "GET "+repo+"/hooks", f.bitbucketRepository(bitbucketListHooks))
+	f.handle(mux, "POST "+repo+"/hooks", f.bitbucketRepository(f.bitbucketCreateHook))
+	f.handle(mux, "DELETE "+repo+"/hooks/{uuid}", f.bitbucketRepository(bitbucketDeleteHook))
+}
+
+// bitbucketError responds with the error body of the Bitbucket API
+func bitbucketError(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]any{"type": "error", "error": map[string]string{"message": message}})
+}
+
+// bitbucketPage responds with a single page holding all the values
+func bitbucketPage[T any](w http.ResponseWriter, values []*T) {
+	if values == nil {
+		values = []*T{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"values": values, "pagelen": len(values), "page": 1, "size": len(values)})
+}
+
+// bitbucketRepository resolves the repository of the request, responding with 404 Not Found if it does not exist
+func (f *FakeForge) bitbucketRepository(handler func(http.ResponseWriter, *http.Request, *repository)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r := f.repository(req.PathValue("owner"), req.PathValue("repo"))
+		if r == nil {
+			bitbucketError(w, http.StatusNotFound, fmt.Sprintf("Repository %s/%s not found", req.PathValue("owner"), req.PathValue("repo")))
+			return
+		}
+		handler(w, req, r)
+	}
+}
+
+// bitbucketPullRequest resolves the pull request of the request, responding with 404 Not Found if it does not exist
+func (f *FakeForge) bitbucketPullRequest(handler func(http.ResponseWriter, *http.Request, *repository, *pullRequest)) http.HandlerFunc {
+	return f.bitbucketRepository(func(w http.ResponseWriter, req *http.Request, r *repository) {
+		id, ok := pathInt(w, req, "id")
+		if !ok {
+			return
+		}
+		pr := r.pullRequest(int(id))
+		if pr == nil {
+			bitbucketError(w, http.StatusNotFound, fmt.Sprintf("Pull request %d not found", id))
+			return
+		}
+		handler(w, req, r, pr)
+	})
+}
+
+func bitbucketRepository(r *repository) *bitbucket.Repository {
+	return &bitbucket.Repository{Slug: r.name, Name: r.name, FullName: r.fullName()}
+}
+
+func (f *FakeForge) bitbucketFork(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts struct {
+		Name      string `json:"name"`
+		Workspace struct {
+			Slug string `json:"slug"`
+		} `json:"workspace"`
+	}
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	owner, name := r.owner, r.name
+	if opts.Workspace.Slug != "" {
+		owner = opts.Workspace.Slug
+	}
+	if opts.Name != "" {
+		name = opts.Name
+	}
+	fork, err := f.fork(r, owner, name)
+	if err != nil {
+		bitbucketError(w, http.StatusBadRequest, "Repository with this Slug and Owner already exists.")
+		return
+	}
+	writeJSON(w, http.StatusCreated, bitbucketRepository(fork))
+}
+
+func bitbucketBranch(r *repository, branch string) *bitbucket.Branch {
+	return &bitbucket.Branch{Name: branch, Target: bitbucket.Commit{Hash: r.branches[branch]}}
+}
+
+func bitbucketCreateBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts bitbucket.Branch
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if _, ok := r.branches[opts.Name]; ok {
+		bitbucketError(w, http.StatusBadRequest, "BRANCH_ALREADY_EXISTS")
+		return
+	}
+	c := r.resolve(opts.Target.Hash)
+	if c == nil {
+		bitbucketError(w, http.StatusBadRequest, "Commit not found: "+opts.Target.Hash)
+		return
+	}
+	r.branches[opts.Name] = c.sha
+	writeJSON(w, http.StatusCreated, bitbucketBranch(r, opts.Name))
+}
+
+func bitbucketGetBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := req.PathValue("branch")
+	if _, ok := r.branches[branch]; !ok {
+		bitbucketError(w, http.StatusNotFound, "Branch not found: "+branch)
+		return
+	}
+	writeJSON(w, http.StatusOK, bitbucketBranch(r, branch))
+}
+
+func bitbucketDeleteBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := req.PathValue("branch")
+	if _, ok := r.branches[branch]; !ok {
+		bitbucketError(w, http.StatusNotFound, "Branch not found: "+branch)
+		return
+	}
+	delete(r.branches, branch)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func bitbucketGetCommit(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.PathValue("revision"))
+	if c == nil {
+		bitbucketError(w, http.StatusNotFound, "Commit not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, &bitbucket.Commit{Hash: c.sha})
+}
+
+// bitbucketStatusState translates the git.CommitStatus* state to the state of a Bitbucket build status
+func bitbucketStatusState(state string) string {
+	switch state {
+	case git.CommitStatusPending, git.CommitStatusRunning:
+		return "INPROGRESS"
+	case git.CommitStatusSuccess:
+		return "SUCCESSFUL"
+	case git.CommitStatusCanceled, git.CommitStatusSkipped:
+		return "STOPPED"
+	default:
+		return "FAILED"
+	}
+}
+
+func bitbucketListStatuses(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.PathValue("revision"))
+	if c == nil {
+		bitbucketError(w, http.StatusNotFound, "Commit not found")
+		return
+	}
+	var statuses []*bitbucket.CommitStatus
+	for _, s := range r.statuses[c.sha] {
+		statuses = append(statuses, &bitbucket.CommitStatus{
+			Key:         s.name,
+			Name:        s.name,
+			State:       bitbucketStatusState(s.state),
+			Description: s.description,
+			URL:         s.targetURL,
+		})
+	}
+	bitbucketPage(w, statuses)
+}
+
+// bitbucketGetFile serves the raw content of the file
+func bitbucketGetFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.PathValue("revision"))
+	if c == nil {
+		bitbucketError(w, http.StatusNotFound, "Commit not found")
+		return
+	}
+	content, ok := c.files[req.PathValue("path")]
+	if !ok {
+		bitbucketError(w, http.StatusNotFound, "No such file or directory: "+req.PathValue("path"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(content))
+}
+
+// bitbucketCommitFiles creates a commit from the multipart form, the fields other than message and branch
+// set the content of the files named after them while the "files" fields list the files to delete
+func (f *FakeForge) bitbucketCommitFiles(w http.ResponseWriter, req *http.Request, r *repository) {
+	if err := req.ParseMultipartForm(32 << 20); err != nil {
+		bitbucketError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	branch, message := req.FormValue("branch"), req.FormValue("message")
+	if branch == "" {
+		branch = r.defaultBranch
+	}
+	files := map[string]string{}
+	for name, values := range req.MultipartForm.Value {
+		switch name {
+		case "branch", "message", "files", "author", "parents":
+		default:
+			files[name] = values[0]
+		}
+	}
+	if r.branches[branch] == "" {
+		// a commit to a branch which does not exist creates it from the main branch
+		r.branches[branch] = r.branches[r.defaultBranch]
+	}
+	c, err := r.commitFiles(branch, message, files, req.MultipartForm.Value["files"])
+	if err != nil {
+		bitbucketError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("%s/repositories/%s/commit/%s", f.BitbucketURL(), r.fullName(), c.sha))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// bitbucketPullRequest serves the pull request with the abbreviated commit hashes Bitbucket embeds
+func bitbucketPullRequest(pr *pullRequest) *bitbucket.PullRequest {
+	state := "OPEN"
+	switch pr.state {
+	case pullRequestClosed:
+		state = "DECLINED"
+	case pullRequestMerged:
+		state = "MERGED"
+	}
+	result := &bitbucket.PullRequest{
+		ID:          pr.number,
+		Title:       pr.title,
+		Description: pr.body,
+		State:       state,
+	}
+	result.Source.Branch.Name = pr.head
+	result.Source.Commit = &bitbucket.Commit{Hash: pr.headSHA[:bitbucketHashLength]}
+	result.Destination.Branch.Name = pr.base
+	if pr.mergeSHA != "" {
+		result.MergeCommit = &bitbucket.Commit{Hash: pr.mergeSHA[:bitbucketHashLength]}
+	}
+	return result
+}
+
+func bitbucketListPullRequests(w http.ResponseWriter, req *http.Request, r *repository) {
+	states := req.URL.Query()["state"]
+	var prs []*bitbucket.PullRequest
+	for _, pr := range r.pulls {
+		result := bitbucketPullRequest(pr)
+		for _, state := range states {
+			if result.State == state {
+				prs = append(prs, result)
+				break
+			}
+		}
+		if len(states) == 0 && result.State == "OPEN" {
+			prs = append(prs, result)
+		}
+	}
+	bitbucketPage(w, prs)
+}
+
+func (f *FakeForge) bitbucketCreatePullRequest(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts bitbucket.PullRequest
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	base := opts.Destination.Branch.Name
+	if base == "" {
+		base = r.defaultBranch
+	}
+	pr, err := r.createPullRequest(f.newID(), opts.Title, opts.Description, opts.Source.Branch.Name, base)
+	if err != nil {
+		bitbucketError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, bitbucketPullRequest(pr))
+}
+
+func bitbucketMergePullRequest(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+	if err := r.mergePullRequest(pr); err != nil {
+		bitbucketError(w, http.StatusBadRequest, "You can't merge until you resolve all merge checks.")
+		return
+	}
+	writeJSON(w, http.StatusOK, bitbucketPullRequest(pr))
+}
+
+func bitbucketDeclinePullRequest(w http.ResponseWriter, _ *http.Request, _ *repository, pr *pullRequest) {
+	if pr.state != pullRequestOpen {
+		bitbucketError(w, http.StatusBadRequest, "You can't decline a pull request that is not open.")
+		return
+	}
+	pr.state = pullRequestClosed
+	writeJSON(w, http.StatusOK, bitbucketPullRequest(pr))
+}
+
+func bitbucketListComments(w http.ResponseWriter, _ *http.Request, _ *repository, pr *pullRequest) {
+	var comments []*bitbucket.Comment
+	for _, c := range pr.comments {
+		comment := &bitbucket.Comment{
+			ID:        c.id,
+			User:      &bitbucket.User{DisplayName: c.author, Nickname: c.author},
+			CreatedOn: c.created,
+		}
+		comment.Content.Raw = c.body
+		comments = append(comments, comment)
+	}
+	bitbucketPage(w, comments)
+}
+
+func bitbucketHook(h *hook) *bitbucket.Hook {
+	return &bitbucket.Hook{UUID: h.uuid, URL: h.url, Active: true, Events: h.events}
+}
+
+func bitbucketListHooks(w http.ResponseWriter, _ *http.Request, r *repository) {
+	var hooks []*bitbucket.Hook
+	for _, h := range r.hooks {
+		hooks = append(hooks, bitbucketHook(h))
+	}
+	bitbucketPage(w, hooks)
+}
+
+func (f *FakeForge) bitbucketCreateHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts bitbucket.Hook
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if opts.URL == "" || len(opts.Events) == 0 {
+		bitbucketError(w, http.StatusBadRequest, "url and events are required")
+		return
+	}
+	writeJSON(w, http.StatusCreated, bitbucketHook(r.addHook(f.newID(), opts.URL, opts.Events)))
+}
+
+func bitbucketDeleteHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	uuid := req.PathValue("uuid")
+	if !r.deleteHook(func(h *hook) bool { return h.uuid == uuid }) {
+		bitbucketError(w, http.StatusNotFound, "Webhook not found: "+uuid)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
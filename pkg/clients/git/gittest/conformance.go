@@ -0,0 +1,274 @@
+package gittest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+)
+
+// conformanceDomain is the cluster app domain of the webhooks created by the conformance suite
+const conformanceDomain = "apps.conformance.example.com"
+
+// Fixture names the repositories the conformance suite runs against, both in the form the git.Client
+// implementation expects them and as "owner/name" in the fake forge.
+type Fixture struct {
+	// Repository is the repository argument of the client calls, e.g. "hello" for GitHub or "qe/hello" for GitLab
+	Repository string
+	// ForgeRepository is the repository in the fake forge, it is created by the suite
+	ForgeRepository string
+	// DefaultBranch is the default branch of the repository
+	DefaultBranch string
+	// ForkTarget is the target repository of the fork in the form the client expects
+	ForkTarget string
+	// ForgeForkTarget is the fork in the fake forge
+	ForgeForkTarget string
+	// Unsupported lists the git.Client methods the provider cannot implement, the suite expects them to fail
+	Unsupported []string
+}
+
+func (fx Fixture) supports(method string) bool {
+	for _, m := range fx.Unsupported {
+		if m == method {
+			return false
+		}
+	}
+	return true
+}
+
+// RunConformance runs the conformance suite of the git.Client implementation pointed at the fake forge.
+// The scenarios build on each other the way the PaC tests use the clients: branch, commits, pull request,
+// statuses and comments reported on it, merge, then webhooks and forks of the repository.
+func RunConformance(t *testing.T, client git.Client, forge *FakeForge, fx Fixture) {
+	// some clients wait for their changes to be visible with gomega.Eventually
+	gomega.RegisterTestingT(t)
+
+	owner, name, _ := strings.Cut(fx.ForgeRepository, "/")
+	forge.CreateRepository(owner, name, fx.DefaultBranch, map[string]string{
+		"README.md":               "# hello\n",
+		".tekton/on-push.yaml":    "kind: PipelineRun\n",
+		".tekton/on-pull.yaml":    "kind: PipelineRun\n",
+		"docs/obsolete-notes.txt": "obsolete\n",
+	})
+	repo := fx.Repository
+	const branch = "conformance-feature"
+
+	t.Run("BranchExists", func(t *testing.T) {
+		exists, err := client.BranchExists(repo, fx.DefaultBranch)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = client.BranchExists(repo, "does-not-exist")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("CreateBranch", func(t *testing.T) {
+		require.NoError(t, client.CreateBranch(repo, fx.DefaultBranch, "", branch))
+		exists, err := client.BranchExists(repo, branch)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		head, _ := forge.BranchHead(fx.ForgeRepository, branch)
+		base, _ := forge.BranchHead(fx.ForgeRepository, fx.DefaultBranch)
+		assert.Equal(t, base, head, "the branch should start at the head of %s", fx.DefaultBranch)
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		created, err := client.CreateFile(repo, "conformance/created.txt", "created\n", branch)
+		require.NoError(t, err)
+		head, _ := forge.BranchHead(fx.ForgeRepository, branch)
+		assert.Equal(t, head, created.CommitSHA, "CreateFile should return the SHA of the commit")
+
+		file, err := client.GetFile(repo, "conformance/created.txt", branch)
+		require.NoError(t, err)
+		assert.Equal(t, "created\n", file.Content)
+
+		updated, err := client.UpdateFile(repo, "conformance/created.txt", "updated\n", branch)
+		require.NoError(t, err)
+		assert.Equal(t, "updated\n", updated.Content)
+		head, _ = forge.BranchHead(fx.ForgeRepository, branch)
+		assert.Equal(t, head, updated.CommitSHA, "UpdateFile should return the SHA of the commit")
+
+		missing, err := client.UpdateFile(repo, "conformance/missing.txt", "missing\n", branch)
+		require.NoError(t, err, "UpdateFile should create a missing file")
+		head, _ = forge.BranchHead(fx.ForgeRepository, branch)
+		assert.Equal(t, head, missing.CommitSHA)
+		assert.Equal(t, "missing\n", forge.Files(fx.ForgeRepository, branch)["conformance/missing.txt"])
+
+		require.NoError(t, client.DeleteFile(repo, "docs/obsolete-notes.txt", branch))
+		assert.NotContains(t, forge.Files(fx.ForgeRepository, branch), "docs/obsolete-notes.txt")
+		assert.Contains(t, forge.Files(fx.ForgeRepository, fx.DefaultBranch), "docs/obsolete-notes.txt",
+			"DeleteFile should only change the given branch")
+
+		_, err = client.GetFile(repo, "docs/obsolete-notes.txt", branch)
+		assert.Error(t, err)
+	})
+
+	t.Run("PushCommit", func(t *testing.T) {
+		sha, err := client.PushCommit(repo, branch, "update the pipelines", map[string]string{
+			".tekton/on-pull.yaml": "kind: PipelineRun\nmetadata:\n  name: on-pull\n",
+			"conformance/new.txt":  "new\n",
+		})
+		require.NoError(t, err)
+		head, _ := forge.BranchHead(fx.ForgeRepository, branch)
+		assert.Equal(t, head, sha)
+
+		files := forge.Files(fx.ForgeRepository, branch)
+		assert.Equal(t, "kind: PipelineRun\nmetadata:\n  name: on-pull\n", files[".tekton/on-pull.yaml"])
+		assert.Equal(t, "new\n", files["conformance/new.txt"])
+		assert.Equal(t, "updated\n", files["conformance/created.txt"])
+	})
+
+	var pr *git.PullRequest
+	t.Run("CreatePullRequest", func(t *testing.T) {
+		var err error
+		pr, err = client.CreatePullRequest(repo, "Conformance", "conformance test", branch, fx.DefaultBranch)
+		require.NoError(t, err)
+		assert.NotZero(t, pr.Number)
+		head, _ := forge.BranchHead(fx.ForgeRepository, branch)
+		assert.Equal(t, head, pr.HeadSHA)
+
+		prs, err := git.ListPullRequestsWithRetry(client, repo)
+		require.NoError(t, err)
+		require.Len(t, prs, 1)
+		assert.Equal(t, pr.Number, prs[0].Number)
+		assert.Equal(t, branch, prs[0].SourceBranch)
+		assert.Equal(t, fx.DefaultBranch, prs[0].TargetBranch)
+		assert.Equal(t, head, prs[0].HeadSHA)
+	})
+	if pr == nil {
+		t.FailNow()
+	}
+
+	t.Run("ListPullRequestComments", func(t *testing.T) {
+		require.NoError(t, forge.AddPullRequestComment(fx.ForgeRepository, pr.Number, "pac-bot", "PipelineRun started"))
+		require.NoError(t, forge.AddPullRequestComment(fx.ForgeRepository, pr.Number, "reviewer", "/retest"))
+
+		comments, err := client.ListPullRequestComments(repo, pr.Number)
+		require.NoError(t, err)
+		require.Len(t, comments, 2)
+		assert.Equal(t, "pac-bot", comments[0].Author)
+		assert.Equal(t, "PipelineRun started", comments[0].Body)
+		assert.Equal(t, "/retest", comments[1].Body)
+		assert.False(t, comments[0].CreatedAt.IsZero())
+		assert.False(t, comments[1].CreatedAt.Before(comments[0].CreatedAt), "comments should be listed oldest first")
+	})
+
+	t.Run("ListCommitStatuses", func(t *testing.T) {
+		require.NoError(t, forge.SetCommitStatus(fx.ForgeRepository, pr.HeadSHA, "hello-on-pull-request", git.CommitStatusSuccess, "PipelineRun succeeded"))
+		require.NoError(t, forge.SetCommitStatus(fx.ForgeRepository, pr.HeadSHA, "hello-enterprise-contract", git.CommitStatusFailure, "PipelineRun failed"))
+
+		statuses, err := client.ListCommitStatuses(repo, pr.HeadSHA)
+		require.NoError(t, err)
+		require.Len(t, statuses, 2)
+		byName := map[string]*git.CommitStatus{}
+		for _, s := range statuses {
+			byName[s.Name] = s
+		}
+		require.Contains(t, byName, "hello-on-pull-request")
+		require.Contains(t, byName, "hello-enterprise-contract")
+		assert.Equal(t, git.CommitStatusSuccess, byName["hello-on-pull-request"].State)
+		assert.Equal(t, "PipelineRun succeeded", byName["hello-on-pull-request"].Description)
+		assert.NotEmpty(t, byName["hello-on-pull-request"].TargetURL)
+		assert.Equal(t, git.CommitStatusFailure, byName["hello-enterprise-contract"].State)
+
+		status, err := git.WaitForCommitStatus(client, repo, pr.HeadSHA, "on-pull-request", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, status.IsFinished())
+	})
+
+	t.Run("UpdatePullRequestBranch", func(t *testing.T) {
+		_, err := client.PushCommit(repo, fx.DefaultBranch, "change the default branch", map[string]string{"CHANGELOG.md": "changed\n"})
+		require.NoError(t, err)
+
+		err = client.UpdatePullRequestBranch(repo, pr.Number)
+		if !fx.supports("UpdatePullRequestBranch") {
+			assert.Error(t, err)
+			return
+		}
+		require.NoError(t, err)
+		assert.Equal(t, "changed\n", forge.Files(fx.ForgeRepository, branch)["CHANGELOG.md"],
+			"the changes of %s should be brought to the pull request branch", fx.DefaultBranch)
+	})
+
+	t.Run("MergePullRequest", func(t *testing.T) {
+		merged, err := client.MergePullRequest(repo, pr.Number)
+		require.NoError(t, err)
+		head, _ := forge.BranchHead(fx.ForgeRepository, fx.DefaultBranch)
+		assert.Equal(t, head, merged.MergeCommitSHA)
+
+		files := forge.Files(fx.ForgeRepository, fx.DefaultBranch)
+		assert.Equal(t, "new\n", files["conformance/new.txt"])
+		assert.Equal(t, "changed\n", files["CHANGELOG.md"])
+		assert.NotContains(t, files, "docs/obsolete-notes.txt")
+
+		prs, err := client.ListPullRequests(repo)
+		require.NoError(t, err)
+		assert.Empty(t, prs, "the merged pull request should not be listed")
+	})
+
+	t.Run("DeleteBranchAndClosePullRequest", func(t *testing.T) {
+		const abandoned = "conformance-abandoned"
+		require.NoError(t, client.CreateBranch(repo, fx.DefaultBranch, "", abandoned))
+		_, err := client.PushCommit(repo, abandoned, "abandoned change", map[string]string{"abandoned.txt": "abandoned\n"})
+		require.NoError(t, err)
+		abandonedPR, err := client.CreatePullRequest(repo, "Abandoned", "to be closed", abandoned, fx.DefaultBranch)
+		require.NoError(t, err)
+
+		require.NoError(t, client.DeleteBranchAndClosePullRequest(repo, abandonedPR.Number))
+		exists, err := client.BranchExists(repo, abandoned)
+		require.NoError(t, err)
+		assert.False(t, exists)
+		prs, err := client.ListPullRequests(repo)
+		require.NoError(t, err)
+		assert.Empty(t, prs, "the pull request should be closed")
+
+		require.NoError(t, client.DeleteBranch(repo, branch))
+		exists, err = client.BranchExists(repo, branch)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Webhooks", func(t *testing.T) {
+		clusterHook := fmt.Sprintf("https://pipelines-as-code-controller.%s/hook", conformanceDomain)
+		otherHook := "https://hooks.example.org/other"
+		for _, url := range []string{otherHook, clusterHook} {
+			hook, err := client.CreateWebhook(repo, url)
+			require.NoError(t, err)
+			assert.Equal(t, url, hook.URL)
+		}
+
+		hooks, err := client.ListWebhooks(repo)
+		require.NoError(t, err)
+		var urls []string
+		for _, h := range hooks {
+			urls = append(urls, h.URL)
+		}
+		assert.ElementsMatch(t, []string{otherHook, clusterHook}, urls)
+
+		require.NoError(t, client.CleanupWebhooks(repo, conformanceDomain))
+		assert.Equal(t, []string{otherHook}, forge.Webhooks(fx.ForgeRepository),
+			"only the webhook of the cluster should be removed")
+	})
+
+	t.Run("ForkRepository", func(t *testing.T) {
+		require.NoError(t, client.ForkRepository(repo, fx.ForkTarget))
+		head, ok := forge.BranchHead(fx.ForgeForkTarget, fx.DefaultBranch)
+		require.True(t, ok, "the fork %s should exist", fx.ForgeForkTarget)
+		source, _ := forge.BranchHead(fx.ForgeRepository, fx.DefaultBranch)
+		assert.Equal(t, source, head)
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, client.DeleteRepositoryIfExists(fx.ForkTarget), "deleting the fork (attempt %d)", i+1)
+		}
+		_, ok = forge.BranchHead(fx.ForgeForkTarget, fx.DefaultBranch)
+		assert.False(t, ok, "the fork %s should be deleted", fx.ForgeForkTarget)
+	})
+}
@@ -0,0 +1,548 @@
+// Package gittest provides an in-process fake git forge and a conformance suite for the git.Client implementations.
+package gittest
+
+import (
+	"crypto/sha1" // #nosec G505 -- used only to derive commit identifiers
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeForge is an HTTP server emulating the subset of the GitHub, GitLab, Forgejo and Bitbucket REST APIs
+// the git clients use, backed by an in-memory repository model shared by all of them.
+// Repositories are addressed as "owner/name" (the GitHub organization, GitLab group, Forgejo organization
+// or Bitbucket workspace being the owner) and can be seeded with CreateRepository.
+type FakeForge struct {
+	server *httptest.Server
+
+	mu     sync.Mutex
+	repos  map[string]*repository
+	nextID int64
+}
+
+// NewFakeForge starts a fake forge, stop it with Close.
+func NewFakeForge() *FakeForge {
+	f := &FakeForge{repos: map[string]*repository{}}
+	mux := http.NewServeMux()
+	f.registerGitHub(mux, "/github")
+	f.registerGitLab(mux, "/gitlab/api/v4")
+	f.registerForgejo(mux, "/forgejo/api/v1")
+	f.registerBitbucket(mux, "/bitbucket/2.0")
+	f.server = httptest.NewServer(mux)
+	return f
+}
+
+// Close shuts the server down.
+func (f *FakeForge) Close() {
+	f.server.Close()
+}
+
+// GitHubURL returns the base URL of the emulated GitHub API.
+func (f *FakeForge) GitHubURL() string {
+	return f.server.URL + "/github/"
+}
+
+// GitLabURL returns the base URL of the emulated GitLab API.
+func (f *FakeForge) GitLabURL() string {
+	return f.server.URL + "/gitlab/api/v4"
+}
+
+// ForgejoURL returns the base URL of the emulated Forgejo instance (the API is served under /api/v1).
+func (f *FakeForge) ForgejoURL() string {
+	return f.server.URL + "/forgejo"
+}
+
+// BitbucketURL returns the base URL of the emulated Bitbucket Cloud API.
+func (f *FakeForge) BitbucketURL() string {
+	return f.server.URL + "/bitbucket/2.0"
+}
+
+// CreateRepository creates the repository with a single commit holding the files (path -> content) on the default branch.
+func (f *FakeForge) CreateRepository(owner, name, defaultBranch string, files map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r := f.newRepository(owner, name, defaultBranch)
+	c := r.addCommit("Initial commit", copyFiles(files))
+	r.branches[defaultBranch] = c.sha
+}
+
+// BranchHead returns the SHA of the commit the branch of the repository points to.
+func (f *FakeForge) BranchHead(repository, branch string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := f.repos[repository]
+	if !ok {
+		return "", false
+	}
+	sha, ok := r.branches[branch]
+	return sha, ok
+}
+
+// Files returns the files (path -> content) of the commit the branch of the repository points to.
+func (f *FakeForge) Files(repository, branch string) map[string]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := f.repos[repository]
+	if !ok {
+		return nil
+	}
+	c := r.resolve(branch)
+	if c == nil {
+		return nil
+	}
+	return copyFiles(c.files)
+}
+
+// AddPullRequestComment adds a comment to the pull request, as a bot reporting on it would.
+func (f *FakeForge) AddPullRequestComment(repository string, number int, author, body string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := f.repos[repository]
+	if !ok {
+		return fmt.Errorf("repository %s not found", repository)
+	}
+	pr := r.pullRequest(number)
+	if pr == nil {
+		return fmt.Errorf("pull request %d not found in %s", number, repository)
+	}
+	pr.comments = append(pr.comments, &comment{id: f.newID(), author: author, body: body, created: time.Now().UTC()})
+	return nil
+}
+
+// SetCommitStatus reports the status of the commit, state is one of the git.CommitStatus* states
+// and is translated to the vocabulary of each provider when served.
+// A status of the same name reported before is replaced.
+func (f *FakeForge) SetCommitStatus(repository, sha, name, state, description string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	r, ok := f.repos[repository]
+	if !ok {
+		return fmt.Errorf("repository %s not found", repository)
+	}
+	c := r.resolve(sha)
+	if c == nil {
+		return fmt.Errorf("commit %s not found in %s", sha, repository)
+	}
+	s := &status{id: f.newID(), name: name, state: state, description: description, targetURL: "https://console.example.com/" + name}
+	for i, existing := range r.statuses[c.sha] {
+		if existing.name == name {
+			r.statuses[c.sha][i] = s
+			return nil
+		}
+	}
+	r.statuses[c.sha] = append(r.statuses[c.sha], s)
+	return nil
+}
+
+// Webhooks returns the URLs of the webhooks of the repository.
+func (f *FakeForge) Webhooks(repository string) []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var urls []string
+	if r, ok := f.repos[repository]; ok {
+		for _, h := range r.hooks {
+			urls = append(urls, h.url)
+		}
+	}
+	return urls
+}
+
+type commit struct {
+	sha     string
+	tree    string
+	parents []string
+	message string
+	files   map[string]string
+	created time.Time
+}
+
+type pullRequest struct {
+	id       int64
+	number   int
+	title    string
+	body     string
+	head     string
+	base     string
+	headSHA  string
+	state    string
+	mergeSHA string
+	comments []*comment
+}
+
+const (
+	pullRequestOpen   = "open"
+	pullRequestClosed = "closed"
+	pullRequestMerged = "merged"
+)
+
+type comment struct {
+	id      int64
+	author  string
+	body    string
+	created time.Time
+}
+
+type hook struct {
+	id     int64
+	uuid   string
+	url    string
+	events []string
+}
+
+type status struct {
+	id          int64
+	name        string
+	state       string
+	description string
+	targetURL   string
+}
+
+type repository struct {
+	id            int64
+	owner         string
+	name          string
+	defaultBranch string
+	forkedFrom    string
+	branches      map[string]string
+	commits       map[string]*commit
+	pulls         []*pullRequest
+	hooks         []*hook
+	statuses      map[string][]*status
+	// trees maps the tree SHAs to the files of the tree
+	trees map[string]map[string]string
+	// fileSHAs maps the blob SHAs served for the file contents back to the contents
+	fileSHAs map[string]string
+}
+
+func (f *FakeForge) newID() int64 {
+	f.nextID++
+	return f.nextID
+}
+
+func (f *FakeForge) newRepository(owner, name, defaultBranch string) *repository {
+	r := &repository{
+		id:            f.newID(),
+		owner:         owner,
+		name:          name,
+		defaultBranch: defaultBranch,
+		branches:      map[string]string{},
+		commits:       map[string]*commit{},
+		statuses:      map[string][]*status{},
+		trees:         map[string]map[string]string{},
+		fileSHAs:      map[string]string{},
+	}
+	f.repos[r.fullName()] = r
+	return r
+}
+
+// repository returns the repository, nil if it does not exist
+func (f *FakeForge) repository(owner, name string) *repository {
+	return f.repos[owner+"/"+name]
+}
+
+// repositoryByID returns the repository with the numeric ID, nil if it does not exist
+func (f *FakeForge) repositoryByID(id int64) *repository {
+	for _, r := range f.repos {
+		if r.id == id {
+			return r
+		}
+	}
+	return nil
+}
+
+func (f *FakeForge) deleteRepository(r *repository) {
+	delete(f.repos, r.fullName())
+}
+
+func (f *FakeForge) renameRepository(r *repository, name string) {
+	delete(f.repos, r.fullName())
+	r.name = name
+	f.repos[r.fullName()] = r
+}
+
+// fork copies the branches and the history of the repository into a new one
+func (f *FakeForge) fork(source *repository, owner, name string) (*repository, error) {
+	if f.repository(owner, name) != nil {
+		return nil, fmt.Errorf("repository %s/%s already exists", owner, name)
+	}
+	r := f.newRepository(owner, name, source.defaultBranch)
+	r.forkedFrom = source.fullName()
+	for b, sha := range source.branches {
+		r.branches[b] = sha
+	}
+	for sha, c := range source.commits {
+		r.commits[sha] = c
+	}
+	for sha, files := range source.trees {
+		r.trees[sha] = files
+	}
+	for sha, content := range source.fileSHAs {
+		r.fileSHAs[sha] = content
+	}
+	return r, nil
+}
+
+func (r *repository) fullName() string {
+	return r.owner + "/" + r.name
+}
+
+// resolve returns the commit the ref points to, the ref being a branch name (optionally prefixed with
+// "heads/" or "refs/heads/"), a commit SHA or an abbreviation of it. Returns nil if the ref is unknown.
+func (r *repository) resolve(ref string) *commit {
+	if ref == "" {
+		ref = r.defaultBranch
+	}
+	branch := strings.TrimPrefix(strings.TrimPrefix(ref, "refs/"), "heads/")
+	if sha, ok := r.branches[branch]; ok {
+		return r.commits[sha]
+	}
+	if c, ok := r.commits[ref]; ok {
+		return c
+	}
+	if len(ref) >= 7 {
+		for sha, c := range r.commits {
+			if strings.HasPrefix(sha, ref) {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+func (r *repository) addCommit(message string, files map[string]string, parents ...string) *commit {
+	h := sha1.New() // #nosec G401 -- used only to derive commit identifiers
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00%s\x00", r.fullName(), message, len(r.commits), strings.Join(parents, ","))
+	tree := r.addTree(files)
+	fmt.Fprint(h, tree)
+
+	c := &commit{
+		sha:     hex.EncodeToString(h.Sum(nil)),
+		tree:    tree,
+		parents: parents,
+		message: message,
+		files:   files,
+		created: time.Now().UTC(),
+	}
+	r.commits[c.sha] = c
+	return c
+}
+
+// addTree stores the files (path -> content) as a tree and returns its SHA
+func (r *repository) addTree(files map[string]string) string {
+	h := sha1.New() // #nosec G401 -- used only to derive tree identifiers
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		fmt.Fprintf(h, "%s\x00%s\x00", p, files[p])
+		r.fileSHAs[blobSHA(files[p])] = files[p]
+	}
+	sha := hex.EncodeToString(h.Sum(nil))
+	r.trees[sha] = files
+	return sha
+}
+
+// commitFiles creates a commit on top of the branch setting the files (path -> content) and removing
+// the deleted ones, then moves the branch to it
+func (r *repository) commitFiles(branch, message string, files map[string]string, deleted []string) (*commit, error) {
+	head := r.resolve(branch)
+	if head == nil || r.branches[branch] == "" {
+		return nil, fmt.Errorf("branch %s not found", branch)
+	}
+	newFiles := copyFiles(head.files)
+	for p, content := range files {
+		newFiles[p] = content
+	}
+	for _, p := range deleted {
+		if _, ok := newFiles[p]; !ok {
+			return nil, fmt.Errorf("file %s not found", p)
+		}
+		delete(newFiles, p)
+	}
+	c := r.addCommit(message, newFiles, head.sha)
+	r.branches[branch] = c.sha
+	r.refreshPullRequests(branch)
+	return c, nil
+}
+
+// merge creates a merge commit of the source branch into the target branch and moves the target branch to it
+func (r *repository) merge(source, target, message string) (*commit, error) {
+	sourceHead, targetHead := r.resolve(source), r.resolve(target)
+	if sourceHead == nil || targetHead == nil {
+		return nil, fmt.Errorf("branch %s or %s not found", source, target)
+	}
+
+	files := copyFiles(targetHead.files)
+	if base := r.mergeBase(sourceHead.sha, targetHead.sha); base != nil {
+		for p, content := range sourceHead.files {
+			if baseContent, ok := base.files[p]; !ok || baseContent != content {
+				files[p] = content
+			}
+		}
+		for p := range base.files {
+			if _, ok := sourceHead.files[p]; !ok {
+				delete(files, p)
+			}
+		}
+	} else {
+		for p, content := range sourceHead.files {
+			files[p] = content
+		}
+	}
+
+	c := r.addCommit(message, files, targetHead.sha, sourceHead.sha)
+	r.branches[target] = c.sha
+	r.refreshPullRequests(target)
+	return c, nil
+}
+
+// mergeBase returns the closest common ancestor of the commits
+func (r *repository) mergeBase(a, b string) *commit {
+	ancestors := map[string]bool{}
+	queue := []string{a}
+	for len(queue) > 0 {
+		sha := queue[0]
+		queue = queue[1:]
+		if ancestors[sha] {
+			continue
+		}
+		ancestors[sha] = true
+		if c, ok := r.commits[sha]; ok {
+			queue = append(queue, c.parents...)
+		}
+	}
+	queue = []string{b}
+	visited := map[string]bool{}
+	for len(queue) > 0 {
+		sha := queue[0]
+		queue = queue[1:]
+		if visited[sha] {
+			continue
+		}
+		visited[sha] = true
+		if ancestors[sha] {
+			return r.commits[sha]
+		}
+		if c, ok := r.commits[sha]; ok {
+			queue = append(queue, c.parents...)
+		}
+	}
+	return nil
+}
+
+// refreshPullRequests updates the head SHA of the open pull requests from the branch
+func (r *repository) refreshPullRequests(branch string) {
+	for _, pr := range r.pulls {
+		if pr.state == pullRequestOpen && pr.head == branch {
+			pr.headSHA = r.branches[branch]
+		}
+	}
+}
+
+func (r *repository) createPullRequest(id int64, title, body, head, base string) (*pullRequest, error) {
+	headCommit, baseCommit := r.resolve(head), r.resolve(base)
+	if headCommit == nil || baseCommit == nil || r.branches[head] == "" || r.branches[base] == "" {
+		return nil, fmt.Errorf("branch %s or %s not found", head, base)
+	}
+	for _, pr := range r.pulls {
+		if pr.state == pullRequestOpen && pr.head == head && pr.base == base {
+			return nil, fmt.Errorf("a pull request for %s already exists", head)
+		}
+	}
+	pr := &pullRequest{
+		id:      id,
+		number:  len(r.pulls) + 1,
+		title:   title,
+		body:    body,
+		head:    head,
+		base:    base,
+		headSHA: headCommit.sha,
+		state:   pullRequestOpen,
+	}
+	r.pulls = append(r.pulls, pr)
+	return pr, nil
+}
+
+func (r *repository) pullRequest(number int) *pullRequest {
+	if number < 1 || number > len(r.pulls) {
+		return nil
+	}
+	return r.pulls[number-1]
+}
+
+func (r *repository) mergePullRequest(pr *pullRequest) error {
+	if pr.state != pullRequestOpen {
+		return fmt.Errorf("pull request %d is not open", pr.number)
+	}
+	c, err := r.merge(pr.head, pr.base, fmt.Sprintf("Merge pull request #%d from %s", pr.number, pr.head))
+	if err != nil {
+		return err
+	}
+	pr.state = pullRequestMerged
+	pr.mergeSHA = c.sha
+	return nil
+}
+
+// updatePullRequestBranch merges the base branch into the head branch of the pull request
+func (r *repository) updatePullRequestBranch(pr *pullRequest) error {
+	if pr.state != pullRequestOpen {
+		return fmt.Errorf("pull request %d is not open", pr.number)
+	}
+	_, err := r.merge(pr.base, pr.head, fmt.Sprintf("Merge branch '%s' into %s", pr.base, pr.head))
+	return err
+}
+
+func (r *repository) openPullRequests() []*pullRequest {
+	var prs []*pullRequest
+	for _, pr := range r.pulls {
+		if pr.state == pullRequestOpen {
+			prs = append(prs, pr)
+		}
+	}
+	return prs
+}
+
+func (r *repository) addHook(id int64, url string, events []string) *hook {
+	h := &hook{id: id, uuid: fmt.Sprintf("{%08d-0000-4000-8000-000000000000}", id), url: url, events: events}
+	r.hooks = append(r.hooks, h)
+	return h
+}
+
+// deleteHook removes the hook matching the predicate, returns false if there is none
+func (r *repository) deleteHook(match func(*hook) bool) bool {
+	for i, h := range r.hooks {
+		if match(h) {
+			r.hooks = append(r.hooks[:i], r.hooks[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// blobSHA returns the SHA of the blob object git would store the content as
+func blobSHA(content string) string {
+	h := sha1.New() // #nosec G401 -- used only to derive blob identifiers
+	fmt.Fprintf(h, "blob %d\x00%s", len(content), content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func copyFiles(files map[string]string) map[string]string {
+	c := make(map[string]string, len(files))
+	for p, content := range files {
+		c[p] = content
+	}
+	return c
+}
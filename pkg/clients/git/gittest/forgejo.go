@@ -0,0 +1,521 @@
+package gittest
+
+import (
+	"encoding/base64"
+	"net/http"
+	"path"
+
+	"codeberg.org/mvdkleijn/forgejo-sdk/forgejo/v2"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+)
+
+// forgejoVersion is the server version reported to the SDK, which gates some of its calls on it
+const forgejoVersion = "9.0.0"
+
+// registerForgejo registers the emulated Forgejo REST API (v1) endpoints under the prefix
+func (f *FakeForge) registerForgejo(mux *http.ServeMux, prefix string) {
+	repo := prefix + "/repos/{owner}/{repo}"
+
+	f.handle(mux, "GET "+prefix+"/version", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"version": forgejoVersion})
+	})
+
+	f.handle(mux, "GET "+repo, f.forgejoRepository(func(w http.ResponseWriter, _ *http.Request, r *repository) {
+		writeJSON(w, http.StatusOK, forgejoRepository(r))
+	}))
+	f.handle(mux, "DELETE "+repo, f.forgejoRepository(func(w http.ResponseWriter, _ *http.Request, r *repository) {
+		f.deleteRepository(r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	f.handle(mux, "POST "+repo+"/forks", f.forgejoRepository(f.forgejoFork))
+
+	f.handle(mux, "POST "+repo+"/branches", f.forgejoRepository(forgejoCreateBranch))
+	f.handle(mux, "GET "+repo+"/branches/{branch}", f.forgejoRepository(forgejoGetBranch))
+	f.handle(mux, "DELETE "+repo+"/branches/{branch}", f.forgejoRepository(forgejoDeleteBranch))
+
+	f.handle(mux, "GET "+repo+"/contents/{path...}", f.forgejoRepository(forgejoGetContents))
+	f.handle(mux, "POST "+repo+"/contents/{path...}", f.forgejoRepository(forgejoCreateFile))
+	f.handle(mux, "PUT "+repo+"/contents/{path...}", f.forgejoRepository(forgejoUpdateFile))
+	f.handle(mux, "DELETE "+repo+"/contents/{path...}", f.forgejoRepository(forgejoDeleteFile))
+	f.handle(mux, "POST "+repo+"/contents", f.forgejoRepository(forgejoChangeFiles))
+
+	f.handle(mux, "GET "+repo+"/pulls", f.forgejoRepository(forgejoListPullRequests))
+	f.handle(mux, "POST "+repo+"/pulls", f.forgejoRepository(f.forgejoCreatePullRequest))
+	f.handle(mux, "GET "+repo+"/pulls/{number}", f.forgejoPullRequest(func(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+		writeJSON(w, http.StatusOK, forgejoPullRequest(r, pr))
+	}))
+	f.handle(mux, "PATCH "+repo+"/pulls/{number}", f.forgejoPullRequest(forgejoEditPullRequest))
+	f.handle(mux, "POST "+repo+"/pulls/{number}/merge", f.forgejoPullRequest(forgejoMergePullRequest))
+	f.handle(mux, "POST "+repo+"/pulls/{number}/update", f.forgejoPullRequest(forgejoUpdatePullRequest))
+	f.handle(mux, "GET "+repo+"/issues/{number}/comments", f.forgejoPullRequest(forgejoListComments))
+
+	f.handle(mux, "GET "+repo+"/commits/{ref}/status", f.forgejoRepository(forgejoCombinedStatus))
+
+	f.handle(mux, "GET "+repo+"/hooks", f.forgejoRepository(forgejoListHooks))
+	f.handle(mux, "POST "+repo+"/hooks", f.forgejoRepository(f.forgejoCreateHook))
+	f.handle(mux, "DELETE "+repo+"/hooks/{id}", f.forgejoRepository(forgejoDeleteHook))
+}
+
+// forgejoRepository resolves the repository of the request, responding with 404 Not Found if it does not exist
+func (f *FakeForge) forgejoRepository(handler func(http.ResponseWriter, *http.Request, *repository)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r := f.repository(req.PathValue("owner"), req.PathValue("repo"))
+		if r == nil {
+			writeMessage(w, http.StatusNotFound, "The target couldn't be found.")
+			return
+		}
+		handler(w, req, r)
+	}
+}
+
+// forgejoPullRequest resolves the pull request of the request, responding with 404 Not Found if it does not exist
+func (f *FakeForge) forgejoPullRequest(handler func(http.ResponseWriter, *http.Request, *repository, *pullRequest)) http.HandlerFunc {
+	return f.forgejoRepository(func(w http.ResponseWriter, req *http.Request, r *repository) {
+		number, ok := pathInt(w, req, "number")
+		if !ok {
+			return
+		}
+		pr := r.pullRequest(int(number))
+		if pr == nil {
+			writeMessage(w, http.StatusNotFound, "The target couldn't be found.")
+			return
+		}
+		handler(w, req, r, pr)
+	})
+}
+
+func forgejoRepository(r *repository) *forgejo.Repository {
+	return &forgejo.Repository{
+		ID:            r.id,
+		Name:          r.name,
+		FullName:      r.fullName(),
+		Owner:         &forgejo.User{UserName: r.owner},
+		DefaultBranch: r.defaultBranch,
+		Fork:          r.forkedFrom != "",
+	}
+}
+
+func (f *FakeForge) forgejoFork(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts forgejo.CreateForkOption
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	owner, name := r.owner, r.name
+	if opts.Organization != nil {
+		owner = *opts.Organization
+	}
+	if opts.Name != nil {
+		name = *opts.Name
+	}
+	fork, err := f.fork(r, owner, name)
+	if err != nil {
+		writeMessage(w, http.StatusConflict, "The repository with the same name already exists.")
+		return
+	}
+	writeJSON(w, http.StatusAccepted, forgejoRepository(fork))
+}
+
+func forgejoBranch(r *repository, branch string) *forgejo.Branch {
+	c := r.commits[r.branches[branch]]
+	return &forgejo.Branch{
+		Name:        branch,
+		Commit:      &forgejo.PayloadCommit{ID: c.sha, Message: c.message, Timestamp: c.created},
+		UserCanPush: true,
+	}
+}
+
+func forgejoCreateBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts forgejo.CreateBranchOption
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if _, ok := r.branches[opts.BranchName]; ok {
+		writeMessage(w, http.StatusConflict, "The branch already exists.")
+		return
+	}
+	c := r.resolve(opts.OldBranchName)
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "The old branch does not exist")
+		return
+	}
+	r.branches[opts.BranchName] = c.sha
+	writeJSON(w, http.StatusCreated, forgejoBranch(r, opts.BranchName))
+}
+
+func forgejoGetBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := req.PathValue("branch")
+	if _, ok := r.branches[branch]; !ok {
+		writeMessage(w, http.StatusNotFound, "Branch doesn't exist.")
+		return
+	}
+	writeJSON(w, http.StatusOK, forgejoBranch(r, branch))
+}
+
+func forgejoDeleteBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := req.PathValue("branch")
+	if _, ok := r.branches[branch]; !ok {
+		writeMessage(w, http.StatusNotFound, "Branch doesn't exist.")
+		return
+	}
+	delete(r.branches, branch)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func forgejoContents(filePath, content string) *forgejo.ContentsResponse {
+	encoding, encoded := "base64", base64Encode(content)
+	return &forgejo.ContentsResponse{
+		Name:     path.Base(filePath),
+		Path:     filePath,
+		SHA:      blobSHA(content),
+		Type:     "file",
+		Size:     int64(len(content)),
+		Encoding: &encoding,
+		Content:  &encoded,
+	}
+}
+
+func forgejoFileCommit(c *commit) *forgejo.FileCommitResponse {
+	return &forgejo.FileCommitResponse{
+		CommitMeta: forgejo.CommitMeta{SHA: c.sha, Created: c.created},
+		Message:    c.message,
+	}
+}
+
+func forgejoGetContents(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.URL.Query().Get("ref"))
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "The target couldn't be found.")
+		return
+	}
+	filePath := req.PathValue("path")
+	content, ok := c.files[filePath]
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "The target couldn't be found.")
+		return
+	}
+	writeJSON(w, http.StatusOK, forgejoContents(filePath, content))
+}
+
+// forgejoWriteFile commits the base64 encoded content of the file to the branch (the default one if
+// it is empty), sha must be the SHA of the current content of the file unless it is created
+func forgejoWriteFile(w http.ResponseWriter, r *repository, filePath, branch, message, encoded, sha string, statusCode int) {
+	if branch == "" {
+		branch = r.defaultBranch
+	}
+	head := r.resolve(branch)
+	if head == nil || r.branches[branch] == "" {
+		writeMessage(w, http.StatusNotFound, "branch does not exist ["+branch+"]")
+		return
+	}
+	current, exists := head.files[filePath]
+	switch {
+	case statusCode == http.StatusCreated && exists:
+		writeMessage(w, http.StatusUnprocessableEntity, "repository file already exists ["+filePath+"]")
+		return
+	case statusCode != http.StatusCreated && !exists:
+		writeMessage(w, http.StatusNotFound, "file does not exist ["+filePath+"]")
+		return
+	case statusCode != http.StatusCreated && sha != blobSHA(current):
+		writeMessage(w, http.StatusUnprocessableEntity, "sha does not match ["+sha+"]")
+		return
+	}
+	content, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, "content is not valid base64: "+err.Error())
+		return
+	}
+	c, err := r.commitFiles(branch, message, map[string]string{filePath: string(content)}, nil)
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, statusCode, &forgejo.FileResponse{Content: forgejoContents(filePath, string(content)), Commit: forgejoFileCommit(c)})
+}
+
+func forgejoCreateFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts forgejo.CreateFileOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	forgejoWriteFile(w, r, req.PathValue("path"), opts.BranchName, opts.Message, opts.Content, "", http.StatusCreated)
+}
+
+func forgejoUpdateFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts forgejo.UpdateFileOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	forgejoWriteFile(w, r, req.PathValue("path"), opts.BranchName, opts.Message, opts.Content, opts.SHA, http.StatusOK)
+}
+
+func forgejoDeleteFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts forgejo.DeleteFileOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	branch := opts.BranchName
+	if branch == "" {
+		branch = r.defaultBranch
+	}
+	head := r.resolve(branch)
+	if head == nil || r.branches[branch] == "" {
+		writeMessage(w, http.StatusNotFound, "branch does not exist ["+branch+"]")
+		return
+	}
+	filePath := req.PathValue("path")
+	current, exists := head.files[filePath]
+	if !exists {
+		writeMessage(w, http.StatusNotFound, "file does not exist ["+filePath+"]")
+		return
+	}
+	if opts.SHA != blobSHA(current) {
+		writeMessage(w, http.StatusBadRequest, "sha does not match ["+opts.SHA+"]")
+		return
+	}
+	c, err := r.commitFiles(branch, opts.Message, nil, []string{filePath})
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, &forgejo.FileDeleteResponse{Commit: forgejoFileCommit(c)})
+}
+
+// forgejoChangeFiles creates a single commit from the create, update and delete operations on the files
+func forgejoChangeFiles(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts struct {
+		Message string `json:"message"`
+		Branch  string `json:"branch"`
+		Files   []struct {
+			Operation string `json:"operation"`
+			Path      string `json:"path"`
+			Content   string `json:"content"`
+			SHA       string `json:"sha"`
+		} `json:"files"`
+	}
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	branch := opts.Branch
+	if branch == "" {
+		branch = r.defaultBranch
+	}
+	head := r.resolve(branch)
+	if head == nil || r.branches[branch] == "" {
+		writeMessage(w, http.StatusNotFound, "branch does not exist ["+branch+"]")
+		return
+	}
+
+	files := map[string]string{}
+	var deleted []string
+	for _, file := range opts.Files {
+		current, exists := head.files[file.Path]
+		switch {
+		case file.Operation == "create" && exists:
+			writeMessage(w, http.StatusUnprocessableEntity, "repository file already exists ["+file.Path+"]")
+			return
+		case file.Operation != "create" && !exists:
+			writeMessage(w, http.StatusNotFound, "file does not exist ["+file.Path+"]")
+			return
+		case file.Operation != "create" && file.SHA != blobSHA(current):
+			writeMessage(w, http.StatusUnprocessableEntity, "sha does not match ["+file.SHA+"]")
+			return
+		}
+		switch file.Operation {
+		case "create", "update":
+			content, err := base64.StdEncoding.DecodeString(file.Content)
+			if err != nil {
+				writeMessage(w, http.StatusUnprocessableEntity, "content is not valid base64: "+err.Error())
+				return
+			}
+			files[file.Path] = string(content)
+		case "delete":
+			deleted = append(deleted, file.Path)
+		default:
+			writeMessage(w, http.StatusUnprocessableEntity, "operation "+file.Operation+" is not supported")
+			return
+		}
+	}
+
+	c, err := r.commitFiles(branch, opts.Message, files, deleted)
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]any{"commit": forgejoFileCommit(c)})
+}
+
+func forgejoPullRequest(r *repository, pr *pullRequest) *forgejo.PullRequest {
+	state := forgejo.StateOpen
+	if pr.state != pullRequestOpen {
+		state = forgejo.StateClosed
+	}
+	result := &forgejo.PullRequest{
+		ID:        pr.id,
+		Index:     int64(pr.number),
+		Title:     pr.title,
+		Body:      pr.body,
+		State:     state,
+		Mergeable: pr.state == pullRequestOpen,
+		HasMerged: pr.state == pullRequestMerged,
+		Head:      &forgejo.PRBranchInfo{Name: pr.head, Ref: pr.head, Sha: pr.headSHA, RepoID: r.id},
+		Base:      &forgejo.PRBranchInfo{Name: pr.base, Ref: pr.base, Sha: r.branches[pr.base], RepoID: r.id},
+	}
+	if pr.mergeSHA != "" {
+		mergeSHA := pr.mergeSHA
+		result.MergedCommitID = &mergeSHA
+	}
+	return result
+}
+
+func forgejoListPullRequests(w http.ResponseWriter, req *http.Request, r *repository) {
+	state := forgejo.StateType(req.URL.Query().Get("state"))
+	prs := []*forgejo.PullRequest{}
+	for _, pr := range r.pulls {
+		result := forgejoPullRequest(r, pr)
+		if state == "" || state == forgejo.StateAll || result.State == state {
+			prs = append(prs, result)
+		}
+	}
+	writeJSON(w, http.StatusOK, prs)
+}
+
+func (f *FakeForge) forgejoCreatePullRequest(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts forgejo.CreatePullRequestOption
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	pr, err := r.createPullRequest(f.newID(), opts.Title, opts.Body, opts.Head, opts.Base)
+	if err != nil {
+		writeMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, forgejoPullRequest(r, pr))
+}
+
+func forgejoEditPullRequest(w http.ResponseWriter, req *http.Request, r *repository, pr *pullRequest) {
+	var opts forgejo.EditPullRequestOption
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if opts.State != nil {
+		switch {
+		case *opts.State == forgejo.StateClosed && pr.state == pullRequestOpen:
+			pr.state = pullRequestClosed
+		case *opts.State == forgejo.StateOpen && pr.state == pullRequestClosed:
+			pr.state = pullRequestOpen
+			pr.headSHA = r.branches[pr.head]
+		}
+	}
+	if opts.Title != "" {
+		pr.title = opts.Title
+	}
+	writeJSON(w, http.StatusCreated, forgejoPullRequest(r, pr))
+}
+
+func forgejoMergePullRequest(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+	if err := r.mergePullRequest(pr); err != nil {
+		writeMessage(w, http.StatusMethodNotAllowed, "Please try again later")
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func forgejoUpdatePullRequest(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+	if err := r.updatePullRequestBranch(pr); err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func forgejoListComments(w http.ResponseWriter, _ *http.Request, _ *repository, pr *pullRequest) {
+	comments := []*forgejo.Comment{}
+	for _, c := range pr.comments {
+		comments = append(comments, &forgejo.Comment{
+			ID:      c.id,
+			Body:    c.body,
+			Poster:  &forgejo.User{UserName: c.author},
+			Created: c.created,
+			Updated: c.created,
+		})
+	}
+	writeJSON(w, http.StatusOK, comments)
+}
+
+// forgejoStatusState translates the git.CommitStatus* state to the state of a Forgejo commit status
+func forgejoStatusState(state string) forgejo.StatusState {
+	switch state {
+	case git.CommitStatusPending, git.CommitStatusRunning:
+		return forgejo.StatusPending
+	case git.CommitStatusSuccess:
+		return forgejo.StatusSuccess
+	case git.CommitStatusNeutral, git.CommitStatusSkipped:
+		return forgejo.StatusWarning
+	case git.CommitStatusCanceled:
+		return forgejo.StatusError
+	default:
+		return forgejo.StatusFailure
+	}
+}
+
+func forgejoCombinedStatus(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.PathValue("ref"))
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "The target couldn't be found.")
+		return
+	}
+	combined := &forgejo.CombinedStatus{SHA: c.sha, State: forgejo.StatusSuccess, Statuses: []*forgejo.Status{}}
+	for _, s := range r.statuses[c.sha] {
+		combined.Statuses = append(combined.Statuses, &forgejo.Status{
+			ID:          s.id,
+			State:       forgejoStatusState(s.state),
+			Context:     s.name,
+			Description: s.description,
+			TargetURL:   s.targetURL,
+		})
+	}
+	combined.TotalCount = len(combined.Statuses)
+	writeJSON(w, http.StatusOK, combined)
+}
+
+func forgejoHook(h *hook) *forgejo.Hook {
+	return &forgejo.Hook{
+		ID:     h.id,
+		Type:   string(forgejo.HookTypeForgejo),
+		Config: map[string]string{"url": h.url, "content_type": "json"},
+		Events: h.events,
+		Active: true,
+	}
+}
+
+func forgejoListHooks(w http.ResponseWriter, _ *http.Request, r *repository) {
+	hooks := []*forgejo.Hook{}
+	for _, h := range r.hooks {
+		hooks = append(hooks, forgejoHook(h))
+	}
+	writeJSON(w, http.StatusOK, hooks)
+}
+
+func (f *FakeForge) forgejoCreateHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts forgejo.CreateHookOption
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if opts.Config["url"] == "" {
+		writeMessage(w, http.StatusUnprocessableEntity, "Missing config option: url")
+		return
+	}
+	writeJSON(w, http.StatusCreated, forgejoHook(r.addHook(f.newID(), opts.Config["url"], opts.Events)))
+}
+
+func forgejoDeleteHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	id, ok := pathInt(w, req, "id")
+	if !ok {
+		return
+	}
+	if !r.deleteHook(func(h *hook) bool { return h.id == id }) {
+		writeMessage(w, http.StatusNotFound, "The target couldn't be found.")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
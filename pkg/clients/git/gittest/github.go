@@ -0,0 +1,601 @@
+package gittest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+)
+
+// registerGitHub registers the emulated GitHub REST API endpoints under the prefix
+func (f *FakeForge) registerGitHub(mux *http.ServeMux, prefix string) {
+	repo := prefix + "/repos/{owner}/{repo}"
+
+	f.handle(mux, "GET "+repo, f.gitHubRepository(func(w http.ResponseWriter, _ *http.Request, r *repository) {
+		writeJSON(w, http.StatusOK, gitHubRepository(r))
+	}))
+	f.handle(mux, "PATCH "+repo, f.gitHubRepository(f.gitHubEditRepository))
+	f.handle(mux, "DELETE "+repo, f.gitHubRepository(func(w http.ResponseWriter, _ *http.Request, r *repository) {
+		f.deleteRepository(r)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	f.handle(mux, "POST "+repo+"/forks", f.gitHubRepository(f.gitHubFork))
+	f.handle(mux, "GET "+repo+"/commits", f.gitHubRepository(gitHubListCommits))
+
+	f.handle(mux, "GET "+repo+"/git/ref/{ref...}", f.gitHubRepository(gitHubGetRef))
+	f.handle(mux, "POST "+repo+"/git/refs", f.gitHubRepository(gitHubCreateRef))
+	f.handle(mux, "PATCH "+repo+"/git/refs/{ref...}", f.gitHubRepository(gitHubUpdateRef))
+	f.handle(mux, "DELETE "+repo+"/git/refs/{ref...}", f.gitHubRepository(gitHubDeleteRef))
+	f.handle(mux, "GET "+repo+"/git/commits/{sha}", f.gitHubRepository(gitHubGetCommit))
+	f.handle(mux, "POST "+repo+"/git/commits", f.gitHubRepository(gitHubCreateCommit))
+	f.handle(mux, "POST "+repo+"/git/trees", f.gitHubRepository(gitHubCreateTree))
+
+	f.handle(mux, "GET "+repo+"/contents/{path...}", f.gitHubRepository(gitHubGetContents))
+	f.handle(mux, "PUT "+repo+"/contents/{path...}", f.gitHubRepository(gitHubPutContents))
+	f.handle(mux, "DELETE "+repo+"/contents/{path...}", f.gitHubRepository(gitHubDeleteContents))
+
+	f.handle(mux, "GET "+repo+"/pulls", f.gitHubRepository(gitHubListPullRequests))
+	f.handle(mux, "POST "+repo+"/pulls", f.gitHubRepository(f.gitHubCreatePullRequest))
+	f.handle(mux, "GET "+repo+"/pulls/{number}", f.gitHubPullRequest(func(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+		writeJSON(w, http.StatusOK, gitHubPullRequest(r, pr))
+	}))
+	f.handle(mux, "PUT "+repo+"/pulls/{number}/merge", f.gitHubPullRequest(gitHubMergePullRequest))
+	f.handle(mux, "PUT "+repo+"/pulls/{number}/update-branch", f.gitHubPullRequest(gitHubUpdatePullRequestBranch))
+	f.handle(mux, "GET "+repo+"/issues/{number}/comments", f.gitHubPullRequest(gitHubListComments))
+
+	f.handle(mux, "GET "+repo+"/commits/{ref}/check-runs", f.gitHubRepository(gitHubListCheckRuns))
+	f.handle(mux, "GET "+repo+"/commits/{ref}/status", f.gitHubRepository(gitHubCombinedStatus))
+
+	f.handle(mux, "GET "+repo+"/hooks", f.gitHubRepository(gitHubListHooks))
+	f.handle(mux, "POST "+repo+"/hooks", f.gitHubRepository(f.gitHubCreateHook))
+	f.handle(mux, "DELETE "+repo+"/hooks/{id}", f.gitHubRepository(gitHubDeleteHook))
+}
+
+// gitHubRepository resolves the repository of the request, responding with 404 Not Found if it does not exist
+func (f *FakeForge) gitHubRepository(handler func(http.ResponseWriter, *http.Request, *repository)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		r := f.repository(req.PathValue("owner"), req.PathValue("repo"))
+		if r == nil {
+			writeMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		handler(w, req, r)
+	}
+}
+
+// gitHubPullRequest resolves the pull request of the request, responding with 404 Not Found if it does not exist
+func (f *FakeForge) gitHubPullRequest(handler func(http.ResponseWriter, *http.Request, *repository, *pullRequest)) http.HandlerFunc {
+	return f.gitHubRepository(func(w http.ResponseWriter, req *http.Request, r *repository) {
+		number, ok := pathInt(w, req, "number")
+		if !ok {
+			return
+		}
+		pr := r.pullRequest(int(number))
+		if pr == nil {
+			writeMessage(w, http.StatusNotFound, "Not Found")
+			return
+		}
+		handler(w, req, r, pr)
+	})
+}
+
+func gitHubRepository(r *repository) *github.Repository {
+	return &github.Repository{
+		ID:            github.Int64(r.id),
+		Name:          github.String(r.name),
+		FullName:      github.String(r.fullName()),
+		DefaultBranch: github.String(r.defaultBranch),
+		Owner:         &github.User{Login: github.String(r.owner)},
+		Fork:          github.Bool(r.forkedFrom != ""),
+	}
+}
+
+func (f *FakeForge) gitHubEditRepository(w http.ResponseWriter, req *http.Request, r *repository) {
+	var edit github.Repository
+	if !readJSON(w, req, &edit) {
+		return
+	}
+	if name := edit.GetName(); name != "" && name != r.name {
+		if f.repository(r.owner, name) != nil {
+			writeMessage(w, http.StatusUnprocessableEntity, "name already exists on this account")
+			return
+		}
+		f.renameRepository(r, name)
+	}
+	writeJSON(w, http.StatusOK, gitHubRepository(r))
+}
+
+// gitHubFork forks the repository asynchronously as GitHub does (202 Accepted), a fork conflicting
+// with an existing repository is named with a numeric suffix
+func (f *FakeForge) gitHubFork(w http.ResponseWriter, req *http.Request, r *repository) {
+	owner := req.URL.Query().Get("organization")
+	if owner == "" {
+		owner = r.owner
+	}
+	name := r.name
+	for i := 1; f.repository(owner, name) != nil; i++ {
+		name = fmt.Sprintf("%s-%d", r.name, i)
+	}
+	fork, err := f.fork(r, owner, name)
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, gitHubRepository(fork))
+}
+
+func gitHubListCommits(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.URL.Query().Get("sha"))
+	commits := []*github.RepositoryCommit{}
+	for c != nil {
+		commits = append(commits, &github.RepositoryCommit{SHA: github.String(c.sha)})
+		if len(c.parents) == 0 {
+			break
+		}
+		c = r.commits[c.parents[0]]
+	}
+	writeJSON(w, http.StatusOK, commits)
+}
+
+func gitHubReference(branch, sha string) *github.Reference {
+	return &github.Reference{
+		Ref:    github.String("refs/heads/" + branch),
+		Object: &github.GitObject{SHA: github.String(sha), Type: github.String("commit")},
+	}
+}
+
+// gitHubBranch returns the branch name of the "heads/<branch>" ref of the request
+func gitHubBranch(req *http.Request) string {
+	return strings.TrimPrefix(req.PathValue("ref"), "heads/")
+}
+
+func gitHubGetRef(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := gitHubBranch(req)
+	sha, ok := r.branches[branch]
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitHubReference(branch, sha))
+}
+
+func gitHubCreateRef(w http.ResponseWriter, req *http.Request, r *repository) {
+	var ref struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	}
+	if !readJSON(w, req, &ref) {
+		return
+	}
+	branch := strings.TrimPrefix(ref.Ref, "refs/heads/")
+	if _, ok := r.branches[branch]; ok {
+		writeMessage(w, http.StatusUnprocessableEntity, "Reference already exists")
+		return
+	}
+	if _, ok := r.commits[ref.SHA]; !ok {
+		writeMessage(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+	r.branches[branch] = ref.SHA
+	writeJSON(w, http.StatusCreated, gitHubReference(branch, ref.SHA))
+}
+
+func gitHubUpdateRef(w http.ResponseWriter, req *http.Request, r *repository) {
+	var ref struct {
+		SHA   string `json:"sha"`
+		Force bool   `json:"force"`
+	}
+	if !readJSON(w, req, &ref) {
+		return
+	}
+	branch := gitHubBranch(req)
+	current, ok := r.branches[branch]
+	if !ok {
+		writeMessage(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+	if _, ok := r.commits[ref.SHA]; !ok {
+		writeMessage(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+	if !ref.Force && r.mergeBase(ref.SHA, current) != r.commits[current] {
+		writeMessage(w, http.StatusUnprocessableEntity, "Update is not a fast forward")
+		return
+	}
+	r.branches[branch] = ref.SHA
+	r.refreshPullRequests(branch)
+	writeJSON(w, http.StatusOK, gitHubReference(branch, ref.SHA))
+}
+
+// gitHubDeleteRef deletes the branch, closing the open pull requests from it as GitHub does
+func gitHubDeleteRef(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := gitHubBranch(req)
+	if _, ok := r.branches[branch]; !ok {
+		writeMessage(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+	delete(r.branches, branch)
+	for _, pr := range r.openPullRequests() {
+		if pr.head == branch {
+			pr.state = pullRequestClosed
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func gitHubCommit(c *commit) *github.Commit {
+	parents := make([]*github.Commit, 0, len(c.parents))
+	for _, p := range c.parents {
+		parents = append(parents, &github.Commit{SHA: github.String(p)})
+	}
+	return &github.Commit{
+		SHA:     github.String(c.sha),
+		Message: github.String(c.message),
+		Tree:    &github.Tree{SHA: github.String(c.tree)},
+		Parents: parents,
+	}
+}
+
+func gitHubGetCommit(w http.ResponseWriter, req *http.Request, r *repository) {
+	c, ok := r.commits[req.PathValue("sha")]
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitHubCommit(c))
+}
+
+func gitHubCreateCommit(w http.ResponseWriter, req *http.Request, r *repository) {
+	var in struct {
+		Message string   `json:"message"`
+		Tree    string   `json:"tree"`
+		Parents []string `json:"parents"`
+	}
+	if !readJSON(w, req, &in) {
+		return
+	}
+	files, ok := r.trees[in.Tree]
+	if !ok {
+		writeMessage(w, http.StatusUnprocessableEntity, "Tree SHA does not exist")
+		return
+	}
+	for _, p := range in.Parents {
+		if _, ok := r.commits[p]; !ok {
+			writeMessage(w, http.StatusUnprocessableEntity, "Parent SHA does not exist or is not a commit object")
+			return
+		}
+	}
+	writeJSON(w, http.StatusCreated, gitHubCommit(r.addCommit(in.Message, copyFiles(files), in.Parents...)))
+}
+
+// gitHubCreateTree creates a tree on top of the base tree, an entry without content nor SHA deletes the file
+func gitHubCreateTree(w http.ResponseWriter, req *http.Request, r *repository) {
+	var in struct {
+		BaseTree string `json:"base_tree"`
+		Tree     []struct {
+			Path    string  `json:"path"`
+			SHA     *string `json:"sha"`
+			Content *string `json:"content"`
+		} `json:"tree"`
+	}
+	if !readJSON(w, req, &in) {
+		return
+	}
+	files := map[string]string{}
+	if in.BaseTree != "" {
+		base, ok := r.trees[in.BaseTree]
+		if !ok {
+			writeMessage(w, http.StatusUnprocessableEntity, "base_tree is not a valid tree oid")
+			return
+		}
+		files = copyFiles(base)
+	}
+	for _, entry := range in.Tree {
+		switch {
+		case entry.Content != nil:
+			files[entry.Path] = *entry.Content
+		case entry.SHA != nil:
+			content, ok := r.fileSHAs[*entry.SHA]
+			if !ok {
+				writeMessage(w, http.StatusUnprocessableEntity, "tree.sha is not a valid blob")
+				return
+			}
+			files[entry.Path] = content
+		default:
+			delete(files, entry.Path)
+		}
+	}
+	writeJSON(w, http.StatusCreated, &github.Tree{SHA: github.String(r.addTree(files))})
+}
+
+func gitHubContent(path, content string) *github.RepositoryContent {
+	name := path[strings.LastIndex(path, "/")+1:]
+	return &github.RepositoryContent{
+		Type:     github.String("file"),
+		Encoding: github.String("base64"),
+		Name:     github.String(name),
+		Path:     github.String(path),
+		SHA:      github.String(blobSHA(content)),
+		Size:     github.Int(len(content)),
+		Content:  github.String(base64Encode(content)),
+	}
+}
+
+func gitHubGetContents(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.URL.Query().Get("ref"))
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "No commit found for the ref")
+		return
+	}
+	path := req.PathValue("path")
+	content, ok := c.files[path]
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitHubContent(path, content))
+}
+
+// gitHubPutContents creates the file, or updates it when the SHA of its current content is given
+func gitHubPutContents(w http.ResponseWriter, req *http.Request, r *repository) {
+	var in github.RepositoryContentFileOptions
+	if !readJSON(w, req, &in) {
+		return
+	}
+	branch := in.GetBranch()
+	if branch == "" {
+		branch = r.defaultBranch
+	}
+	head := r.resolve(branch)
+	if head == nil || r.branches[branch] == "" {
+		writeMessage(w, http.StatusNotFound, "Branch "+branch+" not found")
+		return
+	}
+	path := req.PathValue("path")
+	current, exists := head.files[path]
+	switch {
+	case exists && in.SHA == nil:
+		writeMessage(w, http.StatusUnprocessableEntity, "Invalid request.\n\n\"sha\" wasn't supplied.")
+		return
+	case exists && in.GetSHA() != blobSHA(current):
+		writeMessage(w, http.StatusConflict, fmt.Sprintf("%s does not match %s", path, in.GetSHA()))
+		return
+	case !exists && in.SHA != nil:
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	c, err := r.commitFiles(branch, in.GetMessage(), map[string]string{path: string(in.Content)}, nil)
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	statusCode := http.StatusOK
+	if !exists {
+		statusCode = http.StatusCreated
+	}
+	writeJSON(w, statusCode, &github.RepositoryContentResponse{
+		Content: gitHubContent(path, string(in.Content)),
+		Commit:  *gitHubCommit(c),
+	})
+}
+
+func gitHubDeleteContents(w http.ResponseWriter, req *http.Request, r *repository) {
+	var in github.RepositoryContentFileOptions
+	if !readJSON(w, req, &in) {
+		return
+	}
+	branch := in.GetBranch()
+	if branch == "" {
+		branch = r.defaultBranch
+	}
+	head := r.resolve(branch)
+	if head == nil || r.branches[branch] == "" {
+		writeMessage(w, http.StatusNotFound, "Branch "+branch+" not found")
+		return
+	}
+	path := req.PathValue("path")
+	current, exists := head.files[path]
+	if !exists {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if in.GetSHA() != blobSHA(current) {
+		writeMessage(w, http.StatusConflict, fmt.Sprintf("%s does not match %s", path, in.GetSHA()))
+		return
+	}
+	c, err := r.commitFiles(branch, in.GetMessage(), nil, []string{path})
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.RepositoryContentResponse{Commit: *gitHubCommit(c)})
+}
+
+func gitHubPullRequest(r *repository, pr *pullRequest) *github.PullRequest {
+	state := pr.state
+	if state == pullRequestMerged {
+		state = pullRequestClosed
+	}
+	result := &github.PullRequest{
+		ID:     github.Int64(pr.id),
+		Number: github.Int(pr.number),
+		State:  github.String(state),
+		Title:  github.String(pr.title),
+		Body:   github.String(pr.body),
+		Merged: github.Bool(pr.state == pullRequestMerged),
+		Head:   &github.PullRequestBranch{Ref: github.String(pr.head), SHA: github.String(pr.headSHA), Repo: gitHubRepository(r)},
+		Base:   &github.PullRequestBranch{Ref: github.String(pr.base), SHA: github.String(r.branches[pr.base]), Repo: gitHubRepository(r)},
+	}
+	if pr.mergeSHA != "" {
+		result.MergeCommitSHA = github.String(pr.mergeSHA)
+	}
+	return result
+}
+
+func gitHubListPullRequests(w http.ResponseWriter, req *http.Request, r *repository) {
+	state := req.URL.Query().Get("state")
+	if state == "" {
+		state = "open"
+	}
+	prs := []*github.PullRequest{}
+	for _, pr := range r.pulls {
+		if state == "all" || gitHubPullRequest(r, pr).GetState() == state {
+			prs = append(prs, gitHubPullRequest(r, pr))
+		}
+	}
+	writeJSON(w, http.StatusOK, prs)
+}
+
+func (f *FakeForge) gitHubCreatePullRequest(w http.ResponseWriter, req *http.Request, r *repository) {
+	var in github.NewPullRequest
+	if !readJSON(w, req, &in) {
+		return
+	}
+	// cross-repository pull requests are given the head as "owner:branch"
+	head := in.GetHead()
+	if i := strings.Index(head, ":"); i >= 0 {
+		head = head[i+1:]
+	}
+	pr, err := r.createPullRequest(f.newID(), in.GetTitle(), in.GetBody(), head, in.GetBase())
+	if err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, "Validation Failed: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitHubPullRequest(r, pr))
+}
+
+func gitHubMergePullRequest(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+	if err := r.mergePullRequest(pr); err != nil {
+		writeMessage(w, http.StatusMethodNotAllowed, "Pull Request is not mergeable")
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.PullRequestMergeResult{
+		SHA:     github.String(pr.mergeSHA),
+		Merged:  github.Bool(true),
+		Message: github.String("Pull Request successfully merged"),
+	})
+}
+
+func gitHubUpdatePullRequestBranch(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+	if err := r.updatePullRequestBranch(pr); err != nil {
+		writeMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, &github.PullRequestBranchUpdateResponse{
+		Message: github.String("Updating pull request branch."),
+	})
+}
+
+func gitHubListComments(w http.ResponseWriter, _ *http.Request, _ *repository, pr *pullRequest) {
+	comments := []*github.IssueComment{}
+	for _, c := range pr.comments {
+		created := c.created
+		comments = append(comments, &github.IssueComment{
+			ID:        github.Int64(c.id),
+			Body:      github.String(c.body),
+			User:      &github.User{Login: github.String(c.author)},
+			CreatedAt: &created,
+		})
+	}
+	writeJSON(w, http.StatusOK, comments)
+}
+
+// gitHubListCheckRuns serves the statuses of the commit as check runs, which is how Pipelines as Code reports on GitHub
+func gitHubListCheckRuns(w http.ResponseWriter, req *http.Request, r *repository) {
+	result := &github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{}}
+	if c := r.resolve(req.PathValue("ref")); c != nil {
+		for _, s := range r.statuses[c.sha] {
+			status, conclusion := gitHubCheckRunState(s.state)
+			cr := &github.CheckRun{
+				ID:         github.Int64(s.id),
+				Name:       github.String(s.name),
+				HeadSHA:    github.String(c.sha),
+				Status:     github.String(status),
+				DetailsURL: github.String(s.targetURL),
+				Output:     &github.CheckRunOutput{Title: github.String(s.description)},
+			}
+			if conclusion != "" {
+				cr.Conclusion = github.String(conclusion)
+			}
+			result.CheckRuns = append(result.CheckRuns, cr)
+		}
+	}
+	sort.Slice(result.CheckRuns, func(i, j int) bool { return result.CheckRuns[i].GetID() < result.CheckRuns[j].GetID() })
+	result.Total = github.Int(len(result.CheckRuns))
+	writeJSON(w, http.StatusOK, result)
+}
+
+// gitHubCombinedStatus serves no commit statuses as the statuses of the commit are served as check runs
+func gitHubCombinedStatus(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.PathValue("ref"))
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "No commit found for SHA: "+req.PathValue("ref"))
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.CombinedStatus{
+		State:      github.String("pending"),
+		SHA:        github.String(c.sha),
+		TotalCount: github.Int(0),
+		Statuses:   []*github.RepoStatus{},
+	})
+}
+
+// gitHubCheckRunState translates the git.CommitStatus* state to the status and conclusion of a check run
+func gitHubCheckRunState(state string) (string, string) {
+	switch state {
+	case git.CommitStatusPending:
+		return "queued", ""
+	case git.CommitStatusRunning:
+		return "in_progress", ""
+	case git.CommitStatusCanceled:
+		return "completed", "cancelled"
+	default:
+		return "completed", state
+	}
+}
+
+func gitHubHook(h *hook) *github.Hook {
+	return &github.Hook{
+		ID:     github.Int64(h.id),
+		Events: h.events,
+		Active: github.Bool(true),
+		Config: map[string]interface{}{"url": h.url, "content_type": "json"},
+	}
+}
+
+func gitHubListHooks(w http.ResponseWriter, _ *http.Request, r *repository) {
+	hooks := []*github.Hook{}
+	for _, h := range r.hooks {
+		hooks = append(hooks, gitHubHook(h))
+	}
+	writeJSON(w, http.StatusOK, hooks)
+}
+
+func (f *FakeForge) gitHubCreateHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	var in github.Hook
+	if !readJSON(w, req, &in) {
+		return
+	}
+	url, _ := in.Config["url"].(string)
+	if url == "" {
+		writeMessage(w, http.StatusUnprocessableEntity, "Validation Failed: url is missing")
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitHubHook(r.addHook(f.newID(), url, in.Events)))
+}
+
+func gitHubDeleteHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	id, ok := pathInt(w, req, "id")
+	if !ok {
+		return
+	}
+	if !r.deleteHook(func(h *hook) bool { return h.id == id }) {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,540 @@
+package gittest
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
+	"github.com/xanzy/go-gitlab"
+)
+
+// registerGitLab registers the emulated GitLab REST API (v4) endpoints under the prefix
+func (f *FakeForge) registerGitLab(mux *http.ServeMux, prefix string) {
+	project := prefix + "/projects/{id}"
+
+	f.handle(mux, "GET "+project, f.gitLabProject(func(w http.ResponseWriter, _ *http.Request, r *repository) {
+		writeJSON(w, http.StatusOK, gitLabProject(r))
+	}))
+	f.handle(mux, "DELETE "+project, f.gitLabProject(func(w http.ResponseWriter, _ *http.Request, r *repository) {
+		f.deleteRepository(r)
+		writeMessage(w, http.StatusAccepted, "202 Accepted")
+	}))
+	f.handle(mux, "POST "+project+"/fork", f.gitLabProject(f.gitLabFork))
+
+	f.handle(mux, "POST "+project+"/repository/branches", f.gitLabProject(gitLabCreateBranch))
+	f.handle(mux, "GET "+project+"/repository/branches/{branch}", f.gitLabProject(gitLabGetBranch))
+	f.handle(mux, "DELETE "+project+"/repository/branches/{branch}", f.gitLabProject(gitLabDeleteBranch))
+	f.handle(mux, "GET "+project+"/repository/commits/{sha}", f.gitLabProject(gitLabGetCommit))
+	f.handle(mux, "POST "+project+"/repository/commits", f.gitLabProject(gitLabCreateCommit))
+	f.handle(mux, "GET "+project+"/repository/commits/{sha}/statuses", f.gitLabProject(gitLabListCommitStatuses))
+
+	f.handle(mux, "GET "+project+"/repository/files/{path}", f.gitLabProject(gitLabGetFile))
+	f.handle(mux, "HEAD "+project+"/repository/files/{path}", f.gitLabProject(gitLabGetFileMetaData))
+	f.handle(mux, "POST "+project+"/repository/files/{path}", f.gitLabProject(gitLabCreateFile))
+	f.handle(mux, "PUT "+project+"/repository/files/{path}", f.gitLabProject(gitLabUpdateFile))
+	f.handle(mux, "DELETE "+project+"/repository/files/{path}", f.gitLabProject(gitLabDeleteFile))
+
+	f.handle(mux, "GET "+project+"/merge_requests", f.gitLabProject(gitLabListMergeRequests))
+	f.handle(mux, "POST "+project+"/merge_requests", f.gitLabProject(f.gitLabCreateMergeRequest))
+	f.handle(mux, "GET "+project+"/merge_requests/{iid}", f.gitLabMergeRequest(func(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+		writeJSON(w, http.StatusOK, gitLabMergeRequest(r, pr))
+	}))
+	f.handle(mux, "PUT "+project+"/merge_requests/{iid}", f.gitLabMergeRequest(gitLabUpdateMergeRequest))
+	f.handle(mux, "PUT "+project+"/merge_requests/{iid}/merge", f.gitLabMergeRequest(gitLabAcceptMergeRequest))
+	f.handle(mux, "PUT "+project+"/merge_requests/{iid}/rebase", f.gitLabMergeRequest(gitLabRebaseMergeRequest))
+	f.handle(mux, "GET "+project+"/merge_requests/{iid}/notes", f.gitLabMergeRequest(gitLabListNotes))
+
+	f.handle(mux, "GET "+project+"/hooks", f.gitLabProject(gitLabListHooks))
+	f.handle(mux, "POST "+project+"/hooks", f.gitLabProject(f.gitLabAddHook))
+	f.handle(mux, "DELETE "+project+"/hooks/{hook}", f.gitLabProject(gitLabDeleteHook))
+}
+
+// gitLabProject resolves the project of the request by its numeric ID or its path, responding with
+// 404 Not Found if it does not exist
+func (f *FakeForge) gitLabProject(handler func(http.ResponseWriter, *http.Request, *repository)) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		id := req.PathValue("id")
+		r := f.repos[id]
+		if numericID, err := strconv.ParseInt(id, 10, 64); err == nil {
+			r = f.repositoryByID(numericID)
+		}
+		if r == nil {
+			writeMessage(w, http.StatusNotFound, "404 Project Not Found")
+			return
+		}
+		handler(w, req, r)
+	}
+}
+
+// gitLabMergeRequest resolves the merge request of the request by its IID, responding with 404 Not Found
+// if it does not exist
+func (f *FakeForge) gitLabMergeRequest(handler func(http.ResponseWriter, *http.Request, *repository, *pullRequest)) http.HandlerFunc {
+	return f.gitLabProject(func(w http.ResponseWriter, req *http.Request, r *repository) {
+		iid, err := strconv.Atoi(req.PathValue("iid"))
+		pr := r.pullRequest(iid)
+		if err != nil || pr == nil {
+			writeMessage(w, http.StatusNotFound, "404 Not found")
+			return
+		}
+		handler(w, req, r, pr)
+	})
+}
+
+// gitLabProject serves the project, forks are always reported as imported
+func gitLabProject(r *repository) *gitlab.Project {
+	return &gitlab.Project{
+		ID:                int(r.id),
+		Name:              r.name,
+		Path:              r.name,
+		PathWithNamespace: r.fullName(),
+		DefaultBranch:     r.defaultBranch,
+		ImportStatus:      "finished",
+		Namespace:         &gitlab.ProjectNamespace{Path: r.owner, FullPath: r.owner},
+	}
+}
+
+func (f *FakeForge) gitLabFork(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts gitlab.ForkProjectOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	owner, name := r.owner, r.name
+	if opts.NamespacePath != nil {
+		owner = *opts.NamespacePath
+	}
+	if opts.Path != nil {
+		name = *opts.Path
+	}
+	fork, err := f.fork(r, owner, name)
+	if err != nil {
+		writeJSON(w, http.StatusConflict, map[string]any{"message": map[string][]string{"name": {"has already been taken"}}})
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitLabProject(fork))
+}
+
+func gitLabCommit(c *commit) *gitlab.Commit {
+	created := c.created
+	return &gitlab.Commit{
+		ID:        c.sha,
+		ShortID:   c.sha[:8],
+		Title:     c.message,
+		Message:   c.message,
+		ParentIDs: c.parents,
+		CreatedAt: &created,
+	}
+}
+
+func gitLabBranch(r *repository, branch string) *gitlab.Branch {
+	return &gitlab.Branch{
+		Name:    branch,
+		Default: branch == r.defaultBranch,
+		CanPush: true,
+		Commit:  gitLabCommit(r.commits[r.branches[branch]]),
+	}
+}
+
+func gitLabCreateBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts gitlab.CreateBranchOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if opts.Branch == nil || opts.Ref == nil {
+		writeMessage(w, http.StatusBadRequest, "400 Bad request - branch or ref is missing")
+		return
+	}
+	if _, ok := r.branches[*opts.Branch]; ok {
+		writeMessage(w, http.StatusBadRequest, "Branch already exists")
+		return
+	}
+	c := r.resolve(*opts.Ref)
+	if c == nil {
+		writeMessage(w, http.StatusBadRequest, "Invalid reference name: "+*opts.Ref)
+		return
+	}
+	r.branches[*opts.Branch] = c.sha
+	writeJSON(w, http.StatusCreated, gitLabBranch(r, *opts.Branch))
+}
+
+func gitLabGetBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := req.PathValue("branch")
+	if _, ok := r.branches[branch]; !ok {
+		writeMessage(w, http.StatusNotFound, "404 Branch Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitLabBranch(r, branch))
+}
+
+func gitLabDeleteBranch(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch := req.PathValue("branch")
+	if _, ok := r.branches[branch]; !ok {
+		writeMessage(w, http.StatusNotFound, "404 Branch Not Found")
+		return
+	}
+	delete(r.branches, branch)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func gitLabGetCommit(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.PathValue("sha"))
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "404 Commit Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitLabCommit(c))
+}
+
+// gitLabCreateCommit creates a commit from the create, update and delete actions
+func gitLabCreateCommit(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts gitlab.CreateCommitOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if opts.Branch == nil || opts.CommitMessage == nil {
+		writeMessage(w, http.StatusBadRequest, "400 Bad request - branch or commit_message is missing")
+		return
+	}
+	head := r.resolve(*opts.Branch)
+	if head == nil || r.branches[*opts.Branch] == "" {
+		writeMessage(w, http.StatusBadRequest, "You can only create or edit files when you are on a branch")
+		return
+	}
+
+	files := map[string]string{}
+	var deleted []string
+	for _, action := range opts.Actions {
+		if action.Action == nil || action.FilePath == nil {
+			writeMessage(w, http.StatusBadRequest, "400 Bad request - action or file_path is missing")
+			return
+		}
+		_, exists := head.files[*action.FilePath]
+		switch *action.Action {
+		case gitlab.FileCreate, gitlab.FileUpdate:
+			if exists && *action.Action == gitlab.FileCreate {
+				writeMessage(w, http.StatusBadRequest, "A file with this name already exists")
+				return
+			}
+			if !exists && *action.Action == gitlab.FileUpdate {
+				writeMessage(w, http.StatusBadRequest, "A file with this name doesn't exist")
+				return
+			}
+			content := ""
+			if action.Content != nil {
+				content = *action.Content
+			}
+			files[*action.FilePath] = content
+		case gitlab.FileDelete:
+			deleted = append(deleted, *action.FilePath)
+		default:
+			writeMessage(w, http.StatusBadRequest, fmt.Sprintf("action %s is not supported", *action.Action))
+			return
+		}
+	}
+
+	c, err := r.commitFiles(*opts.Branch, *opts.CommitMessage, files, deleted)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitLabCommit(c))
+}
+
+// gitLabStatus translates the git.CommitStatus* state to the status of a GitLab commit status
+func gitLabStatus(state string) string {
+	switch state {
+	case git.CommitStatusFailure, git.CommitStatusNeutral:
+		return "failed"
+	default:
+		return state
+	}
+}
+
+func gitLabListCommitStatuses(w http.ResponseWriter, req *http.Request, r *repository) {
+	c := r.resolve(req.PathValue("sha"))
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "404 Commit Not Found")
+		return
+	}
+	statuses := []*gitlab.CommitStatus{}
+	for _, s := range r.statuses[c.sha] {
+		statuses = append(statuses, &gitlab.CommitStatus{
+			ID:          int(s.id),
+			SHA:         c.sha,
+			Status:      gitLabStatus(s.state),
+			Name:        s.name,
+			Description: s.description,
+			TargetURL:   s.targetURL,
+		})
+	}
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+// gitLabFile returns the file of the ref given in the query, responding with 404 Not Found if it does not exist
+func gitLabFile(w http.ResponseWriter, req *http.Request, r *repository) (*gitlab.File, bool) {
+	ref := req.URL.Query().Get("ref")
+	c := r.resolve(ref)
+	if c == nil {
+		writeMessage(w, http.StatusNotFound, "404 Commit Not Found")
+		return nil, false
+	}
+	filePath := req.PathValue("path")
+	content, ok := c.files[filePath]
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "404 File Not Found")
+		return nil, false
+	}
+	return &gitlab.File{
+		FileName:     path.Base(filePath),
+		FilePath:     filePath,
+		Size:         len(content),
+		Encoding:     "base64",
+		Content:      base64Encode(content),
+		Ref:          ref,
+		BlobID:       blobSHA(content),
+		CommitID:     c.sha,
+		LastCommitID: c.sha,
+	}, true
+}
+
+func gitLabGetFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	if file, ok := gitLabFile(w, req, r); ok {
+		writeJSON(w, http.StatusOK, file)
+	}
+}
+
+// gitLabGetFileMetaData serves the metadata of the file as the X-Gitlab-* headers
+func gitLabGetFileMetaData(w http.ResponseWriter, req *http.Request, r *repository) {
+	file, ok := gitLabFile(w, req, r)
+	if !ok {
+		return
+	}
+	w.Header().Set("X-Gitlab-Blob-Id", file.BlobID)
+	w.Header().Set("X-Gitlab-Commit-Id", file.CommitID)
+	w.Header().Set("X-Gitlab-Last-Commit-Id", file.LastCommitID)
+	w.Header().Set("X-Gitlab-Encoding", file.Encoding)
+	w.Header().Set("X-Gitlab-File-Name", file.FileName)
+	w.Header().Set("X-Gitlab-File-Path", file.FilePath)
+	w.Header().Set("X-Gitlab-Ref", file.Ref)
+	w.Header().Set("X-Gitlab-Size", strconv.Itoa(file.Size))
+	w.WriteHeader(http.StatusOK)
+}
+
+// gitLabWriteFile commits the content of the file to the branch, the file must not exist yet unless update is set
+func gitLabWriteFile(w http.ResponseWriter, r *repository, filePath, branch, message, content string, update bool) {
+	head := r.resolve(branch)
+	if head == nil || r.branches[branch] == "" {
+		writeMessage(w, http.StatusBadRequest, "You can only create or edit files when you are on a branch")
+		return
+	}
+	if _, exists := head.files[filePath]; exists != update {
+		if update {
+			writeMessage(w, http.StatusBadRequest, "A file with this name doesn't exist")
+		} else {
+			writeMessage(w, http.StatusBadRequest, "A file with this name already exists")
+		}
+		return
+	}
+	if _, err := r.commitFiles(branch, message, map[string]string{filePath: content}, nil); err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	statusCode := http.StatusCreated
+	if update {
+		statusCode = http.StatusOK
+	}
+	writeJSON(w, statusCode, &gitlab.FileInfo{FilePath: filePath, Branch: branch})
+}
+
+func gitLabCreateFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts gitlab.CreateFileOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	gitLabWriteFile(w, r, req.PathValue("path"), stringValue(opts.Branch), stringValue(opts.CommitMessage), stringValue(opts.Content), false)
+}
+
+func gitLabUpdateFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts gitlab.UpdateFileOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	gitLabWriteFile(w, r, req.PathValue("path"), stringValue(opts.Branch), stringValue(opts.CommitMessage), stringValue(opts.Content), true)
+}
+
+// gitLabDeleteFile deletes the file, the client sends the options of a DELETE request as query parameters
+func gitLabDeleteFile(w http.ResponseWriter, req *http.Request, r *repository) {
+	branch, message := req.URL.Query().Get("branch"), req.URL.Query().Get("commit_message")
+	if _, err := r.commitFiles(branch, message, nil, []string{req.PathValue("path")}); err != nil {
+		writeMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func gitLabMergeRequest(r *repository, pr *pullRequest) *gitlab.MergeRequest {
+	state := pr.state
+	if state == pullRequestOpen {
+		state = "opened"
+	}
+	return &gitlab.MergeRequest{
+		ID:             int(pr.id),
+		IID:            pr.number,
+		ProjectID:      int(r.id),
+		Title:          pr.title,
+		Description:    pr.body,
+		State:          state,
+		SourceBranch:   pr.head,
+		TargetBranch:   pr.base,
+		SHA:            pr.headSHA,
+		MergeCommitSHA: pr.mergeSHA,
+	}
+}
+
+func gitLabListMergeRequests(w http.ResponseWriter, req *http.Request, r *repository) {
+	state := req.URL.Query().Get("state")
+	mrs := []*gitlab.MergeRequest{}
+	for _, pr := range r.pulls {
+		mr := gitLabMergeRequest(r, pr)
+		if state == "" || state == "all" || mr.State == state {
+			mrs = append(mrs, mr)
+		}
+	}
+	writeJSON(w, http.StatusOK, mrs)
+}
+
+func (f *FakeForge) gitLabCreateMergeRequest(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts gitlab.CreateMergeRequestOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	pr, err := r.createPullRequest(f.newID(), stringValue(opts.Title), stringValue(opts.Description), stringValue(opts.SourceBranch), stringValue(opts.TargetBranch))
+	if err != nil {
+		writeJSON(w, http.StatusConflict, map[string][]string{"message": {err.Error()}})
+		return
+	}
+	writeJSON(w, http.StatusCreated, gitLabMergeRequest(r, pr))
+}
+
+func gitLabUpdateMergeRequest(w http.ResponseWriter, req *http.Request, r *repository, pr *pullRequest) {
+	var opts gitlab.UpdateMergeRequestOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	switch stringValue(opts.StateEvent) {
+	case "close":
+		if pr.state == pullRequestOpen {
+			pr.state = pullRequestClosed
+		}
+	case "reopen":
+		if pr.state == pullRequestClosed {
+			pr.state = pullRequestOpen
+			pr.headSHA = r.branches[pr.head]
+		}
+	}
+	if opts.Title != nil {
+		pr.title = *opts.Title
+	}
+	writeJSON(w, http.StatusOK, gitLabMergeRequest(r, pr))
+}
+
+func gitLabAcceptMergeRequest(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+	if err := r.mergePullRequest(pr); err != nil {
+		writeMessage(w, http.StatusMethodNotAllowed, "405 Method Not Allowed")
+		return
+	}
+	writeJSON(w, http.StatusOK, gitLabMergeRequest(r, pr))
+}
+
+// gitLabRebaseMergeRequest brings the source branch up to date with the target branch, merging it
+// rather than rebasing as the repository model keeps no linear history
+func gitLabRebaseMergeRequest(w http.ResponseWriter, _ *http.Request, r *repository, pr *pullRequest) {
+	if err := r.updatePullRequestBranch(pr); err != nil {
+		writeMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]bool{"rebase_in_progress": true})
+}
+
+func gitLabListNotes(w http.ResponseWriter, _ *http.Request, _ *repository, pr *pullRequest) {
+	notes := []*gitlab.Note{}
+	for _, c := range pr.comments {
+		created := c.created
+		note := &gitlab.Note{
+			ID:           int(c.id),
+			Body:         c.body,
+			CreatedAt:    &created,
+			NoteableType: "MergeRequest",
+			NoteableIID:  pr.number,
+		}
+		note.Author.Username = c.author
+		notes = append(notes, note)
+	}
+	writeJSON(w, http.StatusOK, notes)
+}
+
+func gitLabHook(r *repository, h *hook) *gitlab.ProjectHook {
+	hook := &gitlab.ProjectHook{ID: int(h.id), URL: h.url, ProjectID: int(r.id)}
+	for _, e := range h.events {
+		switch e {
+		case "push_events":
+			hook.PushEvents = true
+		case "merge_requests_events":
+			hook.MergeRequestsEvents = true
+		case "note_events":
+			hook.NoteEvents = true
+		}
+	}
+	return hook
+}
+
+func gitLabListHooks(w http.ResponseWriter, _ *http.Request, r *repository) {
+	hooks := []*gitlab.ProjectHook{}
+	for _, h := range r.hooks {
+		hooks = append(hooks, gitLabHook(r, h))
+	}
+	writeJSON(w, http.StatusOK, hooks)
+}
+
+// gitLabAddHook adds the hook, its events are stored as the names of the enabled *_events options
+func (f *FakeForge) gitLabAddHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	var opts gitlab.AddProjectHookOptions
+	if !readJSON(w, req, &opts) {
+		return
+	}
+	if opts.URL == nil {
+		writeMessage(w, http.StatusBadRequest, "400 Bad request - url is missing")
+		return
+	}
+	var events []string
+	for name, enabled := range map[string]*bool{
+		"push_events":           opts.PushEvents,
+		"merge_requests_events": opts.MergeRequestsEvents,
+		"note_events":           opts.NoteEvents,
+	} {
+		if enabled != nil && *enabled {
+			events = append(events, name)
+		}
+	}
+	writeJSON(w, http.StatusCreated, gitLabHook(r, r.addHook(f.newID(), *opts.URL, events)))
+}
+
+func gitLabDeleteHook(w http.ResponseWriter, req *http.Request, r *repository) {
+	id, ok := pathInt(w, req, "hook")
+	if !ok {
+		return
+	}
+	if !r.deleteHook(func(h *hook) bool { return h.id == id }) {
+		writeMessage(w, http.StatusNotFound, "404 Not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
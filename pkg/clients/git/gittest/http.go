@@ -0,0 +1,51 @@
+package gittest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// handle registers the handler for the pattern, the handlers are serialized by the lock of the forge
+func (f *FakeForge) handle(mux *http.ServeMux, pattern string, handler http.HandlerFunc) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		handler(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeMessage responds with the {"message": ...} error body GitHub, GitLab and Forgejo use
+func writeMessage(w http.ResponseWriter, statusCode int, message string) {
+	writeJSON(w, statusCode, map[string]string{"message": message})
+}
+
+// readJSON decodes the request body into v, responding with 400 Bad Request if it is malformed
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeMessage(w, http.StatusBadRequest, "Problems parsing JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// pathInt parses the wildcard of the request path as an integer, responding with 404 Not Found if it is not one
+func pathInt(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	v, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return 0, false
+	}
+	return v, true
+}
+
+func base64Encode(content string) string {
+	return base64.StdEncoding.EncodeToString([]byte(content))
+}
@@ -0,0 +1,118 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+	"k8s.io/klog/v2"
+)
+
+// WaitForCommitStatus waits until the status whose name contains statusName is reported for the commit
+// and finished, then returns it.
+func WaitForCommitStatus(client Client, repository, commitSHA, statusName string, timeout time.Duration) (*CommitStatus, error) {
+	var found *CommitStatus
+	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
+		statuses, err := client.ListCommitStatuses(repository, commitSHA)
+		if err != nil {
+			klog.Warningf("error listing commit statuses of %s in %s: %v", commitSHA, repository, err)
+			return false, nil
+		}
+		for _, s := range statuses {
+			if strings.Contains(s.Name, statusName) {
+				found = s
+				return s.IsFinished(), nil
+			}
+		}
+		return false, nil
+	}, time.Second*5, timeout)
+	if err != nil {
+		if found != nil {
+			return nil, fmt.Errorf("timed out when waiting for the commit status %s of %s in %s to finish, last state: %s", statusName, commitSHA, repository, found.State)
+		}
+		return nil, fmt.Errorf("timed out when waiting for the commit status %s of %s in %s to appear", statusName, commitSHA, repository)
+	}
+	return found, nil
+}
+
+// gitHubCheckRunState maps status and conclusion of a GitHub check run onto CommitStatus states
+func gitHubCheckRunState(status, conclusion string) string {
+	switch status {
+	case "completed":
+	case "in_progress":
+		return CommitStatusRunning
+	default:
+		return CommitStatusPending
+	}
+	switch conclusion {
+	case "success":
+		return CommitStatusSuccess
+	case "neutral":
+		return CommitStatusNeutral
+	case "cancelled", "stale":
+		return CommitStatusCanceled
+	case "skipped":
+		return CommitStatusSkipped
+	default:
+		return CommitStatusFailure
+	}
+}
+
+// gitHubStatusState maps state of a GitHub commit status onto CommitStatus states
+func gitHubStatusState(state string) string {
+	switch state {
+	case "pending":
+		return CommitStatusPending
+	case "success":
+		return CommitStatusSuccess
+	default:
+		return CommitStatusFailure
+	}
+}
+
+// gitLabStatusState maps status of a GitLab commit status onto CommitStatus states
+func gitLabStatusState(status string) string {
+	switch status {
+	case "created", "pending", "waiting_for_resource", "preparing", "scheduled", "manual":
+		return CommitStatusPending
+	case "running":
+		return CommitStatusRunning
+	case "success":
+		return CommitStatusSuccess
+	case "canceled":
+		return CommitStatusCanceled
+	case "skipped":
+		return CommitStatusSkipped
+	default:
+		return CommitStatusFailure
+	}
+}
+
+// forgejoStatusState maps state of a Forgejo commit status onto CommitStatus states
+func forgejoStatusState(state string) string {
+	switch state {
+	case "pending":
+		return CommitStatusPending
+	case "success":
+		return CommitStatusSuccess
+	case "warning":
+		return CommitStatusNeutral
+	default:
+		return CommitStatusFailure
+	}
+}
+
+// bitbucketStatusState maps state of a Bitbucket build status onto CommitStatus states
+func bitbucketStatusState(state string) string {
+	switch state {
+	case "INPROGRESS":
+		return CommitStatusRunning
+	case "SUCCESSFUL":
+		return CommitStatusSuccess
+	case "STOPPED":
+		return CommitStatusCanceled
+	default:
+		return CommitStatusFailure
+	}
+}
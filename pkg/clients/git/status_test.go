@@ -0,0 +1,26 @@
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitStatusStates(t *testing.T) {
+	assert.Equal(t, CommitStatusPending, gitHubCheckRunState("queued", ""))
+	assert.Equal(t, CommitStatusRunning, gitHubCheckRunState("in_progress", ""))
+	assert.Equal(t, CommitStatusSuccess, gitHubCheckRunState("completed", "success"))
+	assert.Equal(t, CommitStatusNeutral, gitHubCheckRunState("completed", "neutral"))
+	assert.Equal(t, CommitStatusCanceled, gitHubCheckRunState("completed", "cancelled"))
+	assert.Equal(t, CommitStatusFailure, gitHubCheckRunState("completed", "timed_out"))
+
+	assert.Equal(t, CommitStatusFailure, gitHubStatusState("error"))
+	assert.Equal(t, CommitStatusPending, gitLabStatusState("created"))
+	assert.Equal(t, CommitStatusFailure, gitLabStatusState("failed"))
+	assert.Equal(t, CommitStatusNeutral, forgejoStatusState("warning"))
+	assert.Equal(t, CommitStatusRunning, bitbucketStatusState("INPROGRESS"))
+	assert.Equal(t, CommitStatusCanceled, bitbucketStatusState("STOPPED"))
+
+	assert.False(t, (&CommitStatus{State: CommitStatusRunning}).IsFinished())
+	assert.True(t, (&CommitStatus{State: CommitStatusSkipped}).IsFinished())
+}
@@ -0,0 +1,126 @@
+package git
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
+)
+
+// trackingClient is a Client recording the branches, pull requests, forks and webhooks it creates,
+// together with the webhooks pointing to the cluster from the repositories it touches.
+type trackingClient struct {
+	Client
+	tracker          *tracker.Tracker
+	clusterAppDomain string
+}
+
+// NewTrackingClient wraps c so the git resources it creates are removed by the teardown of t.
+// The webhooks to clusterAppDomain are removed from every repository a branch is created in,
+// nothing is done with them when clusterAppDomain is empty.
+func NewTrackingClient(c Client, t *tracker.Tracker, clusterAppDomain string) Client {
+	if t == nil {
+		return c
+	}
+	return &trackingClient{Client: c, tracker: t, clusterAppDomain: clusterAppDomain}
+}
+
+func (c *trackingClient) CreateBranch(repository, baseBranchName, revision, branchName string) error {
+	if err := c.Client.CreateBranch(repository, baseBranchName, revision, branchName); err != nil {
+		return err
+	}
+	c.tracker.Track("Branch", repository, branchName, tracker.OrderGitBranch, func(ctx context.Context) error {
+		exists, err := c.Client.BranchExists(repository, branchName)
+		if err != nil || !exists {
+			return err
+		}
+		return c.Client.DeleteBranch(repository, branchName)
+	})
+	c.trackWebhooks(repository)
+	return nil
+}
+
+func (c *trackingClient) DeleteBranch(repository, branchName string) error {
+	if err := c.Client.DeleteBranch(repository, branchName); err != nil {
+		return err
+	}
+	c.tracker.Untrack("Branch", repository, branchName)
+	return nil
+}
+
+func (c *trackingClient) CreatePullRequest(repository, title, body, head, base string) (*PullRequest, error) {
+	pr, err := c.Client.CreatePullRequest(repository, title, body, head, base)
+	if err != nil {
+		return nil, err
+	}
+	c.tracker.Track("PullRequest", repository, strconv.Itoa(pr.Number), tracker.OrderGitPullRequest, func(ctx context.Context) error {
+		return c.Client.DeleteBranchAndClosePullRequest(repository, pr.Number)
+	})
+	return pr, nil
+}
+
+func (c *trackingClient) MergePullRequest(repository string, prNumber int) (*PullRequest, error) {
+	pr, err := c.Client.MergePullRequest(repository, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	c.tracker.Untrack("PullRequest", repository, strconv.Itoa(prNumber))
+	return pr, nil
+}
+
+func (c *trackingClient) DeleteBranchAndClosePullRequest(repository string, prNumber int) error {
+	if err := c.Client.DeleteBranchAndClosePullRequest(repository, prNumber); err != nil {
+		return err
+	}
+	c.tracker.Untrack("PullRequest", repository, strconv.Itoa(prNumber))
+	return nil
+}
+
+func (c *trackingClient) ForkRepository(sourceRepoName, targetRepoName string) error {
+	if err := c.Client.ForkRepository(sourceRepoName, targetRepoName); err != nil {
+		return err
+	}
+	c.tracker.Track("Repository", "", targetRepoName, tracker.OrderGitRepository, func(ctx context.Context) error {
+		return c.Client.DeleteRepositoryIfExists(targetRepoName)
+	})
+	return nil
+}
+
+func (c *trackingClient) DeleteRepositoryIfExists(repoName string) error {
+	if err := c.Client.DeleteRepositoryIfExists(repoName); err != nil {
+		return err
+	}
+	c.tracker.Untrack("Repository", "", repoName)
+	return nil
+}
+
+func (c *trackingClient) CreateWebhook(repository, url string) (*Webhook, error) {
+	hook, err := c.Client.CreateWebhook(repository, url)
+	if err != nil {
+		return nil, err
+	}
+	c.tracker.Track("Webhook", repository, url, tracker.OrderGitWebhooks, func(ctx context.Context) error {
+		return c.Client.CleanupWebhooks(repository, url)
+	})
+	return hook, nil
+}
+
+func (c *trackingClient) CleanupWebhooks(repository, clusterAppDomain string) error {
+	if err := c.Client.CleanupWebhooks(repository, clusterAppDomain); err != nil {
+		return err
+	}
+	if clusterAppDomain == c.clusterAppDomain {
+		c.tracker.Untrack("Webhooks", repository, clusterAppDomain)
+	}
+	c.tracker.Untrack("Webhook", repository, clusterAppDomain)
+	return nil
+}
+
+func (c *trackingClient) trackWebhooks(repository string) {
+	if c.clusterAppDomain == "" {
+		return
+	}
+	c.tracker.Track("Webhooks", repository, c.clusterAppDomain, tracker.OrderGitWebhooks, func(ctx context.Context) error {
+		return c.Client.CleanupWebhooks(repository, c.clusterAppDomain)
+	})
+}
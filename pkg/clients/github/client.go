@@ -2,6 +2,8 @@ package github
 
 import (
 	"context"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gofri/go-github-ratelimit/github_ratelimit"
@@ -17,9 +19,16 @@ const (
 type Github struct {
 	client       *github.Client
 	organization string
+	ctx          context.Context
 }
 
 func NewGithubClient(token, organization string) (*Github, error) {
+	return NewGithubClientWithBaseURL(token, organization, "")
+}
+
+// NewGithubClientWithBaseURL creates a client of the GitHub API served at baseURL (e.g. a GitHub Enterprise
+// Server or a fake forge), the public GitHub API is used when baseURL is empty.
+func NewGithubClientWithBaseURL(token, organization, baseURL string) (*Github, error) {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	tc := oauth2.NewClient(context.Background(), ts)
 	// https://docs.github.com/en/rest/guides/best-practices-for-integrators?apiVersion=2022-11-28#dealing-with-secondary-rate-limits
@@ -32,6 +41,14 @@ func NewGithubClient(token, organization string) (*Github, error) {
 	rateLimiter.Transport = utils.NewRetryTransport(rateLimiter.Transport)
 
 	client := github.NewClient(rateLimiter)
+	if baseURL != "" {
+		if !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		if client.BaseURL, err = url.Parse(baseURL); err != nil {
+			return &Github{}, err
+		}
+	}
 	githubClient := &Github{
 		client:       client,
 		organization: organization,
@@ -39,3 +56,18 @@ func NewGithubClient(token, organization string) (*Github, error) {
 
 	return githubClient, nil
 }
+
+// Context returns the context the GitHub API calls are bound to.
+func (g *Github) Context() context.Context {
+	if g.ctx == nil {
+		return context.Background()
+	}
+	return g.ctx
+}
+
+// WithContext returns a shallow copy of the client whose API calls are cancelled once ctx is done.
+func (g *Github) WithContext(ctx context.Context) *Github {
+	g2 := *g
+	g2.ctx = ctx
+	return &g2
+}
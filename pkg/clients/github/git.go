@@ -1,7 +1,6 @@
 package github
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -11,7 +10,7 @@ import (
 )
 
 func (g *Github) DeleteRef(repository, branchName string) error {
-	_, err := g.client.Git.DeleteRef(context.Background(), g.organization, repository, fmt.Sprintf(HEADS, branchName))
+	_, err := g.client.Git.DeleteRef(g.Context(), g.organization, repository, fmt.Sprintf(HEADS, branchName))
 	if err != nil {
 		return err
 	}
@@ -22,7 +21,7 @@ func (g *Github) DeleteRef(repository, branchName string) error {
 // that will be based on the commit specified with sha. If sha is not specified
 // the latest commit from base branch will be used.
 func (g *Github) CreateRef(repository, baseBranchName, sha, newBranchName string) error {
-	ctx := context.Background()
+	ctx := g.Context()
 	ref, _, err := g.client.Git.GetRef(ctx, g.organization, repository, fmt.Sprintf(HEADS, baseBranchName))
 	if err != nil {
 		return fmt.Errorf("error when getting the base branch name '%s' for the repo '%s': %+v", baseBranchName, repository, err)
@@ -38,7 +37,7 @@ func (g *Github) CreateRef(repository, baseBranchName, sha, newBranchName string
 	if err != nil {
 		return fmt.Errorf("error when creating a new branch '%s' for the repo '%s': %+v", newBranchName, repository, err)
 	}
-	err = utils.WaitUntilWithInterval(func() (done bool, err error) {
+	err = utils.WaitUntilWithIntervalContext(g.Context(), func() (done bool, err error) {
 		exist, err := g.ExistsRef(repository, newBranchName)
 		if err != nil {
 			return false, err
@@ -55,7 +54,7 @@ func (g *Github) CreateRef(repository, baseBranchName, sha, newBranchName string
 }
 
 func (g *Github) ExistsRef(repository, branchName string) (bool, error) {
-	_, _, err := g.client.Git.GetRef(context.Background(), g.organization, repository, fmt.Sprintf(HEADS, branchName))
+	_, _, err := g.client.Git.GetRef(g.Context(), g.organization, repository, fmt.Sprintf(HEADS, branchName))
 	if err != nil {
 		if strings.Contains(err.Error(), "404 Not Found") {
 			return false, nil
@@ -83,3 +82,46 @@ func (g *Github) EnsureBranchExists(repository, branchName, fallbackBranch strin
 	// Branch doesn't exist, create it from fallback branch
 	return g.CreateRef(repository, fallbackBranch, "", branchName)
 }
+
+// CreateCommit creates a single commit on top of the branch which sets the content of the given files
+// (path -> content), creating those which do not exist yet. The branch is moved to the new commit.
+func (g *Github) CreateCommit(repository, branchName, message string, files map[string]string) (*github.Commit, error) {
+	ctx := g.Context()
+	ref, _, err := g.client.Git.GetRef(ctx, g.organization, repository, fmt.Sprintf(HEADS, branchName))
+	if err != nil {
+		return nil, fmt.Errorf("error when getting the branch '%s' for the repo '%s': %+v", branchName, repository, err)
+	}
+	parent, _, err := g.client.Git.GetCommit(ctx, g.organization, repository, ref.Object.GetSHA())
+	if err != nil {
+		return nil, fmt.Errorf("error when getting the commit '%s' for the repo '%s': %+v", ref.Object.GetSHA(), repository, err)
+	}
+
+	entries := make([]*github.TreeEntry, 0, len(files))
+	for path, content := range files {
+		entries = append(entries, &github.TreeEntry{
+			Path:    github.String(path),
+			Mode:    github.String("100644"),
+			Type:    github.String("blob"),
+			Content: github.String(content),
+		})
+	}
+	tree, _, err := g.client.Git.CreateTree(ctx, g.organization, repository, parent.GetTree().GetSHA(), entries)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating a tree for the repo '%s': %+v", repository, err)
+	}
+
+	commit, _, err := g.client.Git.CreateCommit(ctx, g.organization, repository, &github.Commit{
+		Message: github.String(message),
+		Tree:    tree,
+		Parents: []*github.Commit{{SHA: parent.SHA}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error when creating a commit for the repo '%s': %+v", repository, err)
+	}
+
+	ref.Object.SHA = commit.SHA
+	if _, _, err = g.client.Git.UpdateRef(ctx, g.organization, repository, ref, false); err != nil {
+		return nil, fmt.Errorf("error when updating the branch '%s' for the repo '%s': %+v", branchName, repository, err)
+	}
+	return commit, nil
+}
@@ -1,7 +1,6 @@
 package github
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -13,7 +12,7 @@ import (
 )
 
 func (g *Github) GetPullRequest(repository string, id int) (*github.PullRequest, error) {
-	pr, _, err := g.client.PullRequests.Get(context.Background(), g.organization, repository, id)
+	pr, _, err := g.client.PullRequests.Get(g.Context(), g.organization, repository, id)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +26,7 @@ func (g *Github) CreatePullRequest(repository, title, body, head, base string) (
 		Head:  &head,
 		Base:  &base,
 	}
-	pr, _, err := g.client.PullRequests.Create(context.Background(), g.organization, repository, newPR)
+	pr, _, err := g.client.PullRequests.Create(g.Context(), g.organization, repository, newPR)
 	if err != nil {
 		return nil, err
 	}
@@ -35,7 +34,7 @@ func (g *Github) CreatePullRequest(repository, title, body, head, base string) (
 }
 
 func (g *Github) ListPullRequests(repository string) ([]*github.PullRequest, error) {
-	prs, _, err := g.client.PullRequests.List(context.Background(), g.organization, repository, &github.PullRequestListOptions{})
+	prs, _, err := g.client.PullRequests.List(g.Context(), g.organization, repository, &github.PullRequestListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error when listing pull requests for the repo %s: %v", repository, err)
 	}
@@ -44,7 +43,7 @@ func (g *Github) ListPullRequests(repository string) ([]*github.PullRequest, err
 }
 
 func (g *Github) ListPullRequestCommentsSince(repository string, prNumber int, since time.Time) ([]*github.IssueComment, error) {
-	comments, _, err := g.client.Issues.ListComments(context.Background(), g.organization, repository, prNumber, &github.IssueListCommentsOptions{
+	comments, _, err := g.client.Issues.ListComments(g.Context(), g.organization, repository, prNumber, &github.IssueListCommentsOptions{
 		Since:     &since,
 		Sort:      github.String("created"),
 		Direction: github.String("asc"),
@@ -57,7 +56,7 @@ func (g *Github) ListPullRequestCommentsSince(repository string, prNumber int, s
 }
 
 func (g *Github) MergePullRequest(repository string, prNumber int) (*github.PullRequestMergeResult, error) {
-	mergeResult, _, err := g.client.PullRequests.Merge(context.Background(), g.organization, repository, prNumber, "", &github.PullRequestOptions{})
+	mergeResult, _, err := g.client.PullRequests.Merge(g.Context(), g.organization, repository, prNumber, "", &github.PullRequestOptions{})
 	if err != nil {
 		mergeErr := fmt.Errorf("error when merging pull request number %d for the repo %s: %v", prNumber, repository, err)
 		// If the head branch is out of date (409), trigger a branch update so the next retry can succeed
@@ -76,7 +75,7 @@ func (g *Github) MergePullRequest(repository string, prNumber int) (*github.Pull
 // UpdatePullRequestBranch updates the PR branch with the latest changes from the base branch.
 // This is useful when the PR branch is out of date and GitHub returns 409 on merge.
 func (g *Github) UpdatePullRequestBranch(repository string, prNumber int) error {
-	_, _, err := g.client.PullRequests.UpdateBranch(context.Background(), g.organization, repository, prNumber, nil)
+	_, _, err := g.client.PullRequests.UpdateBranch(g.Context(), g.organization, repository, prNumber, nil)
 	if err != nil {
 		// UpdateBranch returns AcceptedError (HTTP 202) when the update is queued -- that's fine
 		if _, ok := err.(*github.AcceptedError); ok {
@@ -88,15 +87,24 @@ func (g *Github) UpdatePullRequestBranch(repository string, prNumber int) error
 }
 
 func (g *Github) ListCheckRuns(repository string, ref string) ([]*github.CheckRun, error) {
-	checkRunResults, _, err := g.client.Checks.ListCheckRunsForRef(context.Background(), g.organization, repository, ref, &github.ListCheckRunsOptions{})
+	checkRunResults, _, err := g.client.Checks.ListCheckRunsForRef(g.Context(), g.organization, repository, ref, &github.ListCheckRunsOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error when listing check runs for the repo %s and ref %s: %v", repository, ref, err)
 	}
 	return checkRunResults.CheckRuns, nil
 }
 
+// ListCommitStatuses lists the latest commit statuses of the ref, one per status context.
+func (g *Github) ListCommitStatuses(repository string, ref string) ([]*github.RepoStatus, error) {
+	combined, _, err := g.client.Repositories.GetCombinedStatus(g.Context(), g.organization, repository, ref, &github.ListOptions{PerPage: 100})
+	if err != nil {
+		return nil, fmt.Errorf("error when listing commit statuses for the repo %s and ref %s: %v", repository, ref, err)
+	}
+	return combined.Statuses, nil
+}
+
 func (g *Github) GetCheckRun(repository string, id int64) (*github.CheckRun, error) {
-	checkRun, _, err := g.client.Checks.GetCheckRun(context.Background(), g.organization, repository, id)
+	checkRun, _, err := g.client.Checks.GetCheckRun(g.Context(), g.organization, repository, id)
 	if err != nil {
 		return nil, fmt.Errorf("error when getting check run with id %d for the repo %s: %v", id, repository, err)
 	}
@@ -123,7 +131,7 @@ func (g *Github) GetCheckRunConclusion(checkRunName, repoName, prHeadSha string,
 
 	timeout = time.Minute * 5
 
-	err = utils.WaitUntil(func() (done bool, err error) {
+	err = utils.WaitUntilContext(g.Context(), func() (done bool, err error) {
 		checkRuns, err := g.ListCheckRuns(repoName, prHeadSha)
 		if err != nil {
 			ginkgo.GinkgoWriter.Printf("got error when listing CheckRuns: %+v\n", err)
@@ -140,7 +148,7 @@ func (g *Github) GetCheckRunConclusion(checkRunName, repoName, prHeadSha string,
 	if err != nil {
 		return "", fmt.Errorf("timed out when waiting for the PaC CheckRun to appear for %s", errMsgSuffix)
 	}
-	err = utils.WaitUntil(func() (done bool, err error) {
+	err = utils.WaitUntilContext(g.Context(), func() (done bool, err error) {
 		checkRun, err = g.GetCheckRun(repoName, checkRun.GetID())
 		if err != nil {
 			ginkgo.GinkgoWriter.Printf("got error when listing CheckRuns: %+v\n", errMsgSuffix, err)
@@ -171,7 +179,7 @@ func (g *Github) GetCheckRunStatus(checkRunName, repoName, prHeadSha string, prN
 
 	timeout = time.Minute * 5
 
-	err = utils.WaitUntil(func() (done bool, err error) {
+	err = utils.WaitUntilContext(g.Context(), func() (done bool, err error) {
 		checkRuns, err := g.ListCheckRuns(repoName, prHeadSha)
 		if err != nil {
 			ginkgo.GinkgoWriter.Printf("got error when listing CheckRuns: %+v\n", err)
@@ -204,7 +212,7 @@ func (g *Github) GetCheckRunText(checkRunName, repoName, prHeadSha string, prNum
 
 	timeout = time.Minute * 5
 
-	err = utils.WaitUntil(func() (done bool, err error) {
+	err = utils.WaitUntilContext(g.Context(), func() (done bool, err error) {
 		checkRuns, err := g.ListCheckRuns(repoName, prHeadSha)
 		if err != nil {
 			ginkgo.GinkgoWriter.Printf("got error when listing CheckRuns: %+v\n", err)
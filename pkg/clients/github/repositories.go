@@ -1,20 +1,19 @@
 package github
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
 
-	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	"github.com/google/go-github/v44/github"
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	"github.com/onsi/ginkgo/v2"
 )
 
 func (g *Github) CheckIfReleaseExist(owner, repositoryName, releaseURL string) bool {
 	urlParts := strings.Split(releaseURL, "/")
 	tagName := urlParts[len(urlParts)-1]
-	_, _, err := g.client.Repositories.GetReleaseByTag(context.Background(), owner, repositoryName, tagName)
+	_, _, err := g.client.Repositories.GetReleaseByTag(g.Context(), owner, repositoryName, tagName)
 	if err != nil {
 		ginkgo.GinkgoWriter.Printf("GetReleaseByTag %s returned error in repo %s : %v\n", tagName, repositoryName, err)
 		return false
@@ -26,13 +25,13 @@ func (g *Github) CheckIfReleaseExist(owner, repositoryName, releaseURL string) b
 func (g *Github) DeleteRelease(owner, repositoryName, releaseURL string) bool {
 	urlParts := strings.Split(releaseURL, "/")
 	tagName := urlParts[len(urlParts)-1]
-	release, _, err := g.client.Repositories.GetReleaseByTag(context.Background(), owner, repositoryName, tagName)
+	release, _, err := g.client.Repositories.GetReleaseByTag(g.Context(), owner, repositoryName, tagName)
 	if err != nil {
 		ginkgo.GinkgoWriter.Printf("GetReleaseByTag returned error in repo %s : %v\n", repositoryName, err)
 		return false
 	}
 
-	_, err = g.client.Repositories.DeleteRelease(context.Background(), owner, repositoryName, *release.ID)
+	_, err = g.client.Repositories.DeleteRelease(g.Context(), owner, repositoryName, *release.ID)
 	if err != nil {
 		ginkgo.GinkgoWriter.Printf("DeleteRelease returned error: %v", err)
 	}
@@ -41,7 +40,7 @@ func (g *Github) DeleteRelease(owner, repositoryName, releaseURL string) bool {
 }
 
 func (g *Github) CheckIfRepositoryExist(repository string) bool {
-	_, resp, err := g.client.Repositories.Get(context.Background(), g.organization, repository)
+	_, resp, err := g.client.Repositories.Get(g.Context(), g.organization, repository)
 	if err != nil {
 		ginkgo.GinkgoWriter.Printf("error when sending request to Github API: %v\n", err)
 		return false
@@ -57,7 +56,7 @@ func (g *Github) CreateFile(repository, pathToFile, fileContent, branchName stri
 		Branch:  github.String(branchName),
 	}
 
-	file, _, err := g.client.Repositories.CreateFile(context.Background(), g.organization, repository, pathToFile, opts)
+	file, _, err := g.client.Repositories.CreateFile(g.Context(), g.organization, repository, pathToFile, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error when creating file contents: %v", err)
 	}
@@ -74,7 +73,7 @@ func (g *Github) GetFileWithOrg(org, repository, pathToFile, branchName string)
 	if branchName != "" {
 		opts.Ref = fmt.Sprintf(HEADS, branchName)
 	}
-	file, _, _, err := g.client.Repositories.GetContents(context.Background(), org, repository, pathToFile, opts)
+	file, _, _, err := g.client.Repositories.GetContents(g.Context(), org, repository, pathToFile, opts)
 	if err != nil {
 		return nil, fmt.Errorf("error when listing file contents: %v", err)
 	}
@@ -93,7 +92,7 @@ func (g *Github) UpdateFile(repository, pathToFile, newContent, branchName, file
 		Content: []byte(newContent),
 		Branch:  github.String(branchName),
 	}
-	updatedFile, _, err := g.client.Repositories.UpdateFile(context.Background(), g.organization, repository, pathToFile, newFileContent)
+	updatedFile, _, err := g.client.Repositories.UpdateFile(g.Context(), g.organization, repository, pathToFile, newFileContent)
 	if err != nil {
 		return nil, fmt.Errorf("error when updating a file on github: %v", err)
 	}
@@ -109,17 +108,15 @@ func (g *Github) DeleteFile(repository, pathToFile, branchName string) error {
 		getOpts.Ref = fmt.Sprintf(HEADS, branchName)
 		deleteOpts.Branch = github.String(branchName)
 	}
-	file, _, _, err := g.client.Repositories.GetContents(context.Background(), g.organization, repository, pathToFile, getOpts)
+	file, _, _, err := g.client.Repositories.GetContents(g.Context(), g.organization, repository, pathToFile, getOpts)
 	if err != nil {
 		return fmt.Errorf("error when listing file contents on github: %v", err)
 	}
 
-	deleteOpts = &github.RepositoryContentFileOptions{
-		Message: github.String("delete test files"),
-		SHA:     github.String(file.GetSHA()),
-	}
+	deleteOpts.Message = github.String("delete test files")
+	deleteOpts.SHA = github.String(file.GetSHA())
 
-	_, _, err = g.client.Repositories.DeleteFile(context.Background(), g.organization, repository, pathToFile, deleteOpts)
+	_, _, err = g.client.Repositories.DeleteFile(g.Context(), g.organization, repository, pathToFile, deleteOpts)
 	if err != nil {
 		return fmt.Errorf("error when deleting file on github: %v", err)
 	}
@@ -135,7 +132,7 @@ func (g *Github) GetAllRepositories() ([]*github.Repository, error) {
 	}
 	var allRepos []*github.Repository
 	for {
-		repos, resp, err := g.client.Repositories.ListByOrg(context.Background(), g.organization, opt)
+		repos, resp, err := g.client.Repositories.ListByOrg(g.Context(), g.organization, opt)
 		if err != nil {
 			return nil, err
 		}
@@ -150,7 +147,7 @@ func (g *Github) GetAllRepositories() ([]*github.Repository, error) {
 
 func (g *Github) DeleteRepository(repository *github.Repository) error {
 	ginkgo.GinkgoWriter.Printf("Deleting repository %s\n", *repository.Name)
-	_, err := g.client.Repositories.Delete(context.Background(), g.organization, *repository.Name)
+	_, err := g.client.Repositories.Delete(g.Context(), g.organization, *repository.Name)
 	if err != nil {
 		return err
 	}
@@ -158,7 +155,7 @@ func (g *Github) DeleteRepository(repository *github.Repository) error {
 }
 
 func (g *Github) DeleteRepositoryIfExists(name string) error {
-	ctx := context.Background()
+	ctx := g.Context()
 
 	_, resp, err := g.client.Repositories.Get(ctx, g.organization, name)
 	if err != nil {
@@ -181,13 +178,13 @@ func (g *Github) ForkRepositoryWithOrgs(sourceOrgName, sourceName, targetOrgName
 	var resp *github.Response
 	var repo *github.Repository
 
-	ctx := context.Background()
+	ctx := g.Context()
 
 	forkOptions := &github.RepositoryCreateForkOptions{
 		Organization: targetOrgName,
 	}
 
-	err1 := utils.WaitUntilWithInterval(func() (done bool, err error) {
+	err1 := utils.WaitUntilWithIntervalContext(g.Context(), func() (done bool, err error) {
 		fork, resp, err = g.client.Repositories.CreateFork(ctx, sourceOrgName, sourceName, forkOptions)
 		if err != nil {
 			if _, ok := err.(*github.AcceptedError); ok && resp.StatusCode == 202 {
@@ -208,12 +205,12 @@ func (g *Github) ForkRepositoryWithOrgs(sourceOrgName, sourceName, targetOrgName
 			return false, fmt.Errorf("error forking %s/%s: %v", sourceOrgName, sourceName, err)
 		}
 		return true, nil
-	}, time.Second * 10, time.Minute * 1)
+	}, time.Second*10, time.Minute*1)
 	if err1 != nil {
 		return nil, fmt.Errorf("failed waiting for fork %s/%s: %v", sourceOrgName, sourceName, err1)
 	}
 
-	err2 := utils.WaitUntilWithInterval(func() (done bool, err error) {
+	err2 := utils.WaitUntilWithIntervalContext(g.Context(), func() (done bool, err error) {
 		// Using this to detect repo is created and populated with content
 		// https://stackoverflow.com/questions/33666838/determine-if-a-fork-is-ready
 		_, _, err = g.client.Repositories.ListCommits(ctx, targetOrgName, fork.GetName(), &github.CommitsListOptions{})
@@ -222,7 +219,7 @@ func (g *Github) ForkRepositoryWithOrgs(sourceOrgName, sourceName, targetOrgName
 			return false, nil
 		}
 		return true, nil
-	}, time.Second * 10, time.Minute * 1)
+	}, time.Second*10, time.Minute*1)
 	if err2 != nil {
 		return nil, fmt.Errorf("failed waiting for commits %s/%s: %v", targetOrgName, fork.GetName(), err2)
 	}
@@ -231,7 +228,7 @@ func (g *Github) ForkRepositoryWithOrgs(sourceOrgName, sourceName, targetOrgName
 		Name: github.String(targetName),
 	}
 
-	err3 := utils.WaitUntilWithInterval(func() (done bool, err error) {
+	err3 := utils.WaitUntilWithIntervalContext(g.Context(), func() (done bool, err error) {
 		repo, resp, err = g.client.Repositories.Edit(ctx, targetOrgName, fork.GetName(), editedRepo)
 		if err != nil {
 			if resp.StatusCode == 422 {
@@ -242,7 +239,7 @@ func (g *Github) ForkRepositoryWithOrgs(sourceOrgName, sourceName, targetOrgName
 			return false, fmt.Errorf("error renaming %s/%s to %s: %v", targetOrgName, fork.GetName(), targetName, err)
 		}
 		return true, nil
-	}, time.Second * 10, time.Minute * 1)
+	}, time.Second*10, time.Minute*1)
 	if err3 != nil {
 		return nil, fmt.Errorf("failed waiting for renaming %s/%s: %v", targetOrgName, targetName, err3)
 	}
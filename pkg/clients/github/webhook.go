@@ -1,7 +1,6 @@
 package github
 
 import (
-	"context"
 	"fmt"
 
 	"github.com/google/go-github/v44/github"
@@ -12,7 +11,7 @@ type Webhook struct {
 }
 
 func (g *Github) ListRepoWebhooks(repository string) ([]*github.Hook, error) {
-	hooks, _, err := g.client.Repositories.ListHooks(context.Background(), g.organization, repository, &github.ListOptions{})
+	hooks, _, err := g.client.Repositories.ListHooks(g.Context(), g.organization, repository, &github.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("error when listing webhooks: %v", err)
 	}
@@ -29,15 +28,33 @@ func (g *Github) CreateWebhook(repository, url string) (int64, error) {
 		},
 	}
 
-	hook, _, err := g.client.Repositories.CreateHook(context.Background(), g.organization, repository, newWebhook)
+	hook, _, err := g.client.Repositories.CreateHook(g.Context(), g.organization, repository, newWebhook)
 	if err != nil {
 		return 0, fmt.Errorf("error when creating a webhook: %v", err)
 	}
 	return hook.GetID(), err
 }
 
+// CreateWebhookForEvents creates a webhook to the url triggered by the given events.
+func (g *Github) CreateWebhookForEvents(repository, url string, events []string) (*github.Hook, error) {
+	newWebhook := &github.Hook{
+		Events: events,
+		Config: map[string]interface{}{
+			"content_type": "json",
+			"insecure_ssl": 0,
+			"url":          url,
+		},
+	}
+
+	hook, _, err := g.client.Repositories.CreateHook(g.Context(), g.organization, repository, newWebhook)
+	if err != nil {
+		return nil, fmt.Errorf("error when creating a webhook: %v", err)
+	}
+	return hook, nil
+}
+
 func (g *Github) DeleteWebhook(repository string, ID int64) error {
-	_, err := g.client.Repositories.DeleteHook(context.Background(), g.organization, repository, ID)
+	_, err := g.client.Repositories.DeleteHook(g.Context(), g.organization, repository, ID)
 	if err != nil {
 		return fmt.Errorf("error when deleting webhook: %v", err)
 	}
@@ -55,11 +55,11 @@ func (gc *GitlabClient) CreateBranch(projectID, newBranchName, defaultBranch str
 // ExistsBranch checks if a branch exists in a specified GitLab repository.
 func (gc *GitlabClient) ExistsBranch(projectID, branchName string) (bool, error) {
 
-	_, _, err := gc.client.Branches.GetBranch(projectID, branchName)
+	_, resp, err := gc.client.Branches.GetBranch(projectID, branchName)
 	if err == nil {
 		return true, nil
 	}
-	if err, ok := err.(*gitlab.ErrorResponse); ok && err.Response.StatusCode == 404 {
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
 		return false, nil
 	}
 	return false, err
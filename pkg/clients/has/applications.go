@@ -6,6 +6,7 @@ import (
 	"time"
 
 	appservice "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/logs"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
@@ -20,7 +21,7 @@ func (h *HasController) GetApplication(name string, namespace string) (*appservi
 	application := appservice.Application{
 		Spec: appservice.ApplicationSpec{},
 	}
-	if err := h.KubeRest().Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, &application); err != nil {
+	if err := h.KubeRest().Get(h.Context(), types.NamespacedName{Name: name, Namespace: namespace}, &application); err != nil {
 		return nil, err
 	}
 
@@ -44,11 +45,12 @@ func (h *HasController) CreateApplicationWithTimeout(name string, namespace stri
 		},
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	ctx, cancel := context.WithTimeout(h.Context(), time.Minute*1)
 	defer cancel()
 	if err := h.KubeRest().Create(ctx, application); err != nil {
 		return nil, err
 	}
+	h.TrackObject(application, tracker.OrderApplication)
 
 	return application, nil
 }
@@ -63,12 +65,13 @@ func (h *HasController) DeleteApplication(name string, namespace string, reportE
 			Namespace: namespace,
 		},
 	}
-	if err := h.KubeRest().Delete(context.Background(), &application); err != nil {
+	if err := h.KubeRest().Delete(h.Context(), &application); err != nil {
 		if !k8sErrors.IsNotFound(err) || (k8sErrors.IsNotFound(err) && reportErrorOnNotFound) {
 			return fmt.Errorf("error deleting an application: %+v", err)
 		}
 	}
-	return utils.WaitUntil(h.ApplicationDeleted(&application), 1*time.Minute)
+	h.UntrackObject(&application)
+	return utils.WaitUntilContext(h.Context(), h.ApplicationDeleted(&application), 1*time.Minute)
 }
 
 // ApplicationDeleted check if a given application object was deleted successfully from the kubernetes cluster.
@@ -81,11 +84,11 @@ func (h *HasController) ApplicationDeleted(application *appservice.Application)
 
 // DeleteAllApplicationsInASpecificNamespace removes all application CRs from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (h *HasController) DeleteAllApplicationsInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := h.KubeRest().DeleteAllOf(context.Background(), &appservice.Application{}, rclient.InNamespace(namespace)); err != nil {
+	if err := h.KubeRest().DeleteAllOf(h.Context(), &appservice.Application{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting applications from the namespace %s: %+v", namespace, err)
 	}
 
-	return utils.WaitUntil(func() (done bool, err error) {
+	return utils.WaitUntilContext(h.Context(), func() (done bool, err error) {
 		applicationList, err := h.ListAllApplications(namespace)
 		if err != nil {
 			return false, nil
@@ -97,7 +100,7 @@ func (h *HasController) DeleteAllApplicationsInASpecificNamespace(namespace stri
 // ListAllApplications returns a list of all Applications in a given namespace.
 func (h *HasController) ListAllApplications(namespace string) (*appservice.ApplicationList, error) {
 	applicationList := &appservice.ApplicationList{}
-	err := h.KubeRest().List(context.Background(), applicationList, &rclient.ListOptions{Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), applicationList, &rclient.ListOptions{Namespace: namespace})
 
 	return applicationList, err
 }
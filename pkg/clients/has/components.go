@@ -10,6 +10,7 @@ import (
 	"github.com/devfile/library/v2/pkg/util"
 	appservice "github.com/konflux-ci/application-api/api/v1alpha1"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/tekton"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/logs"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
@@ -35,7 +36,7 @@ const (
 // GetComponent return a component object from kubernetes cluster
 func (h *HasController) GetComponent(name string, namespace string) (*appservice.Component, error) {
 	component := &appservice.Component{}
-	if err := h.KubeRest().Get(context.Background(), types.NamespacedName{Name: name, Namespace: namespace}, component); err != nil {
+	if err := h.KubeRest().Get(h.Context(), types.NamespacedName{Name: name, Namespace: namespace}, component); err != nil {
 		return nil, err
 	}
 
@@ -48,7 +49,7 @@ func (h *HasController) GetComponentByApplicationName(applicationName string, na
 	opts := []rclient.ListOption{
 		rclient.InNamespace(namespace),
 	}
-	err := h.KubeRest().List(context.Background(), components, opts...)
+	err := h.KubeRest().List(h.Context(), components, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -77,8 +78,10 @@ func (h *HasController) GetComponentPipelineRunWithType(componentName string, ap
 	}
 }
 
-// GetComponentPipelineRunsWithType returns all pipeline runs for a given component labels with pipeline type within label "pipelines.appstudio.openshift.io/type" ("build", "test")
-func (h *HasController) GetComponentPipelineRunsWithType(componentName string, applicationName string, namespace, pipelineType string, sha string, eventType string) (*[]pipeline.PipelineRun, error) {
+var pipelineRunsResource = pipeline.SchemeGroupVersion.WithResource("pipelineruns")
+
+// componentPipelineRunLabels returns the labels of the pipeline runs of a given component, pipelineType, sha and eventType are omitted when empty.
+func componentPipelineRunLabels(componentName, applicationName, pipelineType, sha, eventType string) map[string]string {
 	pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName}
 	if pipelineType != "" {
 		pipelineRunLabels["pipelines.appstudio.openshift.io/type"] = pipelineType
@@ -91,9 +94,13 @@ func (h *HasController) GetComponentPipelineRunsWithType(componentName string, a
 	if eventType != "" {
 		pipelineRunLabels["pipelinesascode.tekton.dev/event-type"] = eventType
 	}
+	return pipelineRunLabels
+}
 
+// GetComponentPipelineRunsWithType returns all pipeline runs for a given component labels with pipeline type within label "pipelines.appstudio.openshift.io/type" ("build", "test")
+func (h *HasController) GetComponentPipelineRunsWithType(componentName string, applicationName string, namespace, pipelineType string, sha string, eventType string) (*[]pipeline.PipelineRun, error) {
 	list := &pipeline.PipelineRunList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(componentPipelineRunLabels(componentName, applicationName, pipelineType, sha, eventType)), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace: %v", namespace, err)
@@ -116,7 +123,7 @@ func (h *HasController) GetAllPipelineRunsForApplication(applicationName, namesp
 	pipelineRunLabels := map[string]string{"appstudio.openshift.io/application": applicationName}
 
 	list := &pipeline.PipelineRunList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace: %v", namespace, err)
@@ -134,7 +141,7 @@ func (h *HasController) GetAllGroupSnapshotsForApplication(applicationName, name
 	snapshotLabels := map[string]string{"appstudio.openshift.io/application": applicationName, "test.appstudio.openshift.io/type": "group"}
 
 	list := &appservice.SnapshotList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing snapshots in %s namespace: %v", namespace, err)
@@ -152,7 +159,7 @@ func (h *HasController) GetAllComponentSnapshotsForApplication(applicationName,
 	snapshotLabels := map[string]string{"appstudio.openshift.io/application": applicationName, "test.appstudio.openshift.io/type": "component"}
 
 	list := &appservice.SnapshotList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing snapshots in %s namespace: %v", namespace, err)
@@ -170,7 +177,7 @@ func (h *HasController) GetAllComponentSnapshotsForApplicationAndComponent(appli
 	snapshotLabels := map[string]string{"appstudio.openshift.io/application": applicationName, "test.appstudio.openshift.io/type": "component", "appstudio.openshift.io/component": componentName}
 
 	list := &appservice.SnapshotList{}
-	err := h.KubeRest().List(context.Background(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
+	err := h.KubeRest().List(h.Context(), list, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(snapshotLabels), Namespace: namespace})
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing snapshots in %s namespace: %v", namespace, err)
@@ -208,30 +215,32 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 
 	// Fail fast if the PipelineRun is never created.
 	// Without this, we burn the full 30-minute completion timeout
-	// just polling for a resource that may never appear.
+	// just waiting for a resource that may never appear.
 	const pipelineRunCreationTimeout = 5 * time.Minute
 
 	for {
-		creationDeadline := time.Now().Add(pipelineRunCreationTimeout)
-		prFound := false
-
-		err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-			pr, err = h.GetComponentPipelineRunWithType(component.GetName(), app, component.GetNamespace(), pipelineType, sha, eventType)
-
-			if err != nil {
-				if !prFound && time.Now().After(creationDeadline) {
-					return false, fmt.Errorf("PipelineRun was not created for Component %s/%s within %v",
-						component.GetNamespace(), component.GetName(), pipelineRunCreationTimeout)
-				}
-				ginkgo.GinkgoWriter.Printf("PipelineRun has not been created yet for the Component %s/%s\n", component.GetNamespace(), component.GetName())
-				return false, nil
-			}
-
-			if !prFound {
-				prFound = true
-				ginkgo.GinkgoWriter.Printf("PipelineRun %s found for Component %s/%s\n", pr.Name, component.GetNamespace(), component.GetName())
-			}
-
+		opts := utils.WatchOptions{
+			Namespace:     component.GetNamespace(),
+			LabelSelector: labels.SelectorFromSet(componentPipelineRunLabels(component.GetName(), app, pipelineType, sha, eventType)).String(),
+			Newest:        true,
+			Timeout:       pipelineRunCreationTimeout,
+			PollInterval:  constants.PipelineRunPollingInterval,
+		}
+		created, err := utils.WaitForObject(h.Context(), h.DynamicClient(), pipelineRunsResource, opts, func(*pipeline.PipelineRun) (bool, error) {
+			return true, nil
+		})
+		if err != nil {
+			return fmt.Errorf("PipelineRun was not created for Component %s/%s within %v: %v",
+				component.GetNamespace(), component.GetName(), pipelineRunCreationTimeout, err)
+		}
+		ginkgo.GinkgoWriter.Printf("PipelineRun %s found for Component %s/%s\n", created.Name, component.GetNamespace(), component.GetName())
+		pr = created
+
+		opts.Name = created.Name
+		opts.Newest = false
+		opts.Timeout = 30 * time.Minute
+		_, err = utils.WaitForObject(h.Context(), h.DynamicClient(), pipelineRunsResource, opts, func(candidate *pipeline.PipelineRun) (bool, error) {
+			pr = candidate
 			ginkgo.GinkgoWriter.Printf("PipelineRun %s reason: %s\n", pr.Name, pr.GetStatusCondition().GetCondition(apis.ConditionSucceeded).GetReason())
 
 			if !pr.IsDone() {
@@ -242,20 +251,17 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 				return true, nil
 			}
 
-			var prLogs string
-			if err = t.StorePipelineRun(component.GetName(), pr); err != nil {
+			if err := t.StorePipelineRun(component.GetName(), pr); err != nil {
 				ginkgo.GinkgoWriter.Printf("failed to store PipelineRun %s:%s: %s\n", pr.GetNamespace(), pr.GetName(), err.Error())
 			}
-			if prLogs, err = t.GetPipelineRunLogs(component.GetName(), pr.Name, pr.Namespace); err != nil {
+			prLogs, err := t.GetPipelineRunLogs(component.GetName(), pr.Name, pr.Namespace)
+			if err != nil {
 				ginkgo.GinkgoWriter.Printf("failed to get logs for PipelineRun %s:%s: %s\n", pr.GetNamespace(), pr.GetName(), err.Error())
 			}
 			return false, fmt.Errorf("%s", prLogs)
 		})
 
 		if err != nil {
-			if !prFound {
-				return fmt.Errorf("PipelineRun cannot be created for the Component %s/%s", component.GetNamespace(), component.GetName())
-			}
 			ginkgo.GinkgoWriter.Printf("attempt %d/%d: PipelineRun %q failed: %+v", attempts, r.Retries+1, pr.GetName(), err)
 			// CouldntGetTask: Retry the PipelineRun only in case we hit the known issue https://issues.redhat.com/browse/SRVKP-2749
 			// TaskRunImagePullFailed: Retry in case of https://issues.redhat.com/browse/RHTAPBUGS-985 and https://github.com/tektoncd/pipeline/issues/7184
@@ -265,7 +271,7 @@ func (h *HasController) WaitForComponentPipelineToBeFinished(component *appservi
 			if err = t.RemoveFinalizerFromPipelineRun(pr, constants.E2ETestFinalizerName); err != nil {
 				return fmt.Errorf("failed to remove the finalizer from pipelinerun %s:%s in order to retrigger it: %+v", pr.GetNamespace(), pr.GetName(), err)
 			}
-			if err = h.PipelineClient().TektonV1().PipelineRuns(pr.GetNamespace()).Delete(context.Background(), pr.GetName(), metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
+			if err = h.PipelineClient().TektonV1().PipelineRuns(pr.GetNamespace()).Delete(h.Context(), pr.GetName(), metav1.DeleteOptions{}); err != nil && !k8sErrors.IsNotFound(err) {
 				return fmt.Errorf("failed to delete PipelineRun %q from %q namespace with error: %v", pr.GetName(), pr.GetNamespace(), err)
 			}
 			if sha, err = h.RetriggerComponentPipelineRun(component, pr); err != nil {
@@ -319,11 +325,12 @@ func (h *HasController) CreateComponent(componentSpec appservice.ComponentSpec,
 		componentObject.Annotations = utils.MergeMaps(componentObject.Annotations, constants.ImageControllerAnnotationRequestPublicRepo)
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	ctx, cancel := context.WithTimeout(h.Context(), time.Minute*1)
 	defer cancel()
 	if err := h.KubeRest().Create(ctx, componentObject); err != nil {
 		return nil, err
 	}
+	h.TrackObject(componentObject, tracker.OrderComponent)
 
 	return componentObject, nil
 }
@@ -336,7 +343,7 @@ func (h *HasController) CreateComponentCheckImageRepository(componentSpec appser
 	}
 
 	// Decrease the timeout to 5 mins, when the issue https://issues.redhat.com/browse/STONEBLD-3552 is fixed
-	if err := utils.WaitUntilWithInterval(h.CheckImageRepositoryExists(namespace, componentSpec.ComponentName), time.Second*10, time.Minute*15); err != nil {
+	if err := utils.WaitUntilWithIntervalContext(h.Context(), h.CheckImageRepositoryExists(namespace, componentSpec.ComponentName), time.Second*10, time.Minute*15); err != nil {
 		return nil, fmt.Errorf("timed out waiting for image repository to be ready for component %s in namespace %s: %+v", componentSpec.ComponentName, namespace, err)
 	}
 
@@ -368,10 +375,11 @@ func (h *HasController) CreateComponentWithDockerSource(applicationName, compone
 			Route:          "",
 		},
 	}
-	err := h.KubeRest().Create(context.Background(), component)
+	err := h.KubeRest().Create(h.Context(), component)
 	if err != nil {
 		return nil, err
 	}
+	h.TrackObject(component, tracker.OrderComponent)
 	return component, nil
 }
 
@@ -379,7 +387,7 @@ func (h *HasController) CreateComponentWithDockerSource(applicationName, compone
 func (h *HasController) ScaleComponentReplicas(component *appservice.Component, replicas *int) (*appservice.Component, error) {
 	component.Spec.Replicas = replicas
 
-	err := h.KubeRest().Update(context.Background(), component, &rclient.UpdateOptions{})
+	err := h.KubeRest().Update(h.Context(), component, &rclient.UpdateOptions{})
 	if err != nil {
 		return &appservice.Component{}, err
 	}
@@ -394,28 +402,29 @@ func (h *HasController) DeleteComponent(name string, namespace string, reportErr
 			Namespace: namespace,
 		},
 	}
-	if err := h.KubeRest().Delete(context.Background(), &component); err != nil {
+	if err := h.KubeRest().Delete(h.Context(), &component); err != nil {
 		if !k8sErrors.IsNotFound(err) || (k8sErrors.IsNotFound(err) && reportErrorOnNotFound) {
 			return fmt.Errorf("error deleting a component: %+v", err)
 		}
 	}
+	h.UntrackObject(&component)
 
 	// RHTAPBUGS-978: temporary timeout to 15min
-	err := utils.WaitUntil(h.ComponentDeleted(&component), 15*time.Minute)
+	err := utils.WaitUntilContext(h.Context(), h.ComponentDeleted(&component), 15*time.Minute)
 
 	return err
 }
 
 // DeleteAllComponentsInASpecificNamespace removes all component CRs from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (h *HasController) DeleteAllComponentsInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := h.KubeRest().DeleteAllOf(context.Background(), &appservice.Component{}, rclient.InNamespace(namespace)); err != nil {
+	if err := h.KubeRest().DeleteAllOf(h.Context(), &appservice.Component{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting components from the namespace %s: %+v", namespace, err)
 	}
 
 	componentList := &appservice.ComponentList{}
 
-	err := utils.WaitUntil(func() (done bool, err error) {
-		if err := h.KubeRest().List(context.Background(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	err := utils.WaitUntilContext(h.Context(), func() (done bool, err error) {
+		if err := h.KubeRest().List(h.Context(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 			return false, nil
 		}
 		return len(componentList.Items) == 0, nil
@@ -513,7 +522,7 @@ func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Comp
 				return fmt.Errorf("failed to get component for PipelineRun %q in %q namespace: %+v", pr.GetName(), pr.GetNamespace(), err)
 			}
 			component.Annotations = utils.MergeMaps(component.Annotations, constants.ComponentTriggerSimpleBuildAnnotation)
-			if err = h.KubeRest().Update(context.Background(), component); err != nil {
+			if err = h.KubeRest().Update(h.Context(), component); err != nil {
 				return fmt.Errorf("failed to update Component %q in %q namespace", component.GetName(), component.GetNamespace())
 			}
 			return err
@@ -535,7 +544,7 @@ func (h *HasController) RetriggerComponentPipelineRun(component *appservice.Comp
 		case <-deadline:
 			return "", fmt.Errorf("timed out waiting for new PipelineRun to appear after retriggering it for component %s:%s", component.GetNamespace(), component.GetName())
 		case <-ticker.C:
-			pipelineRuns, listErr := h.PipelineClient().TektonV1().PipelineRuns(component.GetNamespace()).List(context.Background(), metav1.ListOptions{})
+			pipelineRuns, listErr := h.PipelineClient().TektonV1().PipelineRuns(component.GetNamespace()).List(h.Context(), metav1.ListOptions{})
 			if listErr != nil {
 				ginkgo.GinkgoWriter.Printf("failed to list PipelineRuns while waiting for retrigger: %v\n", listErr)
 				continue
@@ -555,7 +564,7 @@ func (h *HasController) CheckImageRepositoryExists(namespace, componentName stri
 	return func() (bool, error) {
 		imageRepositoryList := &imagecontroller.ImageRepositoryList{}
 		imageRepoLabels := map[string]string{"appstudio.redhat.com/component": componentName}
-		err := h.KubeRest().List(context.Background(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
+		err := h.KubeRest().List(h.Context(), imageRepositoryList, &rclient.ListOptions{LabelSelector: labels.SelectorFromSet(imageRepoLabels), Namespace: namespace})
 		if err != nil {
 			return false, err
 		}
@@ -575,14 +584,14 @@ func (h *HasController) CheckImageRepositoryExists(namespace, componentName stri
 
 // DeleteAllImageRepositoriesInASpecificNamespace removes all image repository CRs from a specific namespace. Useful when cleaning up a namespace and component cleanup did not cleaned it's image repository
 func (h *HasController) DeleteAllImageRepositoriesInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := h.KubeRest().DeleteAllOf(context.Background(), &imagecontroller.ImageRepository{}, rclient.InNamespace(namespace)); err != nil {
+	if err := h.KubeRest().DeleteAllOf(h.Context(), &imagecontroller.ImageRepository{}, rclient.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting image repositories from the namespace %s: %+v", namespace, err)
 	}
 
 	imageRepositoryList := &imagecontroller.ImageRepositoryList{}
 
-	err := utils.WaitUntil(func() (done bool, err error) {
-		if err := h.KubeRest().List(context.Background(), imageRepositoryList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	err := utils.WaitUntilContext(h.Context(), func() (done bool, err error) {
+		if err := h.KubeRest().List(h.Context(), imageRepositoryList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 			return false, nil
 		}
 		return len(imageRepositoryList.Items) == 0, nil
@@ -609,7 +618,7 @@ func (h *HasController) SetComponentAnnotation(componentName, annotationKey, ann
 	newAnnotations := component.GetAnnotations()
 	newAnnotations[annotationKey] = annotationValue
 	component.SetAnnotations(newAnnotations)
-	err = h.KubeRest().Update(context.Background(), component)
+	err = h.KubeRest().Update(h.Context(), component)
 	if err != nil {
 		return fmt.Errorf("error when updating component: %+v", err)
 	}
@@ -642,7 +651,7 @@ func (h *HasController) StoreComponent(component *appservice.Component) error {
 // StoreAllComponents stores all Components in a given namespace.
 func (h *HasController) StoreAllComponents(namespace string) error {
 	componentList := &appservice.ComponentList{}
-	if err := h.KubeRest().List(context.Background(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
+	if err := h.KubeRest().List(h.Context(), componentList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 		return err
 	}
 
@@ -656,7 +665,7 @@ func (h *HasController) StoreAllComponents(namespace string) error {
 
 // UpdateComponent updates a component
 func (h *HasController) UpdateComponent(component *appservice.Component) error {
-	err := h.KubeRest().Update(context.Background(), component, &rclient.UpdateOptions{})
+	err := h.KubeRest().Update(h.Context(), component, &rclient.UpdateOptions{})
 
 	if err != nil {
 		return err
@@ -1,6 +1,8 @@
 package has
 
 import (
+	"context"
+
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
 
@@ -25,6 +27,16 @@ type HasController struct {
 	*kubeCl.CustomClient
 }
 
+// WithContext returns a copy of the controller whose kubernetes and GitHub API calls and polling are cancelled once ctx is done.
+func (h *HasController) WithContext(ctx context.Context) *HasController {
+	h2 := *h
+	h2.CustomClient = h.CustomClient.WithContext(ctx)
+	if h.Github != nil {
+		h2.Github = h.Github.WithContext(ctx)
+	}
+	return &h2
+}
+
 // Initializes all the clients and return interface to operate with application-service controller.
 func NewSuiteController(kube *kubeCl.CustomClient) (*HasController, error) {
 	gh, err := github.NewGithubClient(utils.GetEnv(constants.GITHUB_TOKEN_ENV, ""),
@@ -1,6 +1,8 @@
 package integration
 
 import (
+	"context"
+
 	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
 )
 
@@ -13,3 +15,8 @@ func NewSuiteController(kube *kubeCl.CustomClient) (*IntegrationController, erro
 		kube,
 	}, nil
 }
+
+// WithContext returns a copy of the controller whose API calls and polling are cancelled once ctx is done.
+func (i *IntegrationController) WithContext(ctx context.Context) *IntegrationController {
+	return &IntegrationController{i.CustomClient.WithContext(ctx)}
+}
@@ -1,10 +1,10 @@
 package integration
 
 import (
-	"context"
 	"strings"
 
 	"github.com/devfile/library/v2/pkg/util"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	integrationv1beta2 "github.com/konflux-ci/integration-service/api/v1beta2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,10 +61,11 @@ func (i *IntegrationController) CreateIntegrationTestScenario(itsName, applicati
 		}
 	}
 
-	err := i.KubeRest().Create(context.Background(), integrationTestScenario)
+	err := i.KubeRest().Create(i.Context(), integrationTestScenario)
 	if err != nil {
 		return nil, err
 	}
+	i.TrackObject(integrationTestScenario, tracker.OrderIntegrationTestScenario)
 	return integrationTestScenario, nil
 }
 
@@ -119,10 +120,11 @@ func (i *IntegrationController) CreateOptionalIntegrationTestScenario(itsName, a
 		}
 	}
 
-	err := i.KubeRest().Create(context.Background(), integrationTestScenario)
+	err := i.KubeRest().Create(i.Context(), integrationTestScenario)
 	if err != nil {
 		return nil, err
 	}
+	i.TrackObject(integrationTestScenario, tracker.OrderIntegrationTestScenario)
 	return integrationTestScenario, nil
 }
 
@@ -133,7 +135,7 @@ func (i *IntegrationController) GetIntegrationTestScenarios(applicationName, nam
 	}
 
 	integrationTestScenarioList := &integrationv1beta2.IntegrationTestScenarioList{}
-	err := i.KubeRest().List(context.Background(), integrationTestScenarioList, opts...)
+	err := i.KubeRest().List(i.Context(), integrationTestScenarioList, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -149,6 +151,9 @@ func (i *IntegrationController) GetIntegrationTestScenarios(applicationName, nam
 
 // DeleteIntegrationTestScenario removes given testScenario from specified namespace.
 func (i *IntegrationController) DeleteIntegrationTestScenario(testScenario *integrationv1beta2.IntegrationTestScenario, namespace string) error {
-	err := i.KubeRest().Delete(context.Background(), testScenario)
+	err := i.KubeRest().Delete(i.Context(), testScenario)
+	if err == nil {
+		i.UntrackObject(testScenario)
+	}
 	return err
 }
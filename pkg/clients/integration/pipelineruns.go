@@ -1,34 +1,33 @@
 package integration
 
 import (
-	"context"
 	"fmt"
-	"sort"
 	"time"
 
 	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	"github.com/konflux-ci/e2e-tests/pkg/utils/tekton"
 	integrationv1beta2 "github.com/konflux-ci/integration-service/api/v1beta2"
+	"github.com/konflux-ci/operator-toolkit/metadata"
 	ginkgo "github.com/onsi/ginkgo/v2"
 	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
-	"github.com/konflux-ci/operator-toolkit/metadata"
 )
 
 var (
-	shortTimeout = time.Duration(10 * time.Minute)
+	shortTimeout     = time.Duration(10 * time.Minute)
 	superLongTimeout = time.Duration(20 * time.Minute)
 	// SnapshotIntegrationTestRun contains name of test we want to trigger run
 	SnapshotIntegrationTestRun = "test.appstudio.openshift.io/run"
 )
+
 // CreateIntegrationPipelineRun creates new integrationPipelineRun.
 func (i *IntegrationController) CreateIntegrationPipelineRun(snapshotName, namespace, componentName, integrationTestScenarioName string) (*tektonv1.PipelineRun, error) {
 	testpipelineRun := &tektonv1.PipelineRun{
@@ -59,49 +58,70 @@ func (i *IntegrationController) CreateIntegrationPipelineRun(snapshotName, names
 			},
 		},
 	}
-	err := i.KubeRest().Create(context.Background(), testpipelineRun)
+	err := i.KubeRest().Create(i.Context(), testpipelineRun)
 	if err != nil {
 		return nil, err
 	}
+	i.TrackObject(testpipelineRun, tracker.OrderPipelineRun)
 	return testpipelineRun, err
 }
 
 // GetComponentPipeline returns the pipeline for a given component labels.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) GetBuildPipelineRun(componentName, applicationName, namespace string, pacBuild bool, sha string) (*tektonv1.PipelineRun, error) {
-	var pipelineRun *tektonv1.PipelineRun
-
-	err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, superLongTimeout, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName, "pipelines.appstudio.openshift.io/type": "build"}
+	pipelineRun, err := i.waitForBuildPipelineRun(componentName, applicationName, namespace, sha, superLongTimeout, func(*tektonv1.PipelineRun) (bool, error) {
+		return true, nil
+	})
+	if pipelineRun == nil {
+		pipelineRun = &tektonv1.PipelineRun{}
+	}
 
-		if sha != "" {
-			pipelineRunLabels["pipelinesascode.tekton.dev/sha"] = sha
-		}
+	return pipelineRun, err
+}
 
-		list := &tektonv1.PipelineRunList{}
-		err = i.KubeRest().List(context.Background(), list, &client.ListOptions{LabelSelector: labels.SelectorFromSet(pipelineRunLabels), Namespace: namespace})
+var pipelineRunsResource = tektonv1.SchemeGroupVersion.WithResource("pipelineruns")
 
-		if err != nil && !k8sErrors.IsNotFound(err) {
-			ginkgo.GinkgoWriter.Printf("error listing pipelineruns in %s namespace: %v", namespace, err)
-			return false, nil
-		}
+// buildPipelineRunLabels returns the labels of the build pipelineRuns of a given component, of any commit when sha is empty.
+func buildPipelineRunLabels(componentName, applicationName, sha string) map[string]string {
+	pipelineRunLabels := map[string]string{"appstudio.openshift.io/component": componentName, "appstudio.openshift.io/application": applicationName, "pipelines.appstudio.openshift.io/type": "build"}
+	if sha != "" {
+		pipelineRunLabels["pipelinesascode.tekton.dev/sha"] = sha
+	}
+	return pipelineRunLabels
+}
 
-		if len(list.Items) > 0 {
-			// sort PipelineRuns by StartTime in ascending order
-			sort.Slice(list.Items, func(i, j int) bool {
-				return list.Items[i].Status.StartTime.Before(list.Items[j].Status.StartTime)
-			})
-			// get latest pipelineRun
-			pipelineRun = &list.Items[len(list.Items)-1]
-			return true, nil
-		}
+// waitForBuildPipelineRun waits until the newest build pipelineRun of the given component satisfies cond.
+func (i *IntegrationController) waitForBuildPipelineRun(componentName, applicationName, namespace, sha string, timeout time.Duration, cond func(pipelineRun *tektonv1.PipelineRun) (bool, error)) (*tektonv1.PipelineRun, error) {
+	opts := utils.WatchOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(buildPipelineRunLabels(componentName, applicationName, sha)).String(),
+		Newest:        true,
+		Timeout:       timeout,
+		PollInterval:  constants.PipelineRunPollingInterval,
+	}
+	return utils.WaitForObject(i.Context(), i.DynamicClient(), pipelineRunsResource, opts, cond)
+}
 
-		pipelineRun = &tektonv1.PipelineRun{}
-		ginkgo.GinkgoWriter.Printf("no pipelinerun found for component %s %s", componentName, utils.GetAdditionalInfo(applicationName, namespace))
-		return false, nil
-	})
+// integrationPipelineRunLabels returns the labels of the integration pipelineRuns of a given scenario and snapshot.
+func integrationPipelineRunLabels(integrationTestScenarioName, snapshotName string) map[string]string {
+	return map[string]string{
+		"pipelines.appstudio.openshift.io/type": "test",
+		"test.appstudio.openshift.io/scenario":  integrationTestScenarioName,
+		"appstudio.openshift.io/snapshot":       snapshotName,
+	}
+}
 
-	return pipelineRun, err
+// waitForIntegrationPipelineRun waits until the newest integration pipelineRun of the given scenario and snapshot satisfies cond.
+// The pipelineRuns of earlier test runs of the snapshot are not evaluated.
+func (i *IntegrationController) waitForIntegrationPipelineRun(integrationTestScenarioName, snapshotName, namespace string, timeout time.Duration, cond func(pipelineRun *tektonv1.PipelineRun) (bool, error)) (*tektonv1.PipelineRun, error) {
+	opts := utils.WatchOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(integrationPipelineRunLabels(integrationTestScenarioName, snapshotName)).String(),
+		Newest:        true,
+		Timeout:       timeout,
+		PollInterval:  constants.PipelineRunPollingInterval,
+	}
+	return utils.WaitForObject(i.Context(), i.DynamicClient(), pipelineRunsResource, opts, cond)
 }
 
 // GetIntegrationPipelineRun returns the integration pipelineRun
@@ -109,15 +129,11 @@ func (i *IntegrationController) GetBuildPipelineRun(componentName, applicationNa
 func (i *IntegrationController) GetIntegrationPipelineRun(integrationTestScenarioName string, snapshotName string, namespace string) (*tektonv1.PipelineRun, error) {
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
-		client.MatchingLabels{
-			"pipelines.appstudio.openshift.io/type": "test",
-			"test.appstudio.openshift.io/scenario":  integrationTestScenarioName,
-			"appstudio.openshift.io/snapshot":       snapshotName,
-		},
+		client.MatchingLabels(integrationPipelineRunLabels(integrationTestScenarioName, snapshotName)),
 	}
 
 	list := &tektonv1.PipelineRunList{}
-	err := i.KubeRest().List(context.Background(), list, opts...)
+	err := i.KubeRest().List(i.Context(), list, opts...)
 
 	if err != nil && !k8sErrors.IsNotFound(err) {
 		return nil, fmt.Errorf("error listing pipelineruns in %s namespace", namespace)
@@ -133,20 +149,16 @@ func (i *IntegrationController) GetIntegrationPipelineRun(integrationTestScenari
 // WaitForIntegrationPipelineToGetStarted wait for given integration pipeline to get started.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForIntegrationPipelineToGetStarted(testScenarioName, snapshotName, appNamespace string) (*tektonv1.PipelineRun, error) {
-	var testPipelinerun *tektonv1.PipelineRun
-
-	err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, shortTimeout, true, func(ctx context.Context) (done bool, err error) {
-		testPipelinerun, err = i.GetIntegrationPipelineRun(testScenarioName, snapshotName, appNamespace)
-		if err != nil {
-			ginkgo.GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenarioName, appNamespace, snapshotName)
-			return false, nil
-		}
-		if !testPipelinerun.HasStarted() {
-			ginkgo.GinkgoWriter.Println("pipelinerun %s/%s hasn't started yet", testPipelinerun.GetNamespace(), testPipelinerun.GetName())
+	testPipelinerun, err := i.waitForIntegrationPipelineRun(testScenarioName, snapshotName, appNamespace, shortTimeout, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		if !pipelineRun.HasStarted() {
+			ginkgo.GinkgoWriter.Printf("pipelinerun %s/%s hasn't started yet\n", pipelineRun.GetNamespace(), pipelineRun.GetName())
 			return false, nil
 		}
 		return true, nil
 	})
+	if testPipelinerun == nil {
+		testPipelinerun = &tektonv1.PipelineRun{}
+	}
 
 	return testPipelinerun, err
 }
@@ -154,12 +166,7 @@ func (i *IntegrationController) WaitForIntegrationPipelineToGetStarted(testScena
 // WaitForIntegrationPipelineToBeFinished wait for given integration pipeline to finish.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForIntegrationPipelineToBeFinished(testScenario *integrationv1beta2.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, superLongTimeout, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := i.GetIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace)
-		if err != nil {
-			ginkgo.GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenario.GetName(), snapshot.GetNamespace(), snapshot.GetName())
-			return false, nil
-		}
+	_, err := i.waitForIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace, superLongTimeout, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
 		ginkgo.GinkgoWriter.Printf("PipelineRun %s reason: %s\n", pipelineRun.Name, pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).GetReason())
 
 		if !pipelineRun.IsDone() {
@@ -169,12 +176,13 @@ func (i *IntegrationController) WaitForIntegrationPipelineToBeFinished(testScena
 		if pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).IsTrue() {
 			return true, nil
 		}
-		var prLogs string
-		if prLogs, err = tekton.GetFailedPipelineRunLogs(i.KubeRest(), i.KubeInterface(), pipelineRun); err != nil {
+		prLogs, err := tekton.GetFailedPipelineRunLogs(i.KubeRest(), i.KubeInterface(), pipelineRun)
+		if err != nil {
 			return false, fmt.Errorf("failed to get PLR logs: %+v", err)
 		}
 		return false, fmt.Errorf("%s", prLogs)
 	})
+	return err
 }
 
 func (i *IntegrationController) isScenarioInExpectedScenarios(testScenario *integrationv1beta2.IntegrationTestScenario, expectedTestScenarios []string) bool {
@@ -232,12 +240,7 @@ func (i *IntegrationController) WaitForFinalizerToGetRemovedFromAllIntegrationPi
 // WaitForFinalizerToGetRemovedFromIntegrationPipeline waits for the
 // given finalizer to get removed from the given integration pipelinerun
 func (i *IntegrationController) WaitForFinalizerToGetRemovedFromIntegrationPipeline(testScenario *integrationv1beta2.IntegrationTestScenario, snapshot *appstudioApi.Snapshot, appNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, shortTimeout, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := i.GetIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace)
-		if err != nil {
-			ginkgo.GinkgoWriter.Println("PipelineRun has not been created yet for test scenario %s and snapshot %s/%s", testScenario.GetName(), snapshot.GetNamespace(), snapshot.GetName())
-			return false, nil
-		}
+	_, err := i.waitForIntegrationPipelineRun(testScenario.Name, snapshot.Name, appNamespace, shortTimeout, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
 		if controllerutil.ContainsFinalizer(pipelineRun, "test.appstudio.openshift.io/pipelinerun") {
 			ginkgo.GinkgoWriter.Printf("build pipelineRun %s/%s still contains the finalizer: %s", pipelineRun.GetNamespace(), pipelineRun.GetName(), "test.appstudio.openshift.io/pipelinerun")
 			return false, nil
@@ -245,6 +248,7 @@ func (i *IntegrationController) WaitForFinalizerToGetRemovedFromIntegrationPipel
 
 		return true, nil
 	})
+	return err
 }
 
 // GetAnnotationIfExists returns the value of a given annotation within a pipelinerun, if it exists.
@@ -259,48 +263,34 @@ func (i *IntegrationController) GetAnnotationIfExists(testNamespace, application
 // WaitForBuildPipelineRunToGetAnnotated waits for given build pipeline to get annotated with a specific annotation.
 // In case of failure, this function retries till it gets timed out.
 func (i *IntegrationController) WaitForBuildPipelineRunToGetAnnotated(testNamespace, applicationName, componentName, annotationKey string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 5*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := i.GetBuildPipelineRun(componentName, applicationName, testNamespace, false, "")
-		if err != nil {
-			ginkgo.GinkgoWriter.Printf("pipelinerun for Component %s/%s can't be gotten successfully. Error: %v", testNamespace, componentName, err)
-			return false, nil
-		}
-
-		annotationValue, _ := i.GetAnnotationIfExists(testNamespace, applicationName, componentName, annotationKey)
-		if annotationValue == "" {
-			ginkgo.GinkgoWriter.Printf("build pipelinerun %s/%s doesn't contain annotation %s yet", testNamespace, pipelineRun.Name, annotationKey)
+	_, err := i.waitForBuildPipelineRun(componentName, applicationName, testNamespace, "", 5*time.Minute, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		if pipelineRun.Annotations[annotationKey] == "" {
+			ginkgo.GinkgoWriter.Printf("build pipelinerun %s/%s doesn't contain annotation %s yet\n", testNamespace, pipelineRun.Name, annotationKey)
 			return false, nil
 		}
 		return true, nil
 	})
+	return err
 }
 
 // WaitForBuildPipelineToBeFinished wait for given build pipeline to finish.
 // It exposes the error message from the failed task to the end user when the pipelineRun failed.
 func (i *IntegrationController) WaitForBuildPipelineToBeFinished(testNamespace, applicationName, componentName, sha string) (string, error) {
 	var logs string
-	return logs, wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := i.GetBuildPipelineRun(componentName, applicationName, testNamespace, false, sha)
-		if err != nil {
-			ginkgo.GinkgoWriter.Println("Build pipelineRun has not been created yet for app %s/%s, and component %s", testNamespace, applicationName, componentName)
+	_, err := i.waitForBuildPipelineRun(componentName, applicationName, testNamespace, sha, 30*time.Minute, func(pipelineRun *tektonv1.PipelineRun) (bool, error) {
+		ginkgo.GinkgoWriter.Printf("PipelineRun %s reason: %s\n", pipelineRun.Name, pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).GetReason())
+
+		if !pipelineRun.IsDone() {
 			return false, nil
 		}
-		for _, condition := range pipelineRun.Status.Conditions {
-			ginkgo.GinkgoWriter.Printf("PipelineRun %s reason: %s\n", pipelineRun.Name, condition.Reason)
-
-			if !pipelineRun.IsDone() {
-				return false, nil
-			}
 
-			if pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).IsTrue() {
-				return true, nil
-			} else {
-				logs, _ = tekton.GetFailedPipelineRunLogs(i.KubeRest(), i.KubeInterface(), pipelineRun)
-				return false, fmt.Errorf("%s", logs)
-			}
+		if pipelineRun.GetStatusCondition().GetCondition(apis.ConditionSucceeded).IsTrue() {
+			return true, nil
 		}
-		return false, nil
+		logs, _ = tekton.GetFailedPipelineRunLogs(i.KubeRest(), i.KubeInterface(), pipelineRun)
+		return false, fmt.Errorf("%s", logs)
 	})
+	return logs, err
 }
 
 func (i *IntegrationController) IsIntegrationPipelinerunCancelled(integrationTestScenarioName string, snapshot *appstudioApi.Snapshot) (bool, error) {
@@ -323,7 +313,7 @@ func (i *IntegrationController) AddIntegrationTestRerunLabel(snapshot *appstudio
 	if err != nil {
 		return fmt.Errorf("failed to add label %s: %w", SnapshotIntegrationTestRun, err)
 	}
-	err = i.KubeRest().Patch(context.Background(), snapshot, patch)
+	err = i.KubeRest().Patch(i.Context(), snapshot, patch)
 	if err != nil {
 		return fmt.Errorf("failed to patch snapshot: %w", err)
 	}
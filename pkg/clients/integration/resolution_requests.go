@@ -1,7 +1,6 @@
 package integration
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -23,7 +22,7 @@ func (i *IntegrationController) GetRelatedResolutionRequests(namespace string, i
 		Kind:    "ResolutionRequestList",
 	})
 
-	err := i.KubeRest().List(context.Background(), resolutionRequestList, client.InNamespace(namespace))
+	err := i.KubeRest().List(i.Context(), resolutionRequestList, client.InNamespace(namespace))
 	if err != nil {
 		// Check if the error is due to CRD not existing
 		if meta.IsNoMatchError(err) || strings.Contains(err.Error(), "no matches for kind") {
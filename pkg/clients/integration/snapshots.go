@@ -1,27 +1,27 @@
 package integration
 
 import (
-	"context"
 	"fmt"
-	"time"
-	"strconv"
 	"sort"
+	"strconv"
+	"time"
 
 	"github.com/devfile/library/v2/pkg/util"
 	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/logs"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
-	ginkgo "github.com/onsi/ginkgo/v2"
+	"github.com/konflux-ci/operator-toolkit/metadata"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"k8s.io/apimachinery/pkg/api/meta"
-	"github.com/konflux-ci/operator-toolkit/metadata"
 )
 
+var snapshotsResource = appstudioApi.GroupVersion.WithResource("snapshots")
+
 var (
 	// SnapshotTestsStatusAnnotation is annotation in snapshot where integration test results are stored
 	SnapshotTestsStatusAnnotation = "test.appstudio.openshift.io/status"
@@ -62,7 +62,11 @@ func (i *IntegrationController) CreateSnapshotWithComponents(snapshotName, compo
 			Components:  snapshotComponents,
 		},
 	}
-	return snapshot, i.KubeRest().Create(context.Background(), snapshot)
+	if err := i.KubeRest().Create(i.Context(), snapshot); err != nil {
+		return snapshot, err
+	}
+	i.TrackObject(snapshot, tracker.OrderSnapshot)
+	return snapshot, nil
 }
 
 // CreateSnapshotWithImage creates a snapshot using an image.
@@ -88,7 +92,7 @@ func (i *IntegrationController) GetSnapshotByComponent(namespace string) (*appst
 		},
 		client.InNamespace(namespace),
 	}
-	err := i.KubeRest().List(context.Background(), snapshot, opts...)
+	err := i.KubeRest().List(i.Context(), snapshot, opts...)
 
 	if err == nil && len(snapshot.Items) > 0 {
 		return &snapshot.Items[0], nil
@@ -100,7 +104,7 @@ func (i *IntegrationController) GetSnapshotByComponent(namespace string) (*appst
 // It will search for the Snapshot based on the Snapshot name, associated PipelineRun name or Component name
 // In the case the List operation fails, an error will be returned.
 func (i *IntegrationController) GetSnapshot(snapshotName, pipelineRunName, componentName, namespace string) (*appstudioApi.Snapshot, error) {
-	ctx := context.Background()
+	ctx := i.Context()
 	// If Snapshot name is provided, try to get the resource directly
 	if len(snapshotName) > 0 {
 		snapshot := &appstudioApi.Snapshot{}
@@ -138,24 +142,27 @@ func (i *IntegrationController) GetSnapshot(snapshotName, pipelineRunName, compo
 
 // DeleteSnapshot removes given snapshot from specified namespace.
 func (i *IntegrationController) DeleteSnapshot(hasSnapshot *appstudioApi.Snapshot, namespace string) error {
-	err := i.KubeRest().Delete(context.Background(), hasSnapshot)
+	err := i.KubeRest().Delete(i.Context(), hasSnapshot)
+	if err == nil {
+		i.UntrackObject(hasSnapshot)
+	}
 	return err
 }
 
 // PatchSnapshot patches the given snapshot with the provided patch.
 func (i *IntegrationController) PatchSnapshot(oldSnapshot *appstudioApi.Snapshot, newSnapshot *appstudioApi.Snapshot) error {
 	patch := client.MergeFrom(oldSnapshot)
-	err := i.KubeRest().Patch(context.Background(), newSnapshot, patch)
+	err := i.KubeRest().Patch(i.Context(), newSnapshot, patch)
 	return err
 }
 
 // DeleteAllSnapshotsInASpecificNamespace removes all snapshots from a specific namespace. Useful when creating a lot of resources and want to remove all of them
 func (i *IntegrationController) DeleteAllSnapshotsInASpecificNamespace(namespace string, timeout time.Duration) error {
-	if err := i.KubeRest().DeleteAllOf(context.Background(), &appstudioApi.Snapshot{}, client.InNamespace(namespace)); err != nil {
+	if err := i.KubeRest().DeleteAllOf(i.Context(), &appstudioApi.Snapshot{}, client.InNamespace(namespace)); err != nil {
 		return fmt.Errorf("error deleting snapshots from the namespace %s: %+v", namespace, err)
 	}
 
-	return utils.WaitUntil(func() (done bool, err error) {
+	return utils.WaitUntilContext(i.Context(), func() (done bool, err error) {
 		snapshotList, err := i.ListAllSnapshots(namespace)
 		if err != nil {
 			return false, nil
@@ -165,26 +172,29 @@ func (i *IntegrationController) DeleteAllSnapshotsInASpecificNamespace(namespace
 }
 
 // WaitForSnapshotToGetCreated wait for the Snapshot to get created successfully.
+// The Snapshot is matched the same way GetSnapshot does.
 func (i *IntegrationController) WaitForSnapshotToGetCreated(snapshotName, pipelinerunName, componentName, testNamespace string) (*appstudioApi.Snapshot, error) {
-	var snapshot *appstudioApi.Snapshot
-
-	err := wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 10*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		snapshot, err = i.GetSnapshot(snapshotName, pipelinerunName, componentName, testNamespace)
-		if err != nil {
-			ginkgo.GinkgoWriter.Printf("unable to get the Snapshot within the namespace %s. Error: %v", testNamespace, err)
-			return false, nil
+	opts := utils.WatchOptions{
+		Namespace:    testNamespace,
+		Name:         snapshotName,
+		Timeout:      10 * time.Minute,
+		PollInterval: constants.PipelineRunPollingInterval,
+	}
+	return utils.WaitForObject(i.Context(), i.DynamicClient(), snapshotsResource, opts, func(snapshot *appstudioApi.Snapshot) (bool, error) {
+		if len(snapshotName) > 0 {
+			return true, nil
 		}
-
-		return true, nil
+		if len(pipelinerunName) > 0 && snapshot.Labels["appstudio.openshift.io/build-pipelinerun"] == pipelinerunName {
+			return true, nil
+		}
+		return len(componentName) > 0 && snapshot.Labels["appstudio.openshift.io/component"] == componentName, nil
 	})
-
-	return snapshot, err
 }
 
 // ListAllSnapshots returns a list of all Snapshots in a given namespace.
 func (i *IntegrationController) ListAllSnapshots(namespace string) (*appstudioApi.SnapshotList, error) {
 	snapshotList := &appstudioApi.SnapshotList{}
-	err := i.KubeRest().List(context.Background(), snapshotList, &client.ListOptions{Namespace: namespace})
+	err := i.KubeRest().List(i.Context(), snapshotList, &client.ListOptions{Namespace: namespace})
 
 	return snapshotList, err
 }
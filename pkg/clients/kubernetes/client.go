@@ -5,11 +5,13 @@ import (
 	"crypto/tls"
 	"net"
 	"net/http"
+	"reflect"
 	"time"
 
 	toolchainv1alpha1 "github.com/codeready-toolchain/api/api/v1alpha1"
 	ecp "github.com/conforma/crds/api/v1alpha1"
 	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/sandbox"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	imagecontroller "github.com/konflux-ci/image-controller/api/v1alpha1"
@@ -25,6 +27,7 @@ import (
 	release "github.com/konflux-ci/release-service/api/v1alpha1"
 	tekton "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 	pipelineclientset "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -42,12 +45,14 @@ const (
 )
 
 type CustomClient struct {
-	kubeClient            *kubernetes.Clientset
+	kubeClient            kubernetes.Interface
 	crClient              crclient.Client
 	pipelineClient        pipelineclientset.Interface
 	dynamicClient         dynamic.Interface
 	jvmbuildserviceClient jvmbuildserviceclientset.Interface
 	routeClient           routeclientset.Interface
+	tracker               *tracker.Tracker
+	ctx                   context.Context
 }
 
 type K8SClient struct {
@@ -108,6 +113,65 @@ func (c *CustomClient) DynamicClient() dynamic.Interface {
 	return c.dynamicClient
 }
 
+// Context returns the context the API calls and the polling of the controllers built on top of the client are bound to.
+func (c *CustomClient) Context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// WithContext returns a shallow copy of the client whose API calls and polling are cancelled once ctx is done.
+func (c *CustomClient) WithContext(ctx context.Context) *CustomClient {
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// Tracker returns the tracker recording the objects created through the client, nil if they are not tracked.
+func (c *CustomClient) Tracker() *tracker.Tracker {
+	return c.tracker
+}
+
+// SetTracker makes the controllers built on top of the client record the objects they create into t.
+func (c *CustomClient) SetTracker(t *tracker.Tracker) {
+	c.tracker = t
+}
+
+// TrackObject records an object created through the client. During the teardown the object is
+// deleted and waited for to be gone, so its finalizers are run before its dependencies are removed.
+func (c *CustomClient) TrackObject(obj crclient.Object, order tracker.Order) {
+	if c.tracker == nil {
+		return
+	}
+	key := crclient.ObjectKeyFromObject(obj)
+	c.tracker.Track(objectKind(obj), key.Namespace, key.Name, order, func(ctx context.Context) error {
+		target := obj.DeepCopyObject().(crclient.Object)
+		if err := c.KubeRest().Delete(ctx, target); err != nil && !k8sErrors.IsNotFound(err) {
+			return err
+		}
+		return wait.PollUntilContextCancel(ctx, time.Second*2, true, func(ctx context.Context) (bool, error) {
+			err := c.KubeRest().Get(ctx, key, target)
+			if k8sErrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		})
+	})
+}
+
+// UntrackObject forgets an object deleted by a test.
+func (c *CustomClient) UntrackObject(obj crclient.Object) {
+	c.tracker.Untrack(objectKind(obj), obj.GetNamespace(), obj.GetName())
+}
+
+func objectKind(obj crclient.Object) string {
+	if kind := obj.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+		return kind
+	}
+	return reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+}
+
 // Creates Kubernetes clients:
 // 1. Will create a kubernetes client from default kubeconfig as kubeadmin
 // 2. Will create a sandbox user and will generate a client using user token a new client to create resources in RHTAP like a normal user
@@ -0,0 +1,246 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
+	integrationservicev1beta2 "github.com/konflux-ci/integration-service/api/v1beta2"
+	release "github.com/konflux-ci/release-service/api/v1alpha1"
+	routefake "github.com/openshift/client-go/route/clientset/versioned/fake"
+	jvmbuildservicefake "github.com/redhat-appstudio/jvm-build-service/pkg/client/clientset/versioned/fake"
+	tekton "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	pipelinefake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// Scheme returns the scheme with all the Konflux CRDs registered the clients are built with.
+func Scheme() *runtime.Scheme {
+	return scheme
+}
+
+// NewFakeKubernetesClient creates a client backed by in-memory fakes instead of a cluster, so the
+// controllers built on top of it can be unit-tested offline.
+// KubeRest, KubeInterface, PipelineClient and DynamicClient share one object tracker seeded with the
+// objects: an object created or updated through any of them is visible through the others and the watches
+// of all of them, so the helpers waiting for objects see the status moved forward by the Simulate* helpers.
+// KubeRest serves the status subresource of Konflux and Tekton objects like a cluster does.
+func NewFakeKubernetesClient(objects ...crclient.Object) *CustomClient {
+	tracker := &fakeTracker{ObjectTracker: clienttesting.NewObjectTracker(scheme, serializer.NewCodecFactory(scheme).UniversalDecoder())}
+
+	crClient := crfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjectTracker(tracker).
+		WithObjects(objects...).
+		WithStatusSubresource(
+			&appstudioApi.Application{},
+			&appstudioApi.Component{},
+			&appstudioApi.Snapshot{},
+			&integrationservicev1beta2.IntegrationTestScenario{},
+			&release.Release{},
+			&release.ReleasePlan{},
+			&release.ReleasePlanAdmission{},
+			&tekton.PipelineRun{},
+			&tekton.TaskRun{},
+		).
+		Build()
+
+	kubeClient := kubefake.NewSimpleClientset()
+	kubeClient.PrependReactor("*", "*", clienttesting.ObjectReaction(tracker))
+	kubeClient.PrependWatchReactor("*", tracker.watchReaction(false))
+
+	pipelineClient := pipelinefake.NewSimpleClientset()
+	pipelineClient.PrependReactor("*", "*", clienttesting.ObjectReaction(tracker))
+	pipelineClient.PrependWatchReactor("*", tracker.watchReaction(false))
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+	dynamicClient.PrependReactor("*", "*", tracker.unstructuredReaction)
+	dynamicClient.PrependWatchReactor("*", tracker.watchReaction(true))
+
+	return &CustomClient{
+		kubeClient:            kubeClient,
+		pipelineClient:        pipelineClient,
+		dynamicClient:         dynamicClient,
+		jvmbuildserviceClient: jvmbuildservicefake.NewSimpleClientset(),
+		routeClient:           routefake.NewSimpleClientset(),
+		crClient:              crClient,
+		ctx:                   context.Background(),
+	}
+}
+
+// fakeTracker is the object tracker shared by the fakes of NewFakeKubernetesClient. It stores the objects
+// of the kinds known to the scheme as their Go types, whichever client created them, so the typed clients
+// can read the objects created through DynamicClient.
+type fakeTracker struct {
+	clienttesting.ObjectTracker
+}
+
+func (t *fakeTracker) Add(obj runtime.Object) error {
+	obj, err := toTyped(obj)
+	if err != nil {
+		return err
+	}
+	return t.ObjectTracker.Add(obj)
+}
+
+func (t *fakeTracker) Create(gvr schema.GroupVersionResource, obj runtime.Object, ns string, opts ...metav1.CreateOptions) error {
+	obj, err := toTyped(obj)
+	if err != nil {
+		return err
+	}
+	return t.ObjectTracker.Create(gvr, obj, ns, opts...)
+}
+
+func (t *fakeTracker) Update(gvr schema.GroupVersionResource, obj runtime.Object, ns string, opts ...metav1.UpdateOptions) error {
+	obj, err := toTyped(obj)
+	if err != nil {
+		return err
+	}
+	return t.ObjectTracker.Update(gvr, obj, ns, opts...)
+}
+
+func (t *fakeTracker) Patch(gvr schema.GroupVersionResource, obj runtime.Object, ns string, opts ...metav1.PatchOptions) error {
+	obj, err := toTyped(obj)
+	if err != nil {
+		return err
+	}
+	return t.ObjectTracker.Patch(gvr, obj, ns, opts...)
+}
+
+// unstructuredReaction serves the actions of DynamicClient from the tracker, converting the objects
+// to unstructured ones and filtering the listed objects by field selector like the API server does.
+func (t *fakeTracker) unstructuredReaction(action clienttesting.Action) (bool, runtime.Object, error) {
+	handled, obj, err := clienttesting.ObjectReaction(t)(action)
+	if err != nil || obj == nil {
+		return handled, obj, err
+	}
+	u, err := toUnstructured(obj)
+	if err != nil {
+		return true, nil, err
+	}
+	if list, ok := u.(*unstructured.UnstructuredList); ok {
+		if listAction, ok := action.(clienttesting.ListAction); ok {
+			list.Items = filterItems(list.Items, listAction.GetListRestrictions().Fields)
+		}
+	}
+	return true, u, nil
+}
+
+// watchReaction serves the watches from the tracker, filtering the events by the label and field selectors.
+// The objects of the events are converted to unstructured ones for DynamicClient.
+func (t *fakeTracker) watchReaction(toUnstructuredObjects bool) clienttesting.WatchReactionFunc {
+	return func(action clienttesting.Action) (bool, watch.Interface, error) {
+		watcher, err := t.Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return true, nil, err
+		}
+		var restrictions clienttesting.WatchRestrictions
+		if watchAction, ok := action.(clienttesting.WatchAction); ok {
+			restrictions = watchAction.GetWatchRestrictions()
+		}
+
+		return true, watch.Filter(watcher, func(event watch.Event) (watch.Event, bool) {
+			accessor, err := meta.Accessor(event.Object)
+			if err != nil {
+				return event, true
+			}
+			if !matches(accessor, restrictions.Labels, restrictions.Fields) {
+				return event, false
+			}
+			if toUnstructuredObjects {
+				if u, err := toUnstructured(event.Object); err == nil {
+					event.Object = u
+				}
+			}
+			return event, true
+		}), nil
+	}
+}
+
+// matches tells whether the object is selected by the label and field (metadata.name and metadata.namespace) selectors.
+func matches(obj metav1.Object, labelSelector labels.Selector, fieldSelector fields.Selector) bool {
+	if labelSelector != nil && !labelSelector.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+	return fieldSelector == nil || fieldSelector.Matches(fields.Set{"metadata.name": obj.GetName(), "metadata.namespace": obj.GetNamespace()})
+}
+
+func filterItems(items []unstructured.Unstructured, fieldSelector fields.Selector) []unstructured.Unstructured {
+	filtered := make([]unstructured.Unstructured, 0, len(items))
+	for i := range items {
+		if matches(&items[i], nil, fieldSelector) {
+			filtered = append(filtered, items[i])
+		}
+	}
+	return filtered
+}
+
+// toTyped converts an unstructured object of a kind known to the scheme to its Go type.
+func toTyped(obj runtime.Object) (runtime.Object, error) {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return obj, nil
+	}
+	typed, err := scheme.New(u.GroupVersionKind())
+	if err != nil {
+		return obj, nil
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), typed); err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s/%s: %w", u.GetKind(), u.GetNamespace(), u.GetName(), err)
+	}
+	return typed, nil
+}
+
+// toUnstructured converts a typed object or list to the unstructured form DynamicClient returns.
+func toUnstructured(obj runtime.Object) (runtime.Object, error) {
+	switch obj.(type) {
+	case *unstructured.Unstructured, *unstructured.UnstructuredList:
+		return obj, nil
+	}
+
+	gvks, _, err := scheme.ObjectKinds(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+		list := &unstructured.UnstructuredList{Object: map[string]interface{}{}}
+		list.SetGroupVersionKind(gvks[0])
+		if listMeta, err := meta.ListAccessor(obj); err == nil {
+			list.SetResourceVersion(listMeta.GetResourceVersion())
+		}
+		for _, item := range items {
+			u, err := toUnstructured(item)
+			if err != nil {
+				return nil, err
+			}
+			list.Items = append(list.Items, *u.(*unstructured.Unstructured))
+		}
+		return list, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvks[0])
+	return u, nil
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"fmt"
+	"time"
+
+	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
+	intgteststat "github.com/konflux-ci/integration-service/pkg/integrationteststatus"
+	tekton "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Status progression helpers simulate what the controllers would do to the objects on a cluster.
+// They are meant for clients created by NewFakeKubernetesClient, the given object is refreshed
+// from KubeRest before it is updated and holds the updated object afterwards. The update is seen
+// by every client of the fake, so it moves forward the helpers waiting for the object.
+
+const (
+	snapshotTestsStatusAnnotation   = "test.appstudio.openshift.io/status"
+	snapshotTestSucceededCondition  = "AppStudioTestSucceeded"
+	snapshotTestSucceededReasonPass = "Passed"
+	snapshotTestSucceededReasonFail = "Failed"
+)
+
+// SimulatePipelineRunStarted marks the PipelineRun as running.
+func (c *CustomClient) SimulatePipelineRunStarted(pr *tekton.PipelineRun) error {
+	return c.setPipelineRunCondition(pr, corev1.ConditionUnknown, tekton.PipelineRunReasonRunning.String(), "Tasks Completed: 0, Incomplete: 1")
+}
+
+// SimulatePipelineRunSucceeded marks the PipelineRun as successfully completed.
+func (c *CustomClient) SimulatePipelineRunSucceeded(pr *tekton.PipelineRun) error {
+	return c.setPipelineRunCondition(pr, corev1.ConditionTrue, tekton.PipelineRunReasonSuccessful.String(), "Tasks Completed: 1, Skipped: 0")
+}
+
+// SimulatePipelineRunFailed marks the PipelineRun as failed with the given message.
+func (c *CustomClient) SimulatePipelineRunFailed(pr *tekton.PipelineRun, message string) error {
+	return c.setPipelineRunCondition(pr, corev1.ConditionFalse, tekton.PipelineRunReasonFailed.String(), message)
+}
+
+func (c *CustomClient) setPipelineRunCondition(pr *tekton.PipelineRun, status corev1.ConditionStatus, reason, message string) error {
+	if err := c.KubeRest().Get(c.Context(), crclient.ObjectKeyFromObject(pr), pr); err != nil {
+		return fmt.Errorf("failed to get PipelineRun %s/%s: %w", pr.Namespace, pr.Name, err)
+	}
+
+	now := metav1.NewTime(time.Now())
+	if pr.Status.StartTime == nil {
+		pr.Status.StartTime = &now
+	}
+	if status != corev1.ConditionUnknown {
+		pr.Status.CompletionTime = &now
+	}
+	pr.Status.SetCondition(&apis.Condition{
+		Type:    apis.ConditionSucceeded,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+
+	if err := c.KubeRest().Status().Update(c.Context(), pr); err != nil {
+		return fmt.Errorf("failed to update status of PipelineRun %s/%s: %w", pr.Namespace, pr.Name, err)
+	}
+	return nil
+}
+
+// SimulateSnapshotTestStatus records the status of the integration test scenario in the test status annotation of the Snapshot.
+func (c *CustomClient) SimulateSnapshotTestStatus(snapshot *appstudioApi.Snapshot, scenarioName string, status intgteststat.IntegrationTestStatus, details string) error {
+	if err := c.KubeRest().Get(c.Context(), crclient.ObjectKeyFromObject(snapshot), snapshot); err != nil {
+		return fmt.Errorf("failed to get Snapshot %s/%s: %w", snapshot.Namespace, snapshot.Name, err)
+	}
+
+	statuses, err := intgteststat.NewSnapshotIntegrationTestStatuses(snapshot.GetAnnotations()[snapshotTestsStatusAnnotation])
+	if err != nil {
+		return fmt.Errorf("failed to parse test status annotation of Snapshot %s/%s: %w", snapshot.Namespace, snapshot.Name, err)
+	}
+	statuses.UpdateTestStatusIfChanged(scenarioName, status, details)
+	value, err := statuses.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	if snapshot.Annotations == nil {
+		snapshot.Annotations = map[string]string{}
+	}
+	snapshot.Annotations[snapshotTestsStatusAnnotation] = string(value)
+
+	if err := c.KubeRest().Update(c.Context(), snapshot); err != nil {
+		return fmt.Errorf("failed to update Snapshot %s/%s: %w", snapshot.Namespace, snapshot.Name, err)
+	}
+	return nil
+}
+
+// SimulateSnapshotTestsFinished sets the condition telling whether all the integration tests of the Snapshot passed.
+func (c *CustomClient) SimulateSnapshotTestsFinished(snapshot *appstudioApi.Snapshot, passed bool) error {
+	if err := c.KubeRest().Get(c.Context(), crclient.ObjectKeyFromObject(snapshot), snapshot); err != nil {
+		return fmt.Errorf("failed to get Snapshot %s/%s: %w", snapshot.Namespace, snapshot.Name, err)
+	}
+
+	condition := metav1.Condition{
+		Type:    snapshotTestSucceededCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  snapshotTestSucceededReasonPass,
+		Message: "All Integration Pipeline tests passed",
+	}
+	if !passed {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = snapshotTestSucceededReasonFail
+		condition.Message = "Some Integration pipeline tests failed"
+	}
+	meta.SetStatusCondition(&snapshot.Status.Conditions, condition)
+
+	if err := c.KubeRest().Status().Update(c.Context(), snapshot); err != nil {
+		return fmt.Errorf("failed to update status of Snapshot %s/%s: %w", snapshot.Namespace, snapshot.Name, err)
+	}
+	return nil
+}
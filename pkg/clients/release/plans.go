@@ -1,9 +1,9 @@
 package release
 
 import (
-	"context"
 	"strconv"
 
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	tektonutils "github.com/konflux-ci/release-service/tekton/utils"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -40,7 +40,12 @@ func (r *ReleaseController) CreateReleasePlan(name, namespace, application, targ
 		releasePlan.Labels[releaseMetadata.AutoReleaseLabel] = "false"
 	}
 
-	return releasePlan, r.KubeRest().Create(context.Background(), releasePlan)
+	if err := r.KubeRest().Create(r.Context(), releasePlan); err != nil {
+		return releasePlan, err
+	}
+	r.TrackObject(releasePlan, tracker.OrderReleasePlan)
+
+	return releasePlan, nil
 }
 
 // CreateReleasePlanAdmission creates a new ReleasePlanAdmission using the given parameters.
@@ -66,14 +71,19 @@ func (r *ReleaseController) CreateReleasePlanAdmission(name, namespace, environm
 		},
 	}
 
-	return releasePlanAdmission, r.KubeRest().Create(context.Background(), releasePlanAdmission)
+	if err := r.KubeRest().Create(r.Context(), releasePlanAdmission); err != nil {
+		return releasePlanAdmission, err
+	}
+	r.TrackObject(releasePlanAdmission, tracker.OrderReleasePlanAdmission)
+
+	return releasePlanAdmission, nil
 }
 
 // GetReleasePlan returns the ReleasePlan with the given name in the given namespace.
 func (r *ReleaseController) GetReleasePlan(name, namespace string) (*releaseApi.ReleasePlan, error) {
 	releasePlan := &releaseApi.ReleasePlan{}
 
-	err := r.KubeRest().Get(context.Background(), types.NamespacedName{
+	err := r.KubeRest().Get(r.Context(), types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}, releasePlan)
@@ -85,7 +95,7 @@ func (r *ReleaseController) GetReleasePlan(name, namespace string) (*releaseApi.
 func (r *ReleaseController) GetReleasePlanAdmission(name, namespace string) (*releaseApi.ReleasePlanAdmission, error) {
 	releasePlanAdmission := &releaseApi.ReleasePlanAdmission{}
 
-	err := r.KubeRest().Get(context.Background(), types.NamespacedName{
+	err := r.KubeRest().Get(r.Context(), types.NamespacedName{
 		Name:      name,
 		Namespace: namespace,
 	}, releasePlanAdmission)
@@ -101,10 +111,13 @@ func (r *ReleaseController) DeleteReleasePlan(name, namespace string, failOnNotF
 			Namespace: namespace,
 		},
 	}
-	err := r.KubeRest().Delete(context.Background(), releasePlan)
+	err := r.KubeRest().Delete(r.Context(), releasePlan)
 	if err != nil && !failOnNotFound && k8sErrors.IsNotFound(err) {
 		err = nil
 	}
+	if err == nil {
+		r.UntrackObject(releasePlan)
+	}
 	return err
 }
 
@@ -118,9 +131,12 @@ func (r *ReleaseController) DeleteReleasePlanAdmission(name, namespace string, f
 			Namespace: namespace,
 		},
 	}
-	err := r.KubeRest().Delete(context.Background(), &releasePlanAdmission)
+	err := r.KubeRest().Delete(r.Context(), &releasePlanAdmission)
 	if err != nil && !failOnNotFound && k8sErrors.IsNotFound(err) {
 		err = nil
 	}
+	if err == nil {
+		r.UntrackObject(&releasePlanAdmission)
+	}
 	return err
 }
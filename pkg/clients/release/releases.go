@@ -1,13 +1,14 @@
 package release
 
 import (
-	"context"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/logs"
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
 	"github.com/konflux-ci/e2e-tests/pkg/utils/tekton"
 	releaseApi "github.com/konflux-ci/release-service/api/v1alpha1"
 	ginkgo "github.com/onsi/ginkgo/v2"
@@ -15,8 +16,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbac "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"knative.dev/pkg/apis"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -35,7 +36,12 @@ func (r *ReleaseController) CreateRelease(name, namespace, snapshot, releasePlan
 		},
 	}
 
-	return release, r.KubeRest().Create(context.Background(), release)
+	if err := r.KubeRest().Create(r.Context(), release); err != nil {
+		return release, err
+	}
+	r.TrackObject(release, tracker.OrderRelease)
+
+	return release, nil
 }
 
 // CreateReleasePipelineRoleBindingForServiceAccount creates a RoleBinding for the passed serviceAccount to enable
@@ -59,7 +65,7 @@ func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccount(na
 			},
 		},
 	}
-	err := r.KubeRest().Create(context.Background(), roleBinding)
+	err := r.KubeRest().Create(r.Context(), roleBinding)
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +75,7 @@ func (r *ReleaseController) CreateReleasePipelineRoleBindingForServiceAccount(na
 // GetRelease returns the release with in the given namespace.
 // It can find a Release CR based on provided name or a name of an associated Snapshot
 func (r *ReleaseController) GetRelease(releaseName, snapshotName, namespace string) (*releaseApi.Release, error) {
-	ctx := context.Background()
+	ctx := r.Context()
 	if len(releaseName) > 0 {
 		release := &releaseApi.Release{}
 		err := r.KubeRest().Get(ctx, types.NamespacedName{Name: releaseName, Namespace: namespace}, release)
@@ -82,7 +88,7 @@ func (r *ReleaseController) GetRelease(releaseName, snapshotName, namespace stri
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
 	}
-	if err := r.KubeRest().List(context.Background(), releaseList, opts...); err != nil {
+	if err := r.KubeRest().List(r.Context(), releaseList, opts...); err != nil {
 		return nil, err
 	}
 	for _, r := range releaseList.Items {
@@ -99,7 +105,7 @@ func (r *ReleaseController) GetReleases(namespace string) (*releaseApi.ReleaseLi
 	opts := []client.ListOption{
 		client.InNamespace(namespace),
 	}
-	err := r.KubeRest().List(context.Background(), releaseList, opts...)
+	err := r.KubeRest().List(r.Context(), releaseList, opts...)
 
 	return releaseList, err
 }
@@ -153,18 +159,37 @@ func (r *ReleaseController) GetFirstReleaseInNamespace(namespace string) (*relea
 	return &releaseList.Items[0], nil
 }
 
+var pipelineRunsResource = pipeline.SchemeGroupVersion.WithResource("pipelineruns")
+
+// releasePipelineRunLabels returns the labels of the Release PipelineRuns referencing the given release.
+func releasePipelineRunLabels(releaseName, releaseNamespace string) map[string]string {
+	return map[string]string{
+		"release.appstudio.openshift.io/name":      releaseName,
+		"release.appstudio.openshift.io/namespace": releaseNamespace,
+	}
+}
+
+// waitForReleasePipelineRun waits until the newest Release PipelineRun of the given release in managedNamespace satisfies cond.
+func (r *ReleaseController) waitForReleasePipelineRun(release *releaseApi.Release, managedNamespace string, pollInterval, timeout time.Duration, cond func(pipelineRun *pipeline.PipelineRun) (bool, error)) (*pipeline.PipelineRun, error) {
+	opts := utils.WatchOptions{
+		Namespace:     managedNamespace,
+		LabelSelector: labels.SelectorFromSet(releasePipelineRunLabels(release.GetName(), release.GetNamespace())).String(),
+		Newest:        true,
+		Timeout:       timeout,
+		PollInterval:  pollInterval,
+	}
+	return utils.WaitForObject(r.Context(), r.DynamicClient(), pipelineRunsResource, opts, cond)
+}
+
 // GetPipelineRunInNamespace returns the Release PipelineRun referencing the given release.
 func (r *ReleaseController) GetPipelineRunInNamespace(namespace, releaseName, releaseNamespace string) (*pipeline.PipelineRun, error) {
 	pipelineRuns := &pipeline.PipelineRunList{}
 	opts := []client.ListOption{
-		client.MatchingLabels{
-			"release.appstudio.openshift.io/name":      releaseName,
-			"release.appstudio.openshift.io/namespace": releaseNamespace,
-		},
+		client.MatchingLabels(releasePipelineRunLabels(releaseName, releaseNamespace)),
 		client.InNamespace(namespace),
 	}
 
-	err := r.KubeRest().List(context.Background(), pipelineRuns, opts...)
+	err := r.KubeRest().List(r.Context(), pipelineRuns, opts...)
 
 	if err == nil && len(pipelineRuns.Items) > 1 {
 		return &pipelineRuns.Items[0], fmt.Errorf("found multiple PipelineRun in managed namespace '%s' for a release '%s' in '%s' namespace", namespace, releaseName, releaseNamespace)
@@ -184,33 +209,19 @@ func (r *ReleaseController) GetPipelineRunInNamespace(namespace, releaseName, re
 // WaitForReleasePipelineToGetStarted wait for given release pipeline to get started.
 // In case of failure, this function retries till it gets timed out.
 func (r *ReleaseController) WaitForReleasePipelineToGetStarted(release *releaseApi.Release, managedNamespace string) (*pipeline.PipelineRun, error) {
-	var releasePipelinerun *pipeline.PipelineRun
-
-	err := wait.PollUntilContextTimeout(context.Background(), time.Second*2, time.Minute*5, true, func(ctx context.Context) (done bool, err error) {
-		releasePipelinerun, err = r.GetPipelineRunInNamespace(managedNamespace, release.GetName(), release.GetNamespace())
-		if err != nil {
-			ginkgo.GinkgoWriter.Println("PipelineRun has not been created yet for release %s/%s", release.GetNamespace(), release.GetName())
-			return false, nil
-		}
-		if !releasePipelinerun.HasStarted() {
-			ginkgo.GinkgoWriter.Println("pipelinerun %s/%s hasn't started yet", releasePipelinerun.GetNamespace(), releasePipelinerun.GetName())
+	return r.waitForReleasePipelineRun(release, managedNamespace, time.Second*2, time.Minute*5, func(pipelineRun *pipeline.PipelineRun) (bool, error) {
+		if !pipelineRun.HasStarted() {
+			ginkgo.GinkgoWriter.Printf("pipelinerun %s/%s hasn't started yet\n", pipelineRun.GetNamespace(), pipelineRun.GetName())
 			return false, nil
 		}
 		return true, nil
 	})
-
-	return releasePipelinerun, err
 }
 
 // WaitForReleasePipelineToBeFinished wait for given release pipeline to finish.
 // It exposes the error message from the failed task to the end user when the pipelineRun failed.
 func (r *ReleaseController) WaitForReleasePipelineToBeFinished(release *releaseApi.Release, managedNamespace string) error {
-	return wait.PollUntilContextTimeout(context.Background(), constants.PipelineRunPollingInterval, 30*time.Minute, true, func(ctx context.Context) (done bool, err error) {
-		pipelineRun, err := r.GetPipelineRunInNamespace(managedNamespace, release.GetName(), release.GetNamespace())
-		if err != nil {
-			ginkgo.GinkgoWriter.Println("PipelineRun has not been created yet for release %s/%s", release.GetNamespace(), release.GetName())
-			return false, nil
-		}
+	_, err := r.waitForReleasePipelineRun(release, managedNamespace, constants.PipelineRunPollingInterval, 30*time.Minute, func(pipelineRun *pipeline.PipelineRun) (bool, error) {
 		for _, condition := range pipelineRun.Status.Conditions {
 			ginkgo.GinkgoWriter.Printf("PipelineRun %s reason: %s\n", pipelineRun.Name, condition.Reason)
 
@@ -227,4 +238,5 @@ func (r *ReleaseController) WaitForReleasePipelineToBeFinished(release *releaseA
 		}
 		return false, nil
 	})
+	return err
 }
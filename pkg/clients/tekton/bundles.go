@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"fmt"
 
 	"gopkg.in/yaml.v2"
@@ -27,7 +26,7 @@ func (t *TektonController) NewBundles() (*Bundles, error) {
 	}
 	bundles := &Bundles{}
 	configMap := &corev1.ConfigMap{}
-	err := t.KubeRest().Get(context.Background(), namespacedName, configMap)
+	err := t.KubeRest().Get(t.Context(), namespacedName, configMap)
 	if err != nil {
 		return nil, err
 	}
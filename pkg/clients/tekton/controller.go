@@ -1,6 +1,8 @@
 package tekton
 
 import (
+	"context"
+
 	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
 )
 
@@ -15,3 +17,8 @@ func NewSuiteController(kube *kubeCl.CustomClient) *TektonController {
 		kube,
 	}
 }
+
+// WithContext returns a copy of the controller whose API calls and polling are cancelled once ctx is done.
+func (t *TektonController) WithContext(ctx context.Context) *TektonController {
+	return &TektonController{t.CustomClient.WithContext(ctx)}
+}
@@ -12,7 +12,7 @@ import (
 
 // AwaitAttestationAndSignature awaits attestation and signature.
 func (t *TektonController) AwaitAttestationAndSignature(image string, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.Background(), time.Second, timeout, true, func(ctx context.Context) (done bool, err error) {
+	return wait.PollUntilContextTimeout(t.Context(), time.Second, timeout, true, func(ctx context.Context) (done bool, err error) {
 		if _, err := tekton.FindCosignResultsForImage(image); err != nil {
 			g.GinkgoWriter.Printf("failed to get cosign result for image %s: %+v\n", image, err)
 			return false, nil
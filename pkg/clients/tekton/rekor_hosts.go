@@ -1,8 +1,6 @@
 package tekton
 
 import (
-	"context"
-
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,7 +12,7 @@ func (t *TektonController) GetRekorHost() (rekorHost string, err error) {
 	}
 
 	api := t.KubeInterface().CoreV1().ConfigMaps(namespace)
-	ctx := context.Background()
+	ctx := t.Context()
 
 	cm, err := api.Get(ctx, "chains-config", metav1.GetOptions{})
 	if err != nil {
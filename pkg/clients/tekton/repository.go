@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"fmt"
 
 	pacv1alpha1 "github.com/openshift-pipelines/pipelines-as-code/pkg/apis/pipelinesascode/v1alpha1"
@@ -12,7 +11,7 @@ import (
 // given component. Build-service always sets the Component as an ownerReference
 // on the Repository CR regardless of the CR naming scheme.
 func (t *TektonController) GetRepositoryParams(componentName, namespace string) ([]pacv1alpha1.Params, error) {
-	ctx := context.Background()
+	ctx := t.Context()
 	repositoryList := &pacv1alpha1.RepositoryList{}
 	if err := t.KubeRest().List(ctx, repositoryList, &rclient.ListOptions{Namespace: namespace}); err != nil {
 		return nil, fmt.Errorf("list PaC repositories in namespace %s: %w", namespace, err)
@@ -1,8 +1,6 @@
 package tekton
 
 import (
-	"context"
-
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -11,7 +9,7 @@ import (
 // CreateOrUpdateSigningSecret creates a signing secret if it doesn't exist, otherwise updates the existing one.
 func (t *TektonController) CreateOrUpdateSigningSecret(publicKey []byte, name, namespace string) (err error) {
 	api := t.KubeInterface().CoreV1().Secrets(namespace)
-	ctx := context.Background()
+	ctx := t.Context()
 
 	expectedSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{Name: name},
@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"os/exec"
 
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
@@ -12,7 +11,7 @@ import (
 
 // Create a tekton task and return the task or error.
 func (t *TektonController) CreateTask(task *pipeline.Task, ns string) (*pipeline.Task, error) {
-	return t.PipelineClient().TektonV1().Tasks(ns).Create(context.Background(), task, metav1.CreateOptions{})
+	return t.PipelineClient().TektonV1().Tasks(ns).Create(t.Context(), task, metav1.CreateOptions{})
 }
 
 // CreateSkopeoCopyTask creates a skopeo copy task in the given namespace.
@@ -41,7 +40,7 @@ func (t *TektonController) GetTask(name, namespace string) (*pipeline.Task, erro
 			Namespace: namespace,
 		},
 	}
-	err := t.KubeRest().Get(context.Background(), namespacedName, &task)
+	err := t.KubeRest().Get(t.Context(), namespacedName, &task)
 	if err != nil {
 		return nil, err
 	}
@@ -50,5 +49,5 @@ func (t *TektonController) GetTask(name, namespace string) (*pipeline.Task, erro
 
 // DeleteAllTasksInASpecificNamespace removes all Tasks from a given repository. Useful when creating a lot of resources and wanting to remove all of them.
 func (t *TektonController) DeleteAllTasksInASpecificNamespace(namespace string) error {
-	return t.KubeRest().DeleteAllOf(context.Background(), &pipeline.Task{}, crclient.InNamespace(namespace))
+	return t.KubeRest().DeleteAllOf(t.Context(), &pipeline.Task{}, crclient.InNamespace(namespace))
 }
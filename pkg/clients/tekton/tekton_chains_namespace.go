@@ -1,7 +1,6 @@
 package tekton
 
 import (
-	"context"
 	"fmt"
 	"sync"
 
@@ -27,7 +26,7 @@ func (t *TektonController) GetTektonChainsNamespace() (string, error) {
 	resolvedChainsOnce.Do(func() {
 		for _, ns := range tektonChainsNamespaceCandidates {
 			pods, err := t.KubeInterface().CoreV1().Pods(ns).List(
-				context.Background(), metav1.ListOptions{
+				t.Context(), metav1.ListOptions{
 					LabelSelector: "app=tekton-chains-controller",
 				})
 			if err != nil {
@@ -0,0 +1,183 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRemovalTimeout is the time given to the removal of a single resource during the teardown.
+const DefaultRemovalTimeout = time.Minute * 5
+
+// Order is the position of a kind of resource in the teardown, resources of a lower order are removed first.
+// The dependents of a resource are removed before the resource itself, i.e. the Releases before the ReleasePlans,
+// the Components before the Application and the git resources the Components are built from.
+type Order int
+
+const (
+	OrderRelease Order = iota
+	OrderPipelineRun
+	OrderSnapshot
+	OrderReleasePlan
+	OrderReleasePlanAdmission
+	OrderIntegrationTestScenario
+	OrderImageRepository
+	OrderComponent
+	OrderApplication
+	OrderGitPullRequest
+	OrderGitBranch
+	OrderGitWebhooks
+	OrderGitRepository
+	OrderNamespace
+)
+
+// Resource is an object created during a test session. Namespace is the namespace of a kubernetes
+// object or the repository of a git resource, it is empty for cluster scoped objects and repositories.
+type Resource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Order     Order
+
+	remove func(ctx context.Context) error
+}
+
+func (r Resource) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s %s", r.Kind, r.Name)
+	}
+
+	return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+}
+
+func (r Resource) is(kind, namespace, name string) bool {
+	return r.Kind == kind && r.Namespace == namespace && r.Name == name
+}
+
+// Leftover is a resource which could not be removed during the teardown.
+type Leftover struct {
+	Resource Resource
+	Err      error
+}
+
+// CleanupError lists the resources which could not be removed by Tracker.Cleanup.
+type CleanupError struct {
+	Leftovers []Leftover
+}
+
+func (e *CleanupError) Error() string {
+	var lines []string
+	for _, l := range e.Leftovers {
+		lines = append(lines, fmt.Sprintf("%s: %+v", l.Resource, l.Err))
+	}
+
+	return fmt.Sprintf("failed to remove %d resource(s):\n%s", len(e.Leftovers), strings.Join(lines, "\n"))
+}
+
+// Tracker records the resources created during a test session so they can be removed at its end.
+// All the methods of a nil Tracker are no-ops, so the clients do not need to check whether their
+// resources are tracked or not.
+type Tracker struct {
+	mu        sync.Mutex
+	resources []Resource
+	// RemovalTimeout bounds the removal of every single resource, DefaultRemovalTimeout is used when it is 0.
+	RemovalTimeout time.Duration
+}
+
+// New returns an empty Tracker.
+func New() *Tracker {
+	return &Tracker{RemovalTimeout: DefaultRemovalTimeout}
+}
+
+// Track records a resource and the function which removes it. The function is expected to succeed
+// when the resource does not exist anymore. Tracking an already tracked resource replaces its removal function.
+func (t *Tracker) Track(kind, namespace, name string, order Order, remove func(ctx context.Context) error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, r := range t.resources {
+		if r.is(kind, namespace, name) {
+			t.resources[i].Order = order
+			t.resources[i].remove = remove
+			return
+		}
+	}
+	t.resources = append(t.resources, Resource{Kind: kind, Namespace: namespace, Name: name, Order: order, remove: remove})
+}
+
+// Untrack forgets a resource, i.e. when a test removes it by itself.
+func (t *Tracker) Untrack(kind, namespace, name string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, r := range t.resources {
+		if r.is(kind, namespace, name) {
+			t.resources = append(t.resources[:i], t.resources[i+1:]...)
+			return
+		}
+	}
+}
+
+// Resources returns the tracked resources in the order they are removed in: by their Order, the resources
+// of the same Order are removed in the reverse order of their creation.
+func (t *Tracker) Resources() []Resource {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	resources := make([]Resource, len(t.resources))
+	for i, r := range t.resources {
+		resources[len(resources)-1-i] = r
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].Order < resources[j].Order
+	})
+
+	return resources
+}
+
+// Cleanup removes all the tracked resources in dependency order. A resource which fails to be removed
+// does not stop the teardown, it is kept tracked and reported in the returned *CleanupError.
+func (t *Tracker) Cleanup(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+
+	timeout := t.RemovalTimeout
+	if timeout == 0 {
+		timeout = DefaultRemovalTimeout
+	}
+
+	var leftovers []Leftover
+	for _, r := range t.Resources() {
+		if err := ctx.Err(); err != nil {
+			leftovers = append(leftovers, Leftover{Resource: r, Err: err})
+			continue
+		}
+		rctx, cancel := context.WithTimeout(ctx, timeout)
+		err := r.remove(rctx)
+		cancel()
+		if err != nil {
+			leftovers = append(leftovers, Leftover{Resource: r, Err: err})
+			continue
+		}
+		t.Untrack(r.Kind, r.Namespace, r.Name)
+	}
+
+	if len(leftovers) > 0 {
+		return &CleanupError{Leftovers: leftovers}
+	}
+
+	return nil
+}
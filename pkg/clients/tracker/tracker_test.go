@@ -0,0 +1,64 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanupOrder(t *testing.T) {
+	var removed []string
+	remove := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			removed = append(removed, name)
+			return nil
+		}
+	}
+
+	tr := New()
+	tr.Track("Namespace", "", "ns", OrderNamespace, remove("ns"))
+	tr.Track("Application", "ns", "app", OrderApplication, remove("app"))
+	tr.Track("Component", "ns", "first", OrderComponent, remove("first"))
+	tr.Track("Component", "ns", "second", OrderComponent, remove("second"))
+	tr.Track("Release", "ns", "release", OrderRelease, remove("release"))
+	tr.Track("Component", "ns", "untracked", OrderComponent, remove("untracked"))
+	tr.Untrack("Component", "ns", "untracked")
+
+	assert.NoError(t, tr.Cleanup(context.Background()))
+	assert.Equal(t, []string{"release", "second", "first", "app", "ns"}, removed)
+	assert.Empty(t, tr.Resources())
+}
+
+func TestCleanupReportsLeftovers(t *testing.T) {
+	tr := New()
+	tr.Track("Component", "ns", "stuck", OrderComponent, func(ctx context.Context) error {
+		return errors.New("finalizer not removed")
+	})
+	tr.Track("Application", "ns", "app", OrderApplication, func(ctx context.Context) error {
+		return nil
+	})
+
+	err := tr.Cleanup(context.Background())
+	var cleanupErr *CleanupError
+	assert.ErrorAs(t, err, &cleanupErr)
+	assert.Len(t, cleanupErr.Leftovers, 1)
+	assert.Equal(t, "Component ns/stuck", cleanupErr.Leftovers[0].Resource.String())
+	assert.Contains(t, err.Error(), "finalizer not removed")
+	assert.Len(t, tr.Resources(), 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = tr.Cleanup(ctx)
+	assert.ErrorAs(t, err, &cleanupErr)
+	assert.ErrorIs(t, cleanupErr.Leftovers[0].Err, context.Canceled)
+}
+
+func TestNilTracker(t *testing.T) {
+	var tr *Tracker
+	tr.Track("Component", "ns", "c", OrderComponent, nil)
+	tr.Untrack("Component", "ns", "c")
+	assert.Empty(t, tr.Resources())
+	assert.NoError(t, tr.Cleanup(context.Background()))
+}
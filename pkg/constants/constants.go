@@ -51,6 +51,9 @@ const (
 	// E2E test namespace where the app and component CRs will be created
 	E2E_APPLICATIONS_NAMESPACE_ENV string = "E2E_APPLICATIONS_NAMESPACE"
 
+	// If set to "true", framework.Cleanup keeps the resources created by a failed spec for debugging
+	E2E_KEEP_RESOURCES_ON_FAILURE_ENV string = "E2E_KEEP_RESOURCES_ON_FAILURE"
+
 	// Skip checking "ApplicationServiceGHTokenSecrName" secret
 	SKIP_HAS_SECRET_CHECK_ENV string = "SKIP_HAS_SECRET_CHECK"
 
@@ -147,6 +150,19 @@ const (
 	// The Codeberg base URL used to run e2e tests against (defaults to https://codeberg.org)
 	CODEBERG_API_URL_ENV string = "CODEBERG_API_URL" // #nosec
 
+	// A Bitbucket bot token is required to run tests against bitbucket.org. It can be a workspace/repository access token,
+	// or an app password when BITBUCKET_USERNAME is set as well.
+	BITBUCKET_BOT_TOKEN_ENV string = "BITBUCKET_BOT_TOKEN" // #nosec
+
+	// The Bitbucket user the app password in BITBUCKET_BOT_TOKEN belongs to
+	BITBUCKET_USERNAME_ENV string = "BITBUCKET_USERNAME"
+
+	// The Bitbucket workspace which owns the test repositories
+	BITBUCKET_QE_WORKSPACE_ENV string = "BITBUCKET_QE_WORKSPACE"
+
+	// The Bitbucket API URL used to run e2e tests against (defaults to https://api.bitbucket.org/2.0)
+	BITBUCKET_API_URL_ENV string = "BITBUCKET_API_URL" // #nosec
+
 	// The smee.io channel URL for forwarding webhooks to the test cluster
 	SMEE_CHANNEL_ENV string = "SMEE_CHANNEL"
 
@@ -197,6 +213,9 @@ const (
 	DefaultCodebergAPIURL = "https://codeberg.org"
 	DefaultCodebergQEOrg  = "konflux-qe"
 
+	DefaultBitbucketAPIURL      = "https://api.bitbucket.org/2.0"
+	DefaultBitbucketQEWorkspace = "konflux-qe"
+
 	RegistryAuthSecretName = "redhat-appstudio-registry-pull-secret"
 	ComponentSecretName    = "comp-secret"
 
@@ -1,24 +1,41 @@
 package framework
 
 import (
+	"context"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/konflux-ci/e2e-tests/pkg/logs"
+	"github.com/konflux-ci/e2e-tests/pkg/utils/tekton"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 )
 
-func ReportFailure(f **Framework) func() {
-	namespaces := map[string]string{
-		"Build Service":       "build-service",
-		"JVM Build Service":   "jvm-build-service",
-		"Application Service": "application-service",
-		"Image Controller":    "image-controller"}
+// DefaultControllerNamespaces are the controller namespaces whose logs are collected
+// when a spec fails and ReportFailure is not given namespaces of its own.
+var DefaultControllerNamespaces = []string{
+	"build-service",
+	"jvm-build-service",
+	"application-service",
+	"image-controller",
+	"integration-service",
+	"release-service",
+}
+
+// ReportFailure returns a function storing a diagnostics bundle of the current spec when it failed.
+// The bundle holds resources, events and failed PipelineRun logs of the framework user namespace
+// together with logs and events of the controller namespaces. Suites can pass their own
+// controller namespaces, DefaultControllerNamespaces are used otherwise.
+func ReportFailure(f **Framework, controllerNamespaces ...string) func() {
+	if len(controllerNamespaces) == 0 {
+		controllerNamespaces = DefaultControllerNamespaces
+	}
 
 	return func() {
-		if !ginkgo.CurrentSpecReport().Failed() {
+		report := ginkgo.CurrentSpecReport()
+		if !report.Failed() {
 			return
 		}
 
@@ -31,27 +48,25 @@ func ReportFailure(f **Framework) func() {
 			ginkgo.GinkgoWriter.Printf("failed to store test timing: %v\n", err)
 		}
 
-		allPodLogs := make(map[string][]byte)
-		for _, namespace := range namespaces {
-			podList, err := fwk.AsKubeAdmin.CommonController.ListAllPods(namespace)
-			if err != nil {
-				ginkgo.GinkgoWriter.Printf("failed to list pods in namespace %s: %v\n", namespace, err)
-				return
-			}
-
-			for _, pod := range podList.Items {
-				podLogs := fwk.AsKubeAdmin.CommonController.GetPodLogs(&pod)
+		kubeClient := fwk.AsKubeAdmin.CommonController.KubeInterface()
+		collector := &logs.DiagnosticsCollector{
+			KubeInterface: kubeClient,
+			DynamicClient: fwk.AsKubeAdmin.CommonController.DynamicClient(),
+			FailedPipelineRunLogs: func(pr *pipeline.PipelineRun) (string, error) {
+				return tekton.GetFailedPipelineRunLogs(fwk.AsKubeAdmin.CommonController.KubeRest(), kubeClient, pr)
+			},
+			FilterControllerLogs: func(log string) string {
+				return FilterLogs(log, report.StartTime)
+			},
+		}
 
-				for podName, log := range podLogs {
-					if filteredLogs := FilterLogs(string(log), ginkgo.CurrentSpecReport().StartTime); filteredLogs != "" {
-						allPodLogs[podName] = []byte(filteredLogs)
-					}
-				}
-			}
+		bundle := collector.Collect(context.Background(), report.FullText(), report.FailureMessage(), fwk.UserNamespace, controllerNamespaces)
+		for _, e := range bundle.Errors {
+			ginkgo.GinkgoWriter.Printf("diagnostics: %s\n", e)
 		}
 
-		if err := logs.StoreArtifacts(allPodLogs); err != nil {
-			ginkgo.GinkgoWriter.Printf("failed to store pod logs: %v\n", err)
+		if err := logs.StoreArtifacts(bundle.Artifacts); err != nil {
+			ginkgo.GinkgoWriter.Printf("failed to store diagnostics bundle: %v\n", err)
 		}
 	}
 }
@@ -0,0 +1,137 @@
+package framework
+
+import (
+	"testing"
+	"time"
+
+	appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
+	integrationv1beta2 "github.com/konflux-ci/integration-service/api/v1beta2"
+	releaseApi "github.com/konflux-ci/release-service/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tekton "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// simulateLater runs the status progression after the helper under test started waiting,
+// so the helper has to notice the change instead of finding the object already in its final state.
+func simulateLater(t *testing.T, simulate func() error) {
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		assert.NoError(t, simulate())
+	}()
+}
+
+func TestFakeHubWatchPipelineRunSucceeded(t *testing.T) {
+	pr := &tekton.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "user-ns"}}
+	hub, err := NewFakeControllerHub(pr)
+	require.NoError(t, err)
+
+	simulateLater(t, func() error {
+		if err := hub.TektonController.SimulatePipelineRunStarted(pr); err != nil {
+			return err
+		}
+		return hub.TektonController.SimulatePipelineRunSucceeded(pr)
+	})
+
+	assert.NoError(t, hub.TektonController.WatchPipelineRunSucceeded(pr.Name, pr.Namespace, 10))
+	assert.NoError(t, hub.TektonController.WatchPipelineRun(pr.Name, pr.Namespace, 10))
+}
+
+func TestFakeHubWaitForSnapshotToGetCreated(t *testing.T) {
+	hub, err := NewFakeControllerHub()
+	require.NoError(t, err)
+
+	snapshot := &appstudioApi.Snapshot{ObjectMeta: metav1.ObjectMeta{
+		Name:      "snapshot",
+		Namespace: "user-ns",
+		Labels:    map[string]string{"appstudio.openshift.io/build-pipelinerun": "build"},
+	}}
+	simulateLater(t, func() error {
+		return hub.IntegrationController.KubeRest().Create(hub.IntegrationController.Context(), snapshot)
+	})
+
+	created, err := hub.IntegrationController.WaitForSnapshotToGetCreated("", "build", "", "user-ns")
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot", created.Name)
+}
+
+func TestFakeHubWaitForIntegrationPipelineToBeFinished(t *testing.T) {
+	scenario := &integrationv1beta2.IntegrationTestScenario{ObjectMeta: metav1.ObjectMeta{Name: "scenario", Namespace: "user-ns"}}
+	snapshot := &appstudioApi.Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: "user-ns"}}
+	pr := &tekton.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+		Name:      "test",
+		Namespace: "user-ns",
+		Labels: map[string]string{
+			"pipelines.appstudio.openshift.io/type": "test",
+			"test.appstudio.openshift.io/scenario":  scenario.Name,
+			"appstudio.openshift.io/snapshot":       snapshot.Name,
+		},
+	}}
+	hub, err := NewFakeControllerHub(scenario, snapshot, pr)
+	require.NoError(t, err)
+
+	simulateLater(t, func() error { return hub.IntegrationController.SimulatePipelineRunStarted(pr) })
+	started, err := hub.IntegrationController.WaitForIntegrationPipelineToGetStarted(scenario.Name, snapshot.Name, "user-ns")
+	require.NoError(t, err)
+	assert.Equal(t, "test", started.Name)
+
+	simulateLater(t, func() error { return hub.IntegrationController.SimulatePipelineRunSucceeded(pr) })
+	assert.NoError(t, hub.IntegrationController.WaitForIntegrationPipelineToBeFinished(scenario, snapshot, "user-ns"))
+}
+
+func TestFakeHubWaitForReleasePipelineToBeFinished(t *testing.T) {
+	release := &releaseApi.Release{ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "user-ns"}}
+	pr := &tekton.PipelineRun{ObjectMeta: metav1.ObjectMeta{
+		Name:      "managed",
+		Namespace: "managed-ns",
+		Labels: map[string]string{
+			"release.appstudio.openshift.io/name":      release.Name,
+			"release.appstudio.openshift.io/namespace": release.Namespace,
+		},
+	}}
+	hub, err := NewFakeControllerHub(release, pr)
+	require.NoError(t, err)
+
+	simulateLater(t, func() error {
+		if err := hub.ReleaseController.SimulatePipelineRunStarted(pr); err != nil {
+			return err
+		}
+		return hub.ReleaseController.SimulatePipelineRunSucceeded(pr)
+	})
+
+	assert.NoError(t, hub.ReleaseController.WaitForReleasePipelineToBeFinished(release, "managed-ns"))
+}
+
+func TestFakeHubWatchPipelineRunSucceededFailsFast(t *testing.T) {
+	pr := &tekton.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "user-ns"}}
+	hub, err := NewFakeControllerHub(pr)
+	require.NoError(t, err)
+
+	simulateLater(t, func() error { return hub.TektonController.SimulatePipelineRunFailed(pr, "step failed") })
+
+	start := time.Now()
+	err = hub.TektonController.WatchPipelineRunSucceeded(pr.Name, pr.Namespace, 60)
+	assert.ErrorContains(t, err, "step failed")
+	assert.Less(t, time.Since(start), 30*time.Second)
+}
+
+func TestFakeHubWaitForIntegrationPipelineToBeFinishedEvaluatesNewestRun(t *testing.T) {
+	scenario := &integrationv1beta2.IntegrationTestScenario{ObjectMeta: metav1.ObjectMeta{Name: "scenario", Namespace: "user-ns"}}
+	snapshot := &appstudioApi.Snapshot{ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: "user-ns"}}
+	labels := map[string]string{
+		"pipelines.appstudio.openshift.io/type": "test",
+		"test.appstudio.openshift.io/scenario":  scenario.Name,
+		"appstudio.openshift.io/snapshot":       snapshot.Name,
+	}
+	created := time.Now().Add(-time.Hour)
+	failed := &tekton.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "test-first", Namespace: "user-ns", Labels: labels, CreationTimestamp: metav1.NewTime(created)}}
+	rerun := &tekton.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "test-rerun", Namespace: "user-ns", Labels: labels, CreationTimestamp: metav1.NewTime(created.Add(time.Minute))}}
+	hub, err := NewFakeControllerHub(scenario, snapshot, failed, rerun)
+	require.NoError(t, err)
+	require.NoError(t, hub.IntegrationController.SimulatePipelineRunFailed(failed, "test failed"))
+
+	simulateLater(t, func() error { return hub.IntegrationController.SimulatePipelineRunSucceeded(rerun) })
+
+	assert.NoError(t, hub.IntegrationController.WaitForIntegrationPipelineToBeFinished(scenario, snapshot, "user-ns"))
+}
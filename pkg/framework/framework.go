@@ -10,16 +10,19 @@ import (
 
 	ginkgo "github.com/onsi/ginkgo/v2"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 
 	"github.com/avast/retry-go/v4"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/common"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/has"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/imagecontroller"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/integration"
 	kubeCl "github.com/konflux-ci/e2e-tests/pkg/clients/kubernetes"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/release"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/tekton"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/tracker"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/sandbox"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
@@ -44,6 +47,10 @@ type Framework struct {
 	UserNamespace        string
 	UserName             string
 	UserToken            string
+	// Tracker records the objects created through the controllers of the framework, see Cleanup.
+	Tracker *tracker.Tracker
+	// KeepOnFailure makes Cleanup keep the tracked objects when the current spec failed.
+	KeepOnFailure bool
 }
 
 func NewFramework(userName string, stageConfig ...utils.Options) (*Framework, error) {
@@ -56,6 +63,7 @@ func NewFrameworkWithTimeout(userName string, timeout time.Duration, options ...
 	var clusterAppDomain, openshiftConsoleHost string
 	var option utils.Options
 	var asUser *ControllerHub
+	resourceTracker := tracker.New()
 
 	if userName == "" {
 		return nil, fmt.Errorf("userName cannot be empty when initializing a new framework instance")
@@ -87,6 +95,7 @@ func NewFrameworkWithTimeout(userName string, timeout time.Duration, options ...
 		if err != nil {
 			return nil, fmt.Errorf("error when initializing kubernetes clients: %v", err)
 		}
+		k.AsKubeDeveloper.SetTracker(resourceTracker)
 		asUser, err = InitControllerHub(k.AsKubeDeveloper)
 		if err != nil {
 			return nil, fmt.Errorf("error when initializing appstudio hub controllers for sandbox user: %v", err)
@@ -98,6 +107,7 @@ func NewFrameworkWithTimeout(userName string, timeout time.Duration, options ...
 		if err != nil {
 			return nil, err
 		}
+		client.SetTracker(resourceTracker)
 
 		asAdmin, err = InitControllerHub(client)
 		if err != nil {
@@ -113,6 +123,9 @@ func NewFrameworkWithTimeout(userName string, timeout time.Duration, options ...
 			if err != nil {
 				return nil, fmt.Errorf("failed to create test namespace %s: %+v", nsName, err)
 			}
+			resourceTracker.Track("Namespace", "", nsName, tracker.OrderNamespace, func(ctx context.Context) error {
+				return asAdmin.CommonController.WithContext(ctx).DeleteNamespace(nsName)
+			})
 
 		}
 
@@ -166,9 +179,66 @@ func NewFrameworkWithTimeout(userName string, timeout time.Duration, options ...
 		UserNamespace:        k.UserNamespace,
 		UserName:             k.UserName,
 		UserToken:            k.UserToken,
+		Tracker:              resourceTracker,
+		KeepOnFailure:        os.Getenv(constants.E2E_KEEP_RESOURCES_ON_FAILURE_ENV) == "true",
 	}, nil
 }
 
+// TrackGitClient makes the branches, pull requests and forks created through c removed by Cleanup,
+// together with the webhooks the cluster registered in the repositories c creates branches in.
+func (f *Framework) TrackGitClient(c git.Client) git.Client {
+	return git.NewTrackingClient(c, f.Tracker, f.ClusterAppDomain)
+}
+
+// Cleanup removes the objects created through the framework in dependency order: the Releases,
+// Snapshots and PipelineRuns first, then the Components and Applications, the git resources and
+// finally the test namespace created by the framework. When KeepOnFailure is set and the current
+// spec failed, nothing is removed. The objects which could not be removed are reported in the
+// returned *tracker.CleanupError and kept tracked, so Cleanup can be retried.
+func (f *Framework) Cleanup(ctx context.Context) error {
+	if f.KeepOnFailure && ginkgo.CurrentSpecReport().Failed() {
+		for _, r := range f.Tracker.Resources() {
+			ginkgo.GinkgoWriter.Printf("keeping %s of the failed spec\n", r)
+		}
+		return nil
+	}
+
+	err := f.Tracker.Cleanup(ctx)
+	if err != nil {
+		ginkgo.GinkgoWriter.Printf("%+v\n", err)
+	}
+	return err
+}
+
+// WithContext returns a copy of the framework whose controllers cancel their API calls and polling once ctx is done.
+func (f *Framework) WithContext(ctx context.Context) *Framework {
+	f2 := *f
+	if f.AsKubeAdmin != nil {
+		f2.AsKubeAdmin = f.AsKubeAdmin.WithContext(ctx)
+	}
+	if f.AsKubeDeveloper != nil {
+		f2.AsKubeDeveloper = f.AsKubeDeveloper.WithContext(ctx)
+	}
+	return &f2
+}
+
+// WithContext returns a copy of the hub whose controllers cancel their API calls and polling once ctx is done,
+// i.e. bound to the context of a ginkgo spec:
+//
+//	It("builds the component", func(ctx SpecContext) {
+//		component, err := f.AsKubeAdmin.WithContext(ctx).HasController.GetComponent(componentName, namespace)
+//	}, NodeTimeout(time.Minute*10))
+func (h *ControllerHub) WithContext(ctx context.Context) *ControllerHub {
+	return &ControllerHub{
+		HasController:         h.HasController.WithContext(ctx),
+		CommonController:      h.CommonController.WithContext(ctx),
+		TektonController:      h.TektonController.WithContext(ctx),
+		ReleaseController:     h.ReleaseController.WithContext(ctx),
+		IntegrationController: h.IntegrationController.WithContext(ctx),
+		ImageController:       h.ImageController.WithContext(ctx),
+	}
+}
+
 func InitControllerHub(cc *kubeCl.CustomClient) (*ControllerHub, error) {
 	// Initialize Common controller
 	commonCtrl, err := common.NewSuiteController(cc)
@@ -212,3 +282,10 @@ func InitControllerHub(cc *kubeCl.CustomClient) (*ControllerHub, error) {
 		ImageController:       imageController,
 	}, nil
 }
+
+// NewFakeControllerHub initializes the controllers on top of a client backed by in-memory fakes
+// seeded with the given objects, so the helpers of the controllers can be unit-tested without a cluster.
+// The Simulate* helpers of the controllers move the objects forward for the helpers waiting for them.
+func NewFakeControllerHub(objects ...crclient.Object) (*ControllerHub, error) {
+	return InitControllerHub(kubeCl.NewFakeKubernetesClient(objects...))
+}
@@ -0,0 +1,324 @@
+package logs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/konflux-ci/e2e-tests/pkg/utils"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+// DiagnosticResources are the resources dumped from the spec namespace into the diagnostics bundle.
+var DiagnosticResources = []schema.GroupVersionResource{
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "applications"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "components"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "snapshots"},
+	{Group: "appstudio.redhat.com", Version: "v1beta2", Resource: "integrationtestscenarios"},
+	{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releases"},
+	{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"},
+	{Group: "tekton.dev", Version: "v1", Resource: "taskruns"},
+}
+
+var pipelineRunsResource = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"}
+
+// DiagnosticsCollector gathers a diagnostics bundle of a failed spec.
+// Every collection step is best effort: errors are recorded in the bundle
+// summary and collection carries on with the next step.
+type DiagnosticsCollector struct {
+	KubeInterface kubernetes.Interface
+	DynamicClient dynamic.Interface
+	// FailedPipelineRunLogs returns the logs of the failed step of the given PipelineRun,
+	// usually tekton.GetFailedPipelineRunLogs. Failed step logs are skipped when nil.
+	FailedPipelineRunLogs func(pr *pipeline.PipelineRun) (string, error)
+	// FilterControllerLogs trims controller logs to the part relevant for the spec.
+	// Controller logs are stored unfiltered when nil.
+	FilterControllerLogs func(log string) string
+}
+
+// DiagnosticsBundle holds the collected artifacts keyed by their path relative to the spec artifact directory.
+type DiagnosticsBundle struct {
+	Title                string
+	Failure              string
+	Namespace            string
+	ControllerNamespaces []string
+	Artifacts            map[string][]byte
+	ResourceCounts       map[string]int
+	FailedPipelineRuns   []string
+	WarningEvents        int
+	Errors               []string
+}
+
+func (b *DiagnosticsBundle) addError(format string, args ...any) {
+	b.Errors = append(b.Errors, fmt.Sprintf(format, args...))
+}
+
+// Collect gathers resources, events and failed step logs of the spec namespace and
+// pod logs and events of the controller namespaces.
+func (c *DiagnosticsCollector) Collect(ctx context.Context, title, failure, namespace string, controllerNamespaces []string) *DiagnosticsBundle {
+	b := &DiagnosticsBundle{
+		Title:                title,
+		Failure:              failure,
+		Namespace:            namespace,
+		ControllerNamespaces: controllerNamespaces,
+		Artifacts:            map[string][]byte{},
+		ResourceCounts:       map[string]int{},
+	}
+
+	if namespace != "" {
+		c.collectResources(ctx, b, namespace)
+		c.collectEvents(ctx, b, namespace)
+		c.collectFailedPipelineRuns(ctx, b, namespace)
+	}
+	for _, ns := range controllerNamespaces {
+		c.collectControllerLogs(ctx, b, ns)
+		c.collectEvents(ctx, b, ns)
+	}
+
+	b.Artifacts["summary.md"] = b.Summary()
+	b.Artifacts["index.html"] = b.Index()
+
+	return b
+}
+
+func (c *DiagnosticsCollector) collectResources(ctx context.Context, b *DiagnosticsBundle, namespace string) {
+	for _, gvr := range DiagnosticResources {
+		list, err := c.DynamicClient.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			b.addError("failed to list %s in namespace %s: %v", gvr.Resource, namespace, err)
+			continue
+		}
+		if len(list.Items) == 0 {
+			continue
+		}
+
+		var out bytes.Buffer
+		for i := range list.Items {
+			item := &list.Items[i]
+			unstructured.RemoveNestedField(item.Object, "metadata", "managedFields")
+			data, err := yaml.Marshal(item.Object)
+			if err != nil {
+				b.addError("failed to marshal %s %s: %v", gvr.Resource, item.GetName(), err)
+				continue
+			}
+			out.WriteString("---\n")
+			out.Write(data)
+		}
+		b.ResourceCounts[gvr.Resource] = len(list.Items)
+		b.Artifacts[path.Join("resources", namespace, gvr.Resource+".yaml")] = out.Bytes()
+	}
+}
+
+func (c *DiagnosticsCollector) collectEvents(ctx context.Context, b *DiagnosticsBundle, namespace string) {
+	events, err := c.KubeInterface.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.addError("failed to list events in namespace %s: %v", namespace, err)
+		return
+	}
+	if len(events.Items) == 0 {
+		return
+	}
+
+	items := events.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		return eventTime(&items[i]).Before(eventTime(&items[j]))
+	})
+
+	var out strings.Builder
+	for i := range items {
+		e := &items[i]
+		if e.Type == corev1.EventTypeWarning {
+			b.WarningEvents++
+		}
+		fmt.Fprintf(&out, "%s\t%s\t%s\t%s/%s\t%s\n", eventTime(e).Format(time.RFC3339), e.Type, e.Reason, e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Message)
+	}
+	b.Artifacts[path.Join("events", namespace+".log")] = []byte(out.String())
+}
+
+// eventTime returns the most relevant timestamp of the event.
+func eventTime(e *corev1.Event) time.Time {
+	switch {
+	case !e.LastTimestamp.IsZero():
+		return e.LastTimestamp.Time
+	case !e.EventTime.IsZero():
+		return e.EventTime.Time
+	default:
+		return e.CreationTimestamp.Time
+	}
+}
+
+func (c *DiagnosticsCollector) collectFailedPipelineRuns(ctx context.Context, b *DiagnosticsBundle, namespace string) {
+	list, err := c.DynamicClient.Resource(pipelineRunsResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.addError("failed to list pipelineruns in namespace %s: %v", namespace, err)
+		return
+	}
+
+	for i := range list.Items {
+		pr := &pipeline.PipelineRun{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, pr); err != nil {
+			b.addError("failed to convert pipelinerun %s: %v", list.Items[i].GetName(), err)
+			continue
+		}
+		if !pr.IsDone() || !pr.Status.GetCondition("Succeeded").IsFalse() {
+			continue
+		}
+		b.FailedPipelineRuns = append(b.FailedPipelineRuns, pr.Name)
+
+		if c.FailedPipelineRunLogs == nil {
+			continue
+		}
+		log, err := c.FailedPipelineRunLogs(pr)
+		if err != nil {
+			b.addError("failed to get logs of failed pipelinerun %s: %v", pr.Name, err)
+			continue
+		}
+		b.Artifacts[path.Join("failed-pipelineruns", pr.Name+".log")] = []byte(log)
+	}
+}
+
+func (c *DiagnosticsCollector) collectControllerLogs(ctx context.Context, b *DiagnosticsBundle, namespace string) {
+	pods, err := c.KubeInterface.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		b.addError("failed to list pods in namespace %s: %v", namespace, err)
+		return
+	}
+
+	for _, pod := range pods.Items {
+		var containers []corev1.Container
+		containers = append(containers, pod.Spec.InitContainers...)
+		containers = append(containers, pod.Spec.Containers...)
+		for _, container := range containers {
+			log, err := utils.GetContainerLogs(c.KubeInterface, pod.Name, container.Name, namespace)
+			if err != nil {
+				b.addError("failed to get logs of pod/container %s/%s in namespace %s: %v", pod.Name, container.Name, namespace, err)
+				continue
+			}
+			if c.FilterControllerLogs != nil {
+				log = c.FilterControllerLogs(log)
+			}
+			if log == "" {
+				continue
+			}
+			b.Artifacts[path.Join("controller-logs", namespace, "pod-"+pod.Name+"-"+container.Name+".log")] = []byte(log)
+		}
+	}
+}
+
+// Files returns sorted paths of all the bundle artifacts.
+func (b *DiagnosticsBundle) Files() []string {
+	files := make([]string, 0, len(b.Artifacts))
+	for name := range b.Artifacts {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// Summary renders the markdown summary of the bundle.
+func (b *DiagnosticsBundle) Summary() []byte {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# %s\n\n", b.Title)
+	if b.Failure != "" {
+		fmt.Fprintf(&out, "## Failure\n\n```\n%s\n```\n\n", b.Failure)
+	}
+
+	fmt.Fprintf(&out, "## Namespaces\n\n- spec: `%s`\n", b.Namespace)
+	for _, ns := range b.ControllerNamespaces {
+		fmt.Fprintf(&out, "- controller: `%s`\n", ns)
+	}
+
+	out.WriteString("\n## Resources\n\n")
+	for _, gvr := range DiagnosticResources {
+		if count, ok := b.ResourceCounts[gvr.Resource]; ok {
+			fmt.Fprintf(&out, "- %s: %d\n", gvr.Resource, count)
+		}
+	}
+	fmt.Fprintf(&out, "- warning events: %d\n", b.WarningEvents)
+
+	if len(b.FailedPipelineRuns) > 0 {
+		out.WriteString("\n## Failed PipelineRuns\n\n")
+		for _, name := range b.FailedPipelineRuns {
+			fmt.Fprintf(&out, "- [%s](failed-pipelineruns/%s.log)\n", name, name)
+		}
+	}
+
+	if len(b.Errors) > 0 {
+		out.WriteString("\n## Collection errors\n\n")
+		for _, e := range b.Errors {
+			fmt.Fprintf(&out, "- %s\n", e)
+		}
+	}
+
+	out.WriteString("\n## Files\n\n")
+	for _, name := range b.Files() {
+		if name == "summary.md" || name == "index.html" {
+			continue
+		}
+		fmt.Fprintf(&out, "- [%s](%s)\n", name, name)
+	}
+
+	return []byte(out.String())
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Failure}}<h2>Failure</h2>
+<pre>{{.Failure}}</pre>
+{{end}}<h2>Namespaces</h2>
+<ul>
+<li>spec: {{.Namespace}}</li>
+{{range .ControllerNamespaces}}<li>controller: {{.}}</li>
+{{end}}</ul>
+{{if .FailedPipelineRuns}}<h2>Failed PipelineRuns</h2>
+<ul>
+{{range .FailedPipelineRuns}}<li><a href="failed-pipelineruns/{{.}}.log">{{.}}</a></li>
+{{end}}</ul>
+{{end}}{{if .Errors}}<h2>Collection errors</h2>
+<ul>
+{{range .Errors}}<li>{{.}}</li>
+{{end}}</ul>
+{{end}}<h2>Files</h2>
+<ul>
+<li><a href="summary.md">summary.md</a></li>
+{{range .Files}}<li><a href="{{.}}">{{.}}</a></li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+// Index renders the HTML index of the bundle.
+func (b *DiagnosticsBundle) Index() []byte {
+	var files []string
+	for _, name := range b.Files() {
+		if name != "summary.md" && name != "index.html" {
+			files = append(files, name)
+		}
+	}
+
+	var out bytes.Buffer
+	err := indexTemplate.Execute(&out, struct {
+		*DiagnosticsBundle
+		Files []string
+	}{b, files})
+	if err != nil {
+		return []byte(fmt.Sprintf("failed to render index: %v", err))
+	}
+	return out.Bytes()
+}
@@ -0,0 +1,76 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func newPipelineRun(name, status string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "tekton.dev/v1",
+		"kind":       "PipelineRun",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "user-ns"},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Succeeded", "status": status, "reason": "Done"},
+			},
+		},
+	}}
+}
+
+func TestDiagnosticsCollect(t *testing.T) {
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, gvr := range DiagnosticResources {
+		listKinds[gvr] = "List"
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds,
+		newPipelineRun("build-ok", "True"),
+		newPipelineRun("build-failed", "False"),
+	)
+	kubeClient := kubefake.NewSimpleClientset(
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "user-ns"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "FailedMount",
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "build-failed-pod"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "controller", Namespace: "build-service"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "manager"}}},
+		},
+	)
+
+	collector := &DiagnosticsCollector{
+		KubeInterface: kubeClient,
+		DynamicClient: dynamicClient,
+		FailedPipelineRunLogs: func(pr *pipeline.PipelineRun) (string, error) {
+			return fmt.Sprintf("logs of %s", pr.Name), nil
+		},
+	}
+	b := collector.Collect(context.Background(), "spec", "boom", "user-ns", []string{"build-service", "missing"})
+
+	assert.Equal(t, []string{"build-failed"}, b.FailedPipelineRuns)
+	assert.Equal(t, "logs of build-failed", string(b.Artifacts["failed-pipelineruns/build-failed.log"]))
+	assert.Equal(t, 2, b.ResourceCounts["pipelineruns"])
+	assert.Contains(t, string(b.Artifacts["resources/user-ns/pipelineruns.yaml"]), "name: build-failed")
+	assert.Equal(t, 1, b.WarningEvents)
+	assert.Contains(t, string(b.Artifacts["events/user-ns.log"]), "FailedMount")
+	assert.Contains(t, b.Artifacts, "controller-logs/build-service/pod-controller-manager.log")
+
+	summary := string(b.Artifacts["summary.md"])
+	assert.Contains(t, summary, "boom")
+	assert.Contains(t, summary, "[build-failed](failed-pipelineruns/build-failed.log)")
+	assert.True(t, strings.Contains(string(b.Artifacts["index.html"]), `href="events/user-ns.log"`))
+}
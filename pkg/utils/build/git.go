@@ -83,6 +83,33 @@ func CreateCodebergBuildSecret(f *framework.Framework, secretName string, annota
 	return nil
 }
 
+// CreateBitbucketBuildSecret creates a Kubernetes secret for Bitbucket build credentials
+// The username is required by Bitbucket for basic auth with app passwords, for access tokens it is "x-token-auth"
+func CreateBitbucketBuildSecret(f *framework.Framework, secretName string, annotations map[string]string, username, token string) error {
+	buildSecret := v1.Secret{}
+	buildSecret.Name = secretName
+	buildSecret.Labels = map[string]string{
+		"appstudio.redhat.com/credentials": "scm",
+		"appstudio.redhat.com/scm.host":    "bitbucket.org",
+	}
+	if annotations != nil {
+		buildSecret.Annotations = annotations
+	}
+	if username == "" {
+		username = "x-token-auth"
+	}
+	buildSecret.Type = "kubernetes.io/basic-auth"
+	buildSecret.StringData = map[string]string{
+		"username": username,
+		"password": token,
+	}
+	_, err := f.AsKubeAdmin.CommonController.CreateSecret(f.UserNamespace, &buildSecret)
+	if err != nil {
+		return fmt.Errorf("error creating build secret: %v", err)
+	}
+	return nil
+}
+
 func CleanupWebhooks(f *framework.Framework, repoName string) error {
 	hooks, err := f.AsKubeAdmin.CommonController.Github.ListRepoWebhooks(repoName)
 	if err != nil {
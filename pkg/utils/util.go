@@ -126,13 +126,24 @@ func GetGeneratedNamespace(name string) string {
 }
 
 func WaitUntilWithInterval(cond wait.ConditionFunc, interval time.Duration, timeout time.Duration) error {
-	return wait.PollUntilContextTimeout(context.Background(), interval, timeout, true, func(ctx context.Context) (bool, error) { return cond() })
+	return WaitUntilWithIntervalContext(context.Background(), cond, interval, timeout)
 }
 
 func WaitUntil(cond wait.ConditionFunc, timeout time.Duration) error {
 	return WaitUntilWithInterval(cond, time.Second, timeout)
 }
 
+// WaitUntilWithIntervalContext is WaitUntilWithInterval returning as soon as ctx is done,
+// i.e. when the spec times out or the run is interrupted.
+func WaitUntilWithIntervalContext(ctx context.Context, cond wait.ConditionFunc, interval time.Duration, timeout time.Duration) error {
+	return wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) { return cond() })
+}
+
+// WaitUntilContext is WaitUntil returning as soon as ctx is done.
+func WaitUntilContext(ctx context.Context, cond wait.ConditionFunc, timeout time.Duration) error {
+	return WaitUntilWithIntervalContext(ctx, cond, time.Second, timeout)
+}
+
 func ExecuteCommandInASpecificDirectory(command string, args []string, directory string) error {
 	cmd := exec.Command(command, args...) // nolint:gosec
 	cmd.Dir = directory
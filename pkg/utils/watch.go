@@ -0,0 +1,258 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	// DefaultWatchPollInterval is the interval of the polling WaitForObject falls back to when the objects cannot be watched.
+	DefaultWatchPollInterval = time.Second * 10
+
+	// watchResumeDelay is waited for before a closed watch is resumed, so a watch closed right away is not resumed in a busy loop.
+	watchResumeDelay = time.Second
+)
+
+// WatchOptions selects the objects WaitForObject waits on.
+type WatchOptions struct {
+	Namespace string
+	// Name restricts the selection to the single object of the given name.
+	Name          string
+	LabelSelector string
+	// Newest restricts the selection to the most recently created of the selected objects, so the objects left
+	// behind by earlier attempts (i.e. the PipelineRuns of a rerun) are not evaluated.
+	Newest bool
+	// Timeout bounds the waiting, only the context given to WaitForObject does when it is 0.
+	Timeout time.Duration
+	// PollInterval is DefaultWatchPollInterval when it is 0.
+	PollInterval time.Duration
+}
+
+func (o WatchOptions) listOptions() metav1.ListOptions {
+	opts := metav1.ListOptions{LabelSelector: o.LabelSelector}
+	if o.Name != "" {
+		opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", o.Name).String()
+	}
+	return opts
+}
+
+// WaitForObject waits until one of the objects of the resource gvr selected by opts satisfies cond and returns it.
+// T is the type the objects are converted to before cond is called, i.e. tektonv1.PipelineRun for the pipelineruns.
+//
+// Instead of polling the API server, the objects are listed once and watched from then on: a watch closed by the
+// API server is resumed from the last resourceVersion seen and the objects are listed again when that resourceVersion
+// is too old. When the objects cannot be listed nor watched (i.e. through a proxy not supporting watches) it falls back
+// to listing them every opts.PollInterval. An error returned by cond stops the waiting.
+func WaitForObject[T any](ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource, opts WatchOptions, cond func(obj *T) (bool, error)) (*T, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	w := &objectWaiter[T]{
+		resource: client.Resource(gvr).Namespace(opts.Namespace),
+		gvr:      gvr,
+		opts:     opts,
+		cond:     cond,
+	}
+
+	for {
+		obj, resourceVersion, listed, err := w.list(ctx)
+		if err != nil || obj != nil {
+			return obj, err
+		}
+		if !listed {
+			return w.poll(ctx)
+		}
+		obj, relist, err := w.watch(ctx, resourceVersion)
+		if err != nil || obj != nil {
+			return obj, err
+		}
+		if !relist {
+			return w.poll(ctx)
+		}
+	}
+}
+
+type objectWaiter[T any] struct {
+	resource dynamic.ResourceInterface
+	gvr      schema.GroupVersionResource
+	opts     WatchOptions
+	cond     func(obj *T) (bool, error)
+	// newest is the most recently created object seen when opts.Newest is set.
+	newest *unstructured.Unstructured
+}
+
+// isNewest records u as the newest object when it is not older than the newest one seen so far.
+// Objects created in the same second are ordered by name.
+func (w *objectWaiter[T]) isNewest(u *unstructured.Unstructured) bool {
+	if w.newest != nil && !sameObject(w.newest, u) {
+		created, newestCreated := u.GetCreationTimestamp(), w.newest.GetCreationTimestamp()
+		if created.Before(&newestCreated) || (created.Equal(&newestCreated) && u.GetName() < w.newest.GetName()) {
+			return false
+		}
+	}
+	w.newest = u
+	return true
+}
+
+func sameObject(a, b *unstructured.Unstructured) bool {
+	return a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+}
+
+// checkList returns the listed object satisfying the condition, only the newest one is checked when opts.Newest is set.
+func (w *objectWaiter[T]) checkList(items []unstructured.Unstructured) (*T, error) {
+	if !w.opts.Newest {
+		for i := range items {
+			if obj, err := w.check(&items[i]); err != nil || obj != nil {
+				return obj, err
+			}
+		}
+		return nil, nil
+	}
+
+	w.newest = nil
+	for i := range items {
+		w.isNewest(&items[i])
+	}
+	if w.newest == nil {
+		return nil, nil
+	}
+	return w.check(w.newest)
+}
+
+// check converts u and returns it when it satisfies the condition.
+func (w *objectWaiter[T]) check(u *unstructured.Unstructured) (*T, error) {
+	obj := new(T)
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), obj); err != nil {
+		return nil, fmt.Errorf("failed to convert %s %s/%s: %+v", w.gvr.Resource, u.GetNamespace(), u.GetName(), err)
+	}
+	ok, err := w.cond(obj)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// list returns the first listed object satisfying the condition, or the resourceVersion of the list to watch from.
+// listed is false when the objects cannot be listed.
+func (w *objectWaiter[T]) list(ctx context.Context) (obj *T, resourceVersion string, listed bool, err error) {
+	list, err := w.resource.List(ctx, w.opts.listOptions())
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, "", false, fmt.Errorf("failed to wait for %s: %+v", w.gvr.Resource, ctx.Err())
+		}
+		return nil, "", false, nil
+	}
+	if obj, err := w.checkList(list.Items); err != nil || obj != nil {
+		return obj, "", true, err
+	}
+	return nil, list.GetResourceVersion(), true, nil
+}
+
+// watch checks the objects changed since resourceVersion. It returns relist when the objects have to be listed again,
+// neither an object nor relist when the objects cannot be watched.
+func (w *objectWaiter[T]) watch(ctx context.Context, resourceVersion string) (*T, bool, error) {
+	for {
+		opts := w.opts.listOptions()
+		opts.ResourceVersion = resourceVersion
+		opts.AllowWatchBookmarks = true
+		watcher, err := w.resource.Watch(ctx, opts)
+		if err != nil {
+			if k8sErrors.IsResourceExpired(err) || k8sErrors.IsGone(err) {
+				return nil, true, nil
+			}
+			return nil, false, nil
+		}
+
+		obj, relist, err := w.receive(ctx, watcher, &resourceVersion)
+		watcher.Stop()
+		if err != nil || obj != nil || relist {
+			return obj, relist, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, fmt.Errorf("failed to wait for %s: %+v", w.gvr.Resource, ctx.Err())
+		case <-time.After(watchResumeDelay):
+		}
+	}
+}
+
+// receive checks the events of watcher until it is closed, resourceVersion is the version of the last event received.
+func (w *objectWaiter[T]) receive(ctx context.Context, watcher watch.Interface, resourceVersion *string) (*T, bool, error) {
+	for {
+		var event watch.Event
+		select {
+		case <-ctx.Done():
+			return nil, false, fmt.Errorf("failed to wait for %s: %+v", w.gvr.Resource, ctx.Err())
+		case e, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil, false, nil
+			}
+			event = e
+		}
+
+		switch event.Type {
+		case watch.Error:
+			err := k8sErrors.FromObject(event.Object)
+			if k8sErrors.IsResourceExpired(err) || k8sErrors.IsGone(err) {
+				return nil, true, nil
+			}
+			return nil, false, nil
+		case watch.Added, watch.Modified, watch.Deleted, watch.Bookmark:
+			u, ok := event.Object.(*unstructured.Unstructured)
+			if !ok {
+				continue
+			}
+			if rv := u.GetResourceVersion(); rv != "" {
+				*resourceVersion = rv
+			}
+			if event.Type == watch.Deleted && w.opts.Newest && w.newest != nil && sameObject(w.newest, u) {
+				// The newest object is gone, the one before it is newest again
+				return nil, true, nil
+			}
+			if event.Type == watch.Added || event.Type == watch.Modified {
+				if w.opts.Newest && !w.isNewest(u) {
+					continue
+				}
+				if obj, err := w.check(u); err != nil || obj != nil {
+					return obj, false, err
+				}
+			}
+		}
+	}
+}
+
+func (w *objectWaiter[T]) poll(ctx context.Context) (*T, error) {
+	interval := w.opts.PollInterval
+	if interval == 0 {
+		interval = DefaultWatchPollInterval
+	}
+
+	var obj *T
+	err := wait.PollUntilContextCancel(ctx, interval, true, func(ctx context.Context) (bool, error) {
+		list, err := w.resource.List(ctx, w.opts.listOptions())
+		if err != nil {
+			return false, nil
+		}
+		obj, err = w.checkList(list.Items)
+		return obj != nil, err
+	})
+	if err != nil && obj == nil && ctx.Err() != nil {
+		return nil, fmt.Errorf("failed to wait for %s: %+v", w.gvr.Resource, err)
+	}
+
+	return obj, err
+}
@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var widgets = schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "widgets"}
+
+type widget struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Status            struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
+
+func newWidget(name, phase string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.com/v1")
+	u.SetKind("Widget")
+	u.SetNamespace("ns")
+	u.SetName(name)
+	u.SetResourceVersion("1")
+	_ = unstructured.SetNestedField(u.Object, phase, "status", "phase")
+	return u
+}
+
+func newFakeClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{widgets: "WidgetList"}, objects...)
+}
+
+func isReady(w *widget) (bool, error) {
+	return w.Status.Phase == "Ready", nil
+}
+
+// watchStarted returns a channel receiving a value every time a watch of the widgets is started.
+func watchStarted(client *fake.FakeDynamicClient) chan struct{} {
+	started := make(chan struct{}, 10)
+	client.PrependWatchReactor("widgets", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		started <- struct{}{}
+		return false, nil, nil
+	})
+	return started
+}
+
+func TestWaitForObjectAlreadySatisfied(t *testing.T) {
+	client := newFakeClient(newWidget("a", "Pending"), newWidget("b", "Ready"))
+
+	w, err := WaitForObject(context.Background(), client, widgets, WatchOptions{Namespace: "ns", Timeout: time.Second}, isReady)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", w.Name)
+}
+
+func TestWaitForObjectWatchesChanges(t *testing.T) {
+	client := newFakeClient(newWidget("a", "Pending"))
+	started := watchStarted(client)
+
+	go func() {
+		<-started
+		_, _ = client.Resource(widgets).Namespace("ns").Update(context.Background(), newWidget("a", "Ready"), metav1.UpdateOptions{})
+	}()
+
+	w, err := WaitForObject(context.Background(), client, widgets, WatchOptions{Namespace: "ns", Name: "a", Timeout: time.Second * 5, PollInterval: time.Hour}, isReady)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ready", w.Status.Phase)
+}
+
+func TestWaitForObjectRelistsWhenResourceVersionExpired(t *testing.T) {
+	client := newFakeClient(newWidget("a", "Pending"))
+	expired := watch.NewFake()
+	started := make(chan struct{})
+	watches := 0
+	client.PrependWatchReactor("widgets", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		watches++
+		if watches == 1 {
+			close(started)
+			return true, expired, nil
+		}
+		return false, nil, nil
+	})
+
+	go func() {
+		<-started
+		_, _ = client.Resource(widgets).Namespace("ns").Update(context.Background(), newWidget("a", "Ready"), metav1.UpdateOptions{})
+		expired.Error(&k8sErrors.NewResourceExpired("too old resource version").ErrStatus)
+	}()
+
+	w, err := WaitForObject(context.Background(), client, widgets, WatchOptions{Namespace: "ns", Timeout: time.Second * 5, PollInterval: time.Hour}, isReady)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", w.Name)
+}
+
+func TestWaitForObjectFallsBackToPolling(t *testing.T) {
+	client := newFakeClient(newWidget("a", "Pending"))
+	client.PrependWatchReactor("widgets", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, k8sErrors.NewMethodNotSupported(widgets.GroupResource(), "watch")
+	})
+	polls := 0
+	client.PrependReactor("list", "widgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		polls++
+		if polls == 3 {
+			// the fake client is locked while its reactors run
+			go func() {
+				_, _ = client.Resource(widgets).Namespace("ns").Update(context.Background(), newWidget("a", "Ready"), metav1.UpdateOptions{})
+			}()
+		}
+		return false, nil, nil
+	})
+
+	w, err := WaitForObject(context.Background(), client, widgets, WatchOptions{Namespace: "ns", Timeout: time.Second * 5, PollInterval: time.Millisecond * 10}, isReady)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", w.Name)
+	assert.GreaterOrEqual(t, polls, 3)
+}
+
+func TestWaitForObjectStopsOnConditionError(t *testing.T) {
+	client := newFakeClient(newWidget("a", "Failed"))
+	failed := errors.New("widget failed")
+
+	_, err := WaitForObject(context.Background(), client, widgets, WatchOptions{Namespace: "ns", Timeout: time.Second}, func(w *widget) (bool, error) {
+		if w.Status.Phase == "Failed" {
+			return false, failed
+		}
+		return false, nil
+	})
+	assert.ErrorIs(t, err, failed)
+}
+
+func TestWaitForObjectTimesOut(t *testing.T) {
+	client := newFakeClient(newWidget("a", "Pending"))
+
+	_, err := WaitForObject(context.Background(), client, widgets, WatchOptions{Namespace: "ns", Timeout: time.Millisecond * 100}, isReady)
+	assert.ErrorContains(t, err, "failed to wait for widgets")
+}
+
+func TestWaitForObjectEvaluatesNewestOnly(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	older, newer := newWidget("a", "Ready"), newWidget("b", "Pending")
+	older.SetCreationTimestamp(metav1.NewTime(created))
+	newer.SetCreationTimestamp(metav1.NewTime(created.Add(time.Minute)))
+	client := newFakeClient(older, newer)
+	started := watchStarted(client)
+
+	go func() {
+		<-started
+		older.SetResourceVersion("2")
+		_, _ = client.Resource(widgets).Namespace("ns").Update(context.Background(), older, metav1.UpdateOptions{})
+		newer.SetResourceVersion("2")
+		_ = unstructured.SetNestedField(newer.Object, "Ready", "status", "phase")
+		_, _ = client.Resource(widgets).Namespace("ns").Update(context.Background(), newer, metav1.UpdateOptions{})
+	}()
+
+	w, err := WaitForObject(context.Background(), client, widgets, WatchOptions{Namespace: "ns", Newest: true, Timeout: time.Second * 5, PollInterval: time.Hour}, isReady)
+	assert.NoError(t, err)
+	assert.Equal(t, "b", w.Name)
+}
@@ -47,6 +47,8 @@ const (
 
 	githubUrlFormat = "https://github.com/%s/%s"
 	gitlabUrlFormat = "https://gitlab.com/%s"
+	// bitbucketUrlFormat takes the "workspace/repo" path of the repository
+	bitbucketUrlFormat = "https://bitbucket.org/%s"
 
 	//Logging related
 	buildStatusAnnotationValueLoggingFormat = "build status annotation value: %s\n"
@@ -66,6 +68,12 @@ var (
 	multiComponentContextDirs          = []string{"go-component", "python-component"}
 	pythonComponentGitHubURL           = fmt.Sprintf(githubUrlFormat, githubOrg, pythonComponentRepoName)
 
+	giteaOrg                              = utils.GetEnv(constants.CODEBERG_QE_ORG_ENV, constants.DefaultCodebergQEOrg)
+	giteaUrlFormat                        = strings.TrimSuffix(utils.GetEnv(constants.CODEBERG_API_URL_ENV, constants.DefaultCodebergAPIURL), "/") + "/%s"
+	helloWorldComponentGiteaProjectID     = fmt.Sprintf("%s/%s", giteaOrg, helloWorldComponentGitSourceRepoName)
+	bitbucketWorkspace                    = utils.GetEnv(constants.BITBUCKET_QE_WORKSPACE_ENV, constants.DefaultBitbucketQEWorkspace)
+	helloWorldComponentBitbucketProjectID = fmt.Sprintf("%s/%s", bitbucketWorkspace, helloWorldComponentGitSourceRepoName)
+
 	secretLookupComponentOneGitSourceURL = fmt.Sprintf(githubUrlFormat, noAppOrgName, secretLookupGitSourceRepoOneName)
 	secretLookupComponentTwoGitSourceURL = fmt.Sprintf(githubUrlFormat, noAppOrgName, secretLookupGitSourceRepoTwoName)
 
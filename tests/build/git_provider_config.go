@@ -17,14 +17,15 @@ import (
 
 const (
 	// E2E_GIT_PROVIDERS_ENV controls which git providers to run tests against.
-	// Comma-separated list of provider prefixes: "gh,gl,gt" or "gh" or "gl,gt"
-	// If not set or empty, all registered providers will be used.
+	// Comma-separated list of provider prefixes: "gh,gl,gt,bb" or "gh" or "gl,bb"
+	// If not set or empty, all registered providers except the opt-in ones (Gitea, Bitbucket) will be used.
 	// Examples:
 	//   E2E_GIT_PROVIDERS=gh           -> only GitHub
 	//   E2E_GIT_PROVIDERS=gl           -> only GitLab
 	//   E2E_GIT_PROVIDERS=gh,gl        -> GitHub and GitLab
-	//   E2E_GIT_PROVIDERS=gh,gl,gt     -> all three (GitHub, GitLab, Gitea)
-	//   E2E_GIT_PROVIDERS=""           -> all registered providers (default)
+	//   E2E_GIT_PROVIDERS=gh,gl,gt,bb  -> all four (GitHub, GitLab, Gitea, Bitbucket)
+	//   E2E_GIT_PROVIDERS=bb           -> only Bitbucket (Cloud, Server/Data Center is not supported)
+	//   E2E_GIT_PROVIDERS=""           -> GitHub and GitLab (default)
 	E2E_GIT_PROVIDERS_ENV = "E2E_GIT_PROVIDERS"
 )
 
@@ -33,10 +34,10 @@ type GitProviderConfig struct {
 	// Provider identifier
 	Provider git.GitProvider
 
-	// Prefix used for component names (e.g., "gh", "gl", "gt")
+	// Prefix used for component names (e.g., "gh", "gl", "gt", "bb")
 	Prefix string
 
-	// LabelName is the Ginkgo label used for --label-filter (e.g., "github", "gitlab", "gitea")
+	// LabelName is the Ginkgo label used for --label-filter (e.g., "github", "gitlab", "gitea", "bitbucket")
 	LabelName string
 
 	// URLFormat is the format string for repository URLs (e.g., "https://github.com/%s/%s")
@@ -67,6 +68,9 @@ type GitProviderConfig struct {
 
 	// BuildTargetRepoURL builds the full URL for the target repository
 	BuildTargetRepoURL func(org, repoName string) string
+
+	// OptIn providers are used only when listed in E2E_GIT_PROVIDERS
+	OptIn bool
 }
 
 // GitProviderRegistry holds all registered git provider configurations
@@ -111,28 +115,32 @@ func GetRegisteredProviderEntries() []TableEntry {
 //   - E2E_GIT_PROVIDERS=gh          -> only GitHub tests run
 //   - E2E_GIT_PROVIDERS=gl          -> only GitLab tests run
 //   - E2E_GIT_PROVIDERS=gh,gl       -> both GitHub and GitLab
-//   - E2E_GIT_PROVIDERS=gh,gl,gt    -> all three providers
-//   - E2E_GIT_PROVIDERS="" or unset -> all registered providers (default)
+//   - E2E_GIT_PROVIDERS=gh,gl,gt,bb -> all four providers
+//   - E2E_GIT_PROVIDERS="" or unset -> all registered providers except the opt-in ones (default)
 func GetEnabledProviderEntries() []TableEntry {
 	enabledProviders := getEnabledProviderPrefixes()
 
 	var entries []TableEntry
 	for provider, config := range GitProviderRegistry {
-		if isProviderEnabled(config.Prefix, enabledProviders) {
+		if isProviderEnabled(config, enabledProviders) {
 			entries = append(entries, Entry(config.Prefix, Label(config.LabelName), provider, config.Prefix))
 		}
 	}
 
-	// If no entries matched (e.g., typo in env var), return all as fallback
+	// If no entries matched (e.g., typo in env var), return the default ones as fallback
 	if len(entries) == 0 {
-		return GetRegisteredProviderEntries()
+		for provider, config := range GitProviderRegistry {
+			if isProviderEnabled(config, nil) {
+				entries = append(entries, Entry(config.Prefix, Label(config.LabelName), provider, config.Prefix))
+			}
+		}
 	}
 
 	return entries
 }
 
 // getEnabledProviderPrefixes parses the E2E_GIT_PROVIDERS environment variable
-// Returns nil if not set (meaning all providers are enabled)
+// Returns nil if not set (meaning all providers except the opt-in ones are enabled)
 func getEnabledProviderPrefixes() []string {
 	envValue := os.Getenv(E2E_GIT_PROVIDERS_ENV)
 	if envValue == "" {
@@ -150,12 +158,12 @@ func getEnabledProviderPrefixes() []string {
 }
 
 // isProviderEnabled checks if a provider prefix is in the enabled list
-// If enabledList is nil, all providers are considered enabled
-func isProviderEnabled(prefix string, enabledList []string) bool {
+// If enabledList is nil, all providers except the opt-in ones are considered enabled
+func isProviderEnabled(config *GitProviderConfig, enabledList []string) bool {
 	if enabledList == nil {
-		return true // all enabled
+		return !config.OptIn
 	}
-	prefix = strings.ToLower(prefix)
+	prefix := strings.ToLower(config.Prefix)
 	for _, enabled := range enabledList {
 		if enabled == prefix {
 			return true
@@ -171,7 +179,7 @@ func IsProviderEnabled(provider git.GitProvider) bool {
 	if !exists {
 		return false
 	}
-	return isProviderEnabled(config.Prefix, getEnabledProviderPrefixes())
+	return isProviderEnabled(config, getEnabledProviderPrefixes())
 }
 
 func init() {
@@ -187,7 +195,7 @@ func init() {
 		SecretName:          "",
 
 		CreateClient: func(f *framework.Framework) git.Client {
-			return git.NewGitHubClient(f.AsKubeAdmin.CommonController.Github)
+			return f.TrackGitClient(git.NewGitHubClient(f.AsKubeAdmin.CommonController.Github))
 		},
 
 		SetupBuildSecret: nil, // GitHub doesn't need special secret setup in this context
@@ -213,7 +221,7 @@ func init() {
 		SecretName:          "pipelines-as-code-secret",
 
 		CreateClient: func(f *framework.Framework) git.Client {
-			return git.NewGitlabClient(f.AsKubeAdmin.CommonController.Gitlab)
+			return f.TrackGitClient(git.NewGitlabClient(f.AsKubeAdmin.CommonController.Gitlab))
 		},
 
 		SetupBuildSecret: func(f *framework.Framework) error {
@@ -234,38 +242,74 @@ func init() {
 		},
 	})
 
-	// To add Gitea, simply add another RegisterGitProvider call here:
-	//
-	// RegisterGitProvider(&GitProviderConfig{
-	//     Provider:            git.GiteaProvider,  // Add this constant to pkg/clients/git/git.go
-	//     Prefix:              "gt",
-	//     LabelName:           "gitea",
-	//     URLFormat:           giteaUrlFormat,     // Add to const.go: "https://gitea.example.com/%s/%s"
-	//     Org:                 giteaOrg,           // Add to const.go
-	//     SourceRepoProjectID: helloWorldComponentGiteaRepoName,
-	//     TokenEnvVar:         constants.GITEA_BOT_TOKEN_ENV,
-	//     SecretName:          "gitea-pac-secret",
-	//
-	//     CreateClient: func(f *framework.Framework) git.Client {
-	//         return git.NewGiteaClient(f.AsKubeAdmin.CommonController.Gitea)
-	//     },
-	//
-	//     SetupBuildSecret: func(f *framework.Framework) error {
-	//         giteaToken := utils.GetEnv(constants.GITEA_BOT_TOKEN_ENV, "")
-	//         if giteaToken == "" {
-	//             return fmt.Errorf("Gitea token not set")
-	//         }
-	//         return build.CreateGiteaBuildSecret(f, "gitea-pac-secret", nil, giteaToken)
-	//     },
-	//
-	//     BuildTargetRepoName: func(baseRepoName string) string {
-	//         return baseRepoName + "-" + util.GenerateRandomString(6)
-	//     },
-	//
-	//     BuildTargetRepoURL: func(org, repoName string) string {
-	//         return fmt.Sprintf(giteaUrlFormat, org, repoName)
-	//     },
-	// })
+	// Register Gitea provider (Forgejo/Codeberg, or any Gitea compatible forge set by CODEBERG_API_URL)
+	RegisterGitProvider(&GitProviderConfig{
+		Provider:            git.ForgejoProvider,
+		Prefix:              "gt",
+		LabelName:           "gitea",
+		URLFormat:           giteaUrlFormat,
+		Org:                 giteaOrg,
+		SourceRepoProjectID: helloWorldComponentGiteaProjectID,
+		TokenEnvVar:         constants.CODEBERG_BOT_TOKEN_ENV,
+		SecretName:          "pipelines-as-code-secret",
+		OptIn:               true,
+
+		CreateClient: func(f *framework.Framework) git.Client {
+			return f.TrackGitClient(git.NewForgejoClient(f.AsKubeAdmin.CommonController.Forgejo))
+		},
+
+		SetupBuildSecret: func(f *framework.Framework) error {
+			giteaToken := utils.GetEnv(constants.CODEBERG_BOT_TOKEN_ENV, "")
+			if giteaToken == "" {
+				return fmt.Errorf("Gitea token environment variable %s is not set", constants.CODEBERG_BOT_TOKEN_ENV)
+			}
+			return build.CreateCodebergBuildSecret(f, "pipelines-as-code-secret", map[string]string{}, giteaToken)
+		},
+
+		BuildTargetRepoName: func(baseRepoName string) string {
+			return fmt.Sprintf("%s/%s", giteaOrg, baseRepoName+"-"+util.GenerateRandomString(6))
+		},
+
+		BuildTargetRepoURL: func(org, repoName string) string {
+			return fmt.Sprintf(giteaUrlFormat, repoName)
+		},
+	})
+
+	// Register Bitbucket Cloud provider
+	// Only Bitbucket Cloud (bitbucket.org, REST API 2.0) is supported, Bitbucket Server/Data Center is not.
+	RegisterGitProvider(&GitProviderConfig{
+		Provider:            git.BitbucketProvider,
+		Prefix:              "bb",
+		LabelName:           "bitbucket",
+		URLFormat:           bitbucketUrlFormat,
+		Org:                 bitbucketWorkspace,
+		SourceRepoProjectID: helloWorldComponentBitbucketProjectID,
+		TokenEnvVar:         constants.BITBUCKET_BOT_TOKEN_ENV,
+		SecretName:          "pipelines-as-code-secret",
+		OptIn:               true,
+
+		CreateClient: func(f *framework.Framework) git.Client {
+			return f.TrackGitClient(git.NewBitbucketClient(f.AsKubeAdmin.CommonController.Bitbucket))
+		},
+
+		SetupBuildSecret: func(f *framework.Framework) error {
+			bitbucketToken := utils.GetEnv(constants.BITBUCKET_BOT_TOKEN_ENV, "")
+			if bitbucketToken == "" {
+				return fmt.Errorf("Bitbucket token environment variable %s is not set", constants.BITBUCKET_BOT_TOKEN_ENV)
+			}
+			bitbucketUsername := utils.GetEnv(constants.BITBUCKET_USERNAME_ENV, "")
+			return build.CreateBitbucketBuildSecret(f, "pipelines-as-code-secret", map[string]string{}, bitbucketUsername, bitbucketToken)
+		},
+
+		// Bitbucket repository slugs are lowercase
+		BuildTargetRepoName: func(baseRepoName string) string {
+			return strings.ToLower(fmt.Sprintf("%s/%s", bitbucketWorkspace, baseRepoName+"-"+util.GenerateRandomString(6)))
+		},
+
+		BuildTargetRepoURL: func(org, repoName string) string {
+			return fmt.Sprintf(bitbucketUrlFormat, repoName)
+		},
+	})
 }
 
 // SetupGitProviderWithConfig sets up a git provider using the registry configuration
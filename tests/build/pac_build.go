@@ -444,14 +444,10 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 				Expect(expiration).To(Equal(utils.GetEnv(constants.IMAGE_TAG_EXPIRATION_ENV, constants.DefaultImageTagExpiration)))
 			})
 			It("eventually leads to the PipelineRun status report at Checks tab", func() {
-				switch gitProvider {
-				case git.GitHubProvider:
-					expectedCheckRunName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
-					Expect(f.AsKubeAdmin.CommonController.Github.GetCheckRunConclusion(expectedCheckRunName, helloWorldRepository, prHeadSha, prNumber)).To(Equal(constants.CheckrunConclusionSuccess))
-				case git.GitLabProvider:
-					expectedStatusName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
-					Expect(f.AsKubeAdmin.HasController.GitLab.GetCommitStatusConclusion(expectedStatusName, helloWorldRepository, prHeadSha, prNumber)).To(Equal(constants.CheckrunConclusionSuccess))
-				}
+				expectedStatusName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
+				status, err := git.WaitForCommitStatus(gitClient, helloWorldRepository, prHeadSha, expectedStatusName, time.Minute*10)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(status.State).To(Equal(git.CommitStatusSuccess))
 			})
 		})
 
@@ -509,14 +505,10 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 				createdFileSHA = plr.Labels["pipelinesascode.tekton.dev/sha"]
 			})
 			It("eventually leads to another update of a PR about the PipelineRun status report at Checks tab", func() {
-				switch gitProvider {
-				case git.GitHubProvider:
-					expectedCheckRunName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
-					Expect(f.AsKubeAdmin.CommonController.Github.GetCheckRunConclusion(expectedCheckRunName, helloWorldRepository, createdFileSHA, prNumber)).To(Equal(constants.CheckrunConclusionSuccess))
-				case git.GitLabProvider:
-					expectedStatusName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
-					Expect(f.AsKubeAdmin.HasController.GitLab.GetCommitStatusConclusion(expectedStatusName, helloWorldRepository, createdFileSHA, prNumber)).To(Equal(constants.CheckrunConclusionSuccess))
-				}
+				expectedStatusName := fmt.Sprintf("%s-%s", customBranchComponentName, "on-pull-request")
+				status, err := git.WaitForCommitStatus(gitClient, helloWorldRepository, createdFileSHA, expectedStatusName, time.Minute*10)
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(status.State).To(Equal(git.CommitStatusSuccess))
 			})
 		})
 
@@ -113,6 +113,9 @@ var _ = framework.BuildSuiteDescribe("Build service E2E tests", Label("build-ser
 				Expect(err).ShouldNot(HaveOccurred())
 
 				componentDependenciesChildRepository = childRepository
+
+			default:
+				Skip(fmt.Sprintf("component update with renovate is not covered for the %s git provider", gitPrefix))
 			}
 			ParentComponentDef.componentName = fmt.Sprintf("%s-multi-component-parent-%s", gitPrefix, branchString)
 			ChildComponentDef.componentName = fmt.Sprintf("%s-multi-component-child-%s", gitPrefix, branchString)
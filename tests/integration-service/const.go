@@ -20,27 +20,25 @@ const (
 	autoReleasePlan                = "auto-releaseplan"
 	targetReleaseNamespace         = "default"
 
-	componentRepoNameForResolution            = "konflux-test-integration-resolution"
-	componentRepoNameForGeneralIntegration    = "konflux-test-integration"
-	componentRepoNameForGroupIntegration      = "konflux-test-integration-clone"
-	componentRepoNameForStatusReporting       = "konflux-test-integration-status-report"
-	multiComponentRepoNameForGroupSnapshot    = "group-snapshot-multi-component"
-	multiComponentDefaultBranch               = "onboarding"
-	multiComponentGitRevision                 = "0d1835404efb8ab7bb1ab5b5b82cda1ebfda4b25"
-	multiRepoComponentGitRevision             = "79402df023e646c5ad108abc879ad1b28799cbc4"
-	gitlabComponentRepoName                   = "hacbs-test-project-integration"
-	componentDefaultBranch                    = "onboarding"
-	fallbackBranchName                        = "main"
-	componentRevision                         = "79402df023e646c5ad108abc879ad1b28799cbc4"
-	referenceDoesntExist                      = "Reference does not exist"
-	checkrunStatusCompleted                   = "completed"
-	checkrunConclusionSuccess                 = "success"
-	checkrunConclusionFailure                 = "failure"
-	integrationPipelineRunCommitStatusSuccess = "success"
-	integrationPipelineRunCommitStatusFail    = "failed"
-	spaceRequestCronJobNamespace              = "spacerequest-cleaner"
-	spaceRequestCronJobName                   = "spacerequest-cleaner"
-	spaceRequestNamePrefix                    = "task-spacerequest-"
+	componentRepoNameForResolution         = "konflux-test-integration-resolution"
+	componentRepoNameForGeneralIntegration = "konflux-test-integration"
+	componentRepoNameForGroupIntegration   = "konflux-test-integration-clone"
+	componentRepoNameForStatusReporting    = "konflux-test-integration-status-report"
+	multiComponentRepoNameForGroupSnapshot = "group-snapshot-multi-component"
+	multiComponentDefaultBranch            = "onboarding"
+	multiComponentGitRevision              = "0d1835404efb8ab7bb1ab5b5b82cda1ebfda4b25"
+	multiRepoComponentGitRevision          = "79402df023e646c5ad108abc879ad1b28799cbc4"
+	gitlabComponentRepoName                = "hacbs-test-project-integration"
+	componentDefaultBranch                 = "onboarding"
+	fallbackBranchName                     = "main"
+	componentRevision                      = "79402df023e646c5ad108abc879ad1b28799cbc4"
+	referenceDoesntExist                   = "Reference does not exist"
+	checkrunStatusCompleted                = "completed"
+	checkrunConclusionSuccess              = "success"
+	checkrunConclusionFailure              = "failure"
+	spaceRequestCronJobNamespace           = "spacerequest-cleaner"
+	spaceRequestCronJobName                = "spacerequest-cleaner"
+	spaceRequestNamePrefix                 = "task-spacerequest-"
 
 	snapshotAnnotation                       = "appstudio.openshift.io/snapshot"
 	scenarioAnnotation                       = "test.appstudio.openshift.io/scenario"
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/devfile/library/v2/pkg/util"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
 	"github.com/konflux-ci/e2e-tests/pkg/clients/has"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/framework"
@@ -38,6 +39,7 @@ var _ = framework.IntegrationServiceSuiteDescribe("Gitlab Status Reporting of In
 	var applicationName, componentName, componentBaseBranchName, pacBranchName, testNamespace string
 	var mergeResult *gitlab.MergeRequest
 	var mergeResultSha string
+	var gitClient git.Client
 
 	ginkgo.AfterEach(framework.ReportFailure(&f))
 
@@ -68,6 +70,7 @@ var _ = framework.IntegrationServiceSuiteDescribe("Gitlab Status Reporting of In
 			componentBaseBranchName = fmt.Sprintf("base-gitlab-%s", util.GenerateRandomString(6))
 
 			projectID = gitlabProjectIDForStatusReporting
+			gitClient = git.NewGitlabClient(f.AsKubeAdmin.CommonController.Gitlab)
 
 			gitlabToken = utils.GetEnv(constants.GITLAB_BOT_TOKEN_ENV, "")
 			gomega.Expect(gitlabToken).ShouldNot(gomega.BeEmpty(), fmt.Sprintf("'%s' env var is not set", constants.GITLAB_BOT_TOKEN_ENV))
@@ -203,59 +206,38 @@ var _ = framework.IntegrationServiceSuiteDescribe("Gitlab Status Reporting of In
 			})
 
 			ginkgo.It("validates the Integration test scenario PipelineRun is reported to merge request CommitStatus, and it pass", func() {
-				gomega.Eventually(func() string {
-					commitStatuses, _, err := f.AsKubeAdmin.CommonController.Gitlab.GetClient().Commits.GetCommitStatuses(projectID, mrSha, nil)
-					gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
-
-					for _, commitStatus := range commitStatuses {
-						commitStatusNames := strings.Split(commitStatus.Name, "/")
-						if len(commitStatusNames) > 2 {
-							if strings.Contains(integrationTestScenarioPass.Name, strings.TrimSpace(commitStatusNames[1])) {
-								return commitStatus.Status
-							}
-						}
-					}
-					return ""
-				}, shortTimeout, constants.PipelineRunPollingInterval).Should(gomega.Equal(integrationPipelineRunCommitStatusSuccess), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioPass.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred(), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusSuccess))
 			})
 
 			ginkgo.It("eventually leads to the integration test PipelineRun's Pass status reported at MR commit status", func() {
-				gomega.Expect(f.AsKubeAdmin.HasController.GitLab.GetCommitStatusConclusion(integrationTestScenarioPass.Name, projectID, mrSha, mrID)).To(gomega.Equal(integrationPipelineRunCommitStatusSuccess))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioPass.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusSuccess))
 			})
 
 			ginkgo.It("validates the Integration test scenario PipelineRun is reported to merge request CommitStatus, and it fails", func() {
-				gomega.Eventually(func() string {
-					commitStatuses, _, err := f.AsKubeAdmin.CommonController.Gitlab.GetClient().Commits.GetCommitStatuses(projectID, mrSha, nil)
-					gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
-
-					for _, commitStatus := range commitStatuses {
-						commitStatusNames := strings.Split(commitStatus.Name, "/")
-						if len(commitStatusNames) > 2 {
-							if strings.Contains(integrationTestScenarioFail.Name, strings.TrimSpace(commitStatusNames[1])) {
-								return commitStatus.Status
-							}
-						}
-					}
-					return ""
-				}, longTimeout, constants.PipelineRunPollingInterval).Should(gomega.Equal(integrationPipelineRunCommitStatusFail), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioFail.Name, longTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred(), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusFailure))
 			})
 
 			ginkgo.It("eventually leads to the integration test PipelineRun's Fail status reported at MR commit status", func() {
-				gomega.Expect(f.AsKubeAdmin.HasController.GitLab.GetCommitStatusConclusion(integrationTestScenarioFail.Name, projectID, mrSha, mrID)).To(gomega.Equal(integrationPipelineRunCommitStatusFail))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioFail.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusFailure))
 			})
 
 			ginkgo.It("validates at least one MR note contains the final integration test result", func() {
 				gomega.Eventually(func() bool {
-					notes, _, err := f.AsKubeAdmin.CommonController.Gitlab.GetClient().Notes.ListMergeRequestNotes(projectID, mrID, nil)
+					comments, err := gitClient.ListPullRequestComments(projectID, mrID)
 					if err != nil {
 						ginkgo.GinkgoWriter.Printf("failed to list MR notes: %v\n", err)
 						return false
 					}
-					for _, note := range notes {
-						if note.System {
-							continue
-						}
-						body := note.Body
+					for _, comment := range comments {
+						body := comment.Body
 						if strings.Contains(body, integrationTestScenarioPass.Name) || strings.Contains(body, integrationTestScenarioFail.Name) {
 							return true
 						}
@@ -299,45 +281,27 @@ var _ = framework.IntegrationServiceSuiteDescribe("Gitlab Status Reporting of In
 			})
 
 			ginkgo.It("validates the Integration test scenario PipelineRun is reported to merge request CommitStatus, and it pass", func() {
-				gomega.Eventually(func() string {
-					commitStatuses, _, err := f.AsKubeAdmin.CommonController.Gitlab.GetClient().Commits.GetCommitStatuses(projectID, mrSha, nil)
-					gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
-
-					for _, commitStatus := range commitStatuses {
-						commitStatusNames := strings.Split(commitStatus.Name, "/")
-						if len(commitStatusNames) > 2 {
-							if strings.Contains(integrationTestScenarioPass.Name, strings.TrimSpace(commitStatusNames[1])) {
-								return commitStatus.Status
-							}
-						}
-					}
-					return ""
-				}, shortTimeout, constants.PipelineRunPollingInterval).Should(gomega.Equal(integrationPipelineRunCommitStatusSuccess), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioPass.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred(), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusSuccess))
 			})
 
 			ginkgo.It("eventually leads to the integration test PipelineRun's Pass status reported at MR commit status", func() {
-				gomega.Expect(f.AsKubeAdmin.HasController.GitLab.GetCommitStatusConclusion(integrationTestScenarioPass.Name, projectID, mrSha, mrID)).To(gomega.Equal(constants.CheckrunConclusionSuccess))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioPass.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusSuccess))
 			})
 
 			ginkgo.It("validates the Integration test scenario PipelineRun is reported to merge request CommitStatus, and it fails", func() {
-				gomega.Eventually(func() string {
-					commitStatuses, _, err := f.AsKubeAdmin.CommonController.Gitlab.GetClient().Commits.GetCommitStatuses(projectID, mrSha, nil)
-					gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
-
-					for _, commitStatus := range commitStatuses {
-						commitStatusNames := strings.Split(commitStatus.Name, "/")
-						if len(commitStatusNames) > 2 {
-							if strings.Contains(integrationTestScenarioFail.Name, strings.TrimSpace(commitStatusNames[1])) {
-								return commitStatus.Status
-							}
-						}
-					}
-					return ""
-				}, shortTimeout, constants.PipelineRunPollingInterval).Should(gomega.Equal(integrationPipelineRunCommitStatusFail), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioFail.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred(), fmt.Sprintf("timed out when waiting for expected commitStatus to be created for sha %s in %s repository", mrSha, componentRepoNameForStatusReporting))
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusFailure))
 			})
 
 			ginkgo.It("eventually leads to the integration test PipelineRun's Fail status reported at MR commit status", func() {
-				gomega.Expect(f.AsKubeAdmin.HasController.GitLab.GetCommitStatusConclusion(integrationTestScenarioFail.Name, projectID, mrSha, mrID)).To(gomega.Equal(integrationPipelineRunCommitStatusFail))
+				status, err := git.WaitForCommitStatus(gitClient, projectID, mrSha, integrationTestScenarioFail.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusFailure))
 			})
 		})
 	})
@@ -9,6 +9,7 @@ import (
 
 	"github.com/devfile/library/v2/pkg/util"
 	"github.com/google/go-github/v44/github"
+	"github.com/konflux-ci/e2e-tests/pkg/clients/git"
 	"github.com/konflux-ci/e2e-tests/pkg/constants"
 	"github.com/konflux-ci/e2e-tests/pkg/framework"
 	"github.com/konflux-ci/e2e-tests/pkg/utils"
@@ -38,6 +39,7 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 	var applicationName, componentName, componentBaseBranchName, pacBranchName, testNamespace string
 	var mergeResult *github.PullRequestMergeResult
 	var labels, annotations map[string]string
+	var gitClient git.Client
 
 	ginkgo.AfterEach(framework.ReportFailure(&f))
 
@@ -50,6 +52,7 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 			f, err = framework.NewFramework(utils.GetGeneratedNamespace("stat-rep"))
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			testNamespace = f.UserNamespace
+			gitClient = git.NewGitHubClient(f.AsKubeAdmin.CommonController.Github)
 
 			if utils.IsPrivateHostname(f.OpenshiftConsoleHost) {
 				ginkgo.Skip("Using private cluster (not reachable from Github), skipping...")
@@ -131,11 +134,16 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 
 			ginkgo.It("initialized integration test status is reported to github", func() {
 				gomega.Eventually(func() error {
-					status, err := f.AsKubeAdmin.CommonController.Github.GetCheckRunStatus(integrationTestScenarioPass.Name, componentRepoNameForStatusReporting, prHeadSha, prNumber)
-					if status != "queued" || err != nil {
+					statuses, err := gitClient.ListCommitStatuses(componentRepoNameForStatusReporting, prHeadSha)
+					if err != nil {
 						return fmt.Errorf("error occurred when checking pending integration test checkRun %v", err)
 					}
-					return nil
+					for _, status := range statuses {
+						if strings.Contains(status.Name, integrationTestScenarioPass.Name) && status.State == git.CommitStatusPending {
+							return nil
+						}
+					}
+					return fmt.Errorf("pending integration test checkRun of %s was not found", integrationTestScenarioPass.Name)
 				}, longTimeout, constants.PipelineRunPollingInterval).Should(gomega.Succeed(), fmt.Sprintf("timed out when waiting for the pending checkrun for the component  %s/%s and integrationTestScenarioPass %s", testNamespace, componentName, integrationTestScenarioPass.Name))
 			})
 
@@ -203,15 +211,21 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 			})
 
 			ginkgo.It("eventually leads to the status reported at Checks tab for the successful Integration PipelineRun", func() {
-				gomega.Expect(f.AsKubeAdmin.CommonController.Github.GetCheckRunConclusion(integrationTestScenarioPass.Name, componentRepoNameForStatusReporting, prHeadSha, prNumber)).To(gomega.Equal(constants.CheckrunConclusionSuccess))
+				status, err := git.WaitForCommitStatus(gitClient, componentRepoNameForStatusReporting, prHeadSha, integrationTestScenarioPass.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusSuccess))
 			})
 
 			ginkgo.It("eventually leads to the status reported at Checks tab for the failed Integration PipelineRun", func() {
-				gomega.Expect(f.AsKubeAdmin.CommonController.Github.GetCheckRunConclusion(integrationTestScenarioFail.Name, componentRepoNameForStatusReporting, prHeadSha, prNumber)).To(gomega.Equal(constants.CheckrunConclusionFailure))
+				status, err := git.WaitForCommitStatus(gitClient, componentRepoNameForStatusReporting, prHeadSha, integrationTestScenarioFail.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusFailure))
 			})
 
 			ginkgo.It("eventually leads to the status reported at Checks tab for the optional Integration PipelineRun", func() {
-				gomega.Expect(f.AsKubeAdmin.CommonController.Github.GetCheckRunConclusion(integrationTestScenarioOptional.Name, componentRepoNameForStatusReporting, prHeadSha, prNumber)).To(gomega.Equal(constants.CheckrunConclusionNeutral))
+				status, err := git.WaitForCommitStatus(gitClient, componentRepoNameForStatusReporting, prHeadSha, integrationTestScenarioOptional.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusNeutral))
 			})
 
 			ginkgo.It("checks if the optional Integration Test Scenario status is reported in the Snapshot", func() {
@@ -263,12 +277,16 @@ var _ = framework.IntegrationServiceSuiteDescribe("Status Reporting of Integrati
 			})
 
 			ginkgo.It("validates the Integration test scenario PipelineRun is reported to merge request CheckRuns, and it pass", func() {
-				gomega.Expect(f.AsKubeAdmin.CommonController.Github.GetCheckRunConclusion(integrationTestScenarioPass.Name, componentRepoNameForStatusReporting, prHeadSha, prNumber)).To(gomega.Equal(constants.CheckrunConclusionSuccess))
+				status, err := git.WaitForCommitStatus(gitClient, componentRepoNameForStatusReporting, prHeadSha, integrationTestScenarioPass.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusSuccess))
 
 			})
 
 			ginkgo.It("eventually leads to the status reported at Checks tab for the failed Integration PipelineRun", func() {
-				gomega.Expect(f.AsKubeAdmin.CommonController.Github.GetCheckRunConclusion(integrationTestScenarioFail.Name, componentRepoNameForStatusReporting, prHeadSha, prNumber)).To(gomega.Equal(constants.CheckrunConclusionFailure))
+				status, err := git.WaitForCommitStatus(gitClient, componentRepoNameForStatusReporting, prHeadSha, integrationTestScenarioFail.Name, shortTimeout)
+				gomega.Expect(err).ShouldNot(gomega.HaveOccurred())
+				gomega.Expect(status.State).To(gomega.Equal(git.CommitStatusFailure))
 			})
 		})
 
@@ -1,10 +1,12 @@
 package main
 
 import "fmt"
+import "os"
 import "time"
 
 import journey "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/journey"
 import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+import results "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/results"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
 
@@ -47,6 +49,8 @@ func init() {
 	rootCmd.Flags().DurationVar(&opts.StartupDelay, "startup-delay", 0, "when starting per user/per application/per client treads, wait for this duration")
 	rootCmd.Flags().DurationVar(&opts.StartupJitter, "startup-jitter", 3*time.Second, "when applying startup delay, add or remove half of jitter with this maximum value")
 	rootCmd.Flags().BoolVarP(&opts.Purge, "purge", "p", false, "purge all users or resources (on stage) after test is done")
+	rootCmd.Flags().BoolVar(&opts.Resume, "resume", false, "continue unfinished journeys of previous run using load-test-checkpoint.json from --output-dir (with --purge-only just purge resources recorded in it)")
+	rootCmd.Flags().BoolVar(&opts.OverwriteCheckpoint, "overwrite-checkpoint", false, "start new load-test-checkpoint.json even when --output-dir contains one of previous run (resources recorded in it will not be purged anymore)")
 	rootCmd.Flags().BoolVarP(&opts.PurgeOnly, "purge-only", "u", false, "do not run test, only purge resources (this implies --purge)")
 	rootCmd.Flags().StringVar(&opts.TestScenarioGitURL, "test-scenario-git-url", "https://github.com/konflux-ci/integration-examples.git", "test scenario GIT URL (set to \"\" to disable creating these)")
 	rootCmd.Flags().StringVar(&opts.TestScenarioRevision, "test-scenario-revision", "main", "test scenario GIT URL repo revision to use")
@@ -63,7 +67,13 @@ func init() {
 	rootCmd.Flags().BoolVar(&opts.FailFast, "fail-fast", false, "if you want the test to fail fast at first failure")
 	rootCmd.Flags().IntVarP(&opts.Concurrency, "concurrency", "c", 1, "number of concurrent threads to execute")
 	rootCmd.Flags().IntVar(&opts.JourneyRepeats, "journey-repeats", 1, "number of times to repeat user journey (either this or --journey-duration)")
-	rootCmd.Flags().StringVar(&opts.JourneyDuration, "journey-duration", "1h", "repeat user journey until this timeout (either this or --journey-repeats)")
+	rootCmd.Flags().StringVar(&opts.JourneyDuration, "journey-duration", "1h", "repeat user journey until this timeout, journeys still running then are stopped (either this or --journey-repeats)")
+	rootCmd.Flags().StringVar(&opts.ArrivalProfile, "arrival-profile", "", "JSON file with arrival rate profile to start journeys in open-loop mode, users from --concurrency are then reused for journeys (ignores --journey-repeats and --journey-duration)")
+	rootCmd.Flags().StringVar(&opts.ReplayTrace, "replay-trace", "", "file with recorded tenant activity (one JSON event per line) to replay instead of running synthetic journeys, tenants are mapped to users from --concurrency")
+	rootCmd.Flags().Float64Var(&opts.ReplaySpeedup, "replay-speedup", 1, "compress time when replaying trace, e.g. 60 replays an hour of the trace in a minute")
+	rootCmd.Flags().IntVar(&opts.MaxInFlight, "max-in-flight", 0, "maximal number of journeys running at the same time in open-loop mode (0 or more than --concurrency means --concurrency)")
+	rootCmd.Flags().StringVar(&opts.JourneyConfig, "journey-config", "", "JSON file with a list of journey definitions (named lists of application and component steps)")
+	rootCmd.Flags().StringVar(&opts.JourneyName, "journey-name", "default", "name of the journey definition to run, either from --journey-config or built-in \"default\"")
 	rootCmd.Flags().BoolVar(&opts.JourneyReuseApplications, "journey-reuse-applications", false, "when repeating journey, do not create new application (and integration test scenario and release plan and repease plan admission) on every journey repeat")
 	rootCmd.Flags().BoolVar(&opts.JourneyReuseComponents, "journey-reuse-componets", false, "when repeating journey, do not create new component on every journey repeat; this implies --journey-reuse-applications")
 	rootCmd.Flags().BoolVar(&opts.PipelineMintmakerDisabled, "pipeline-mintmaker-disabled", true, "if you want to stop Mintmaker to be creating update PRs for your component (default in loadtest different from Konflux default)")
@@ -71,6 +81,12 @@ func init() {
 	rootCmd.Flags().StringVar(&opts.PipelineRepoTemplatingSource, "pipeline-repo-templating-source", "", "when templating, take template source files from this repository (\"\" means we will get source files from current repo)")
 	rootCmd.Flags().StringVar(&opts.PipelineRepoTemplatingSourceDir, "pipeline-repo-templating-source-dir", "", "when templating from additional repository, take template source files from this directory (\"\" means default \".template/\" will ne used)")
 	rootCmd.Flags().StringArrayVar(&opts.PipelineImagePullSecrets, "pipeline-image-pull-secrets", []string{}, "secret needed to pull task images, can be used multiple times")
+	rootCmd.Flags().StringVar(&opts.MetricsAddress, "metrics-address", "", "expose live Prometheus metrics on /metrics of this address, e.g. \":9090\" (keep empty to disable)")
+	rootCmd.Flags().StringVar(&opts.ChaosConfig, "chaos-config", "", "JSON file with chaos actions (deleting pods, scaling deployments, adding API latency) injected on schedule or at journey steps (keep empty to disable)")
+	rootCmd.Flags().StringVar(&opts.ClusterSamplingConfig, "cluster-sampling-config", "", "JSON file with controller pods, resources and controller metrics endpoints to sample during the run to load-test-cluster-samples.csv (keep empty to disable)")
+	rootCmd.Flags().DurationVar(&opts.ClusterSamplingInterval, "cluster-sampling-interval", 30*time.Second, "how often to sample cluster resources")
+	rootCmd.Flags().StringVar(&opts.SLOConfig, "slo-config", "", "JSON file with per metric SLO thresholds, test exits with non-zero code when some is not met (keep empty to skip SLO evaluation)")
+	rootCmd.Flags().DurationVar(&opts.ResultsWindow, "results-window", 5*time.Minute, "size of time windows throughput is reported for in load-test-summary.json")
 	rootCmd.Flags().StringVarP(&opts.OutputDir, "output-dir", "o", ".", "directory where output files such as load-tests.log or load-tests.json are stored")
 	rootCmd.Flags().StringVar(&opts.BuildPipelineSelectorBundle, "build-pipeline-selector-bundle", "", "BuildPipelineSelector bundle to use when testing with build-definition PR")
 	rootCmd.Flags().BoolVarP(&opts.LogInfo, "log-info", "v", false, "log messages with info level and above")
@@ -119,36 +135,122 @@ func main() {
 	// Show test options
 	logging.Logger.Debug("Options: %+v", &opts)
 
+	// Select journey definition
+	definition, err := journey.LoadDefinition(opts.JourneyConfig, opts.JourneyName)
+	if err != nil {
+		logging.Logger.Fatal("Failed to load journey definition: %v", err)
+	}
+	journey.CurrentDefinition = *definition
+	logging.Logger.Debug("Journey definition: %+v", definition)
+
+	// Load SLOs the results are evaluated against
+	if opts.SLOConfig != "" {
+		slos, err := results.LoadSLOs(opts.SLOConfig)
+		if err != nil {
+			logging.Logger.Fatal("Failed to load SLOs: %v", err)
+		}
+		logging.SetSLOs(slos)
+	}
+
+	// Start or load checkpoint of the run progress
+	err = journey.SetupCheckpoint(&opts)
+	if err != nil {
+		logging.Logger.Fatal("Failed to setup checkpoint: %v", err)
+	}
+
+	// Prepare cluster resources sampling (before chaos injection so it is not slowed down by latency proxy)
+	err = journey.SetupSampling(&opts)
+	if err != nil {
+		logging.Logger.Fatal("Failed to setup cluster sampling: %v", err)
+	}
+
+	// Prepare chaos injection (before any cluster client is created so latency proxy is used by all of them)
+	err = journey.SetupChaos(&opts)
+	if err != nil {
+		logging.Logger.Fatal("Failed to setup chaos injection: %v", err)
+	}
+
+	// Stop journeys on interrupt or once --journey-duration passes
+	journey.SetupJourneysContext(&opts)
+
 	// Tier up measurements logger
-	logging.MeasurementsStart(opts.OutputDir)
+	logging.MeasurementsStart(opts.OutputDir, opts.ResultsWindow)
 
-	// Start given number of `perUserThread()` threads using `journey.PerUserSetup()` and wait for them to finish
-	_, err = logging.Measure(
-		nil,
-		journey.PerUserSetup,
-		perUserThread,
-		&opts,
-	)
+	// Expose live metrics if requested
+	logging.MetricsStart(opts.MetricsAddress)
+
+	// Start sampling cluster resources
+	journey.StartSampling()
+
+	// Start injecting scheduled chaos actions
+	journey.StartChaos()
+
+	if opts.ReplayTrace != "" {
+		// Perform actions recorded in the trace using `journey.PerUserReplaySetup()` and wait for them to finish
+		_, err = logging.Measure(
+			nil,
+			journey.PerUserReplaySetup,
+			&opts,
+		)
+	} else if opts.ArrivalProfile != "" {
+		// Start `perUserJourney()` journeys at given arrival rate using `journey.PerUserArrivalSetup()` and wait for them to finish
+		_, err = logging.Measure(
+			nil,
+			journey.PerUserArrivalSetup,
+			perUserJourney,
+			&opts,
+		)
+	} else {
+		// Start given number of `perUserThread()` threads using `journey.PerUserSetup()` and wait for them to finish
+		_, err = logging.Measure(
+			nil,
+			journey.PerUserSetup,
+			perUserThread,
+			&opts,
+		)
+	}
 	if err != nil {
 		logging.Logger.Fatal("Threads setup failed: %v", err)
 	}
 
+	// Stop watching resources journeys were waiting for
+	journey.StopInformers()
+
+	// Stop sampling cluster resources
+	journey.StopSampling()
+
 	// Cleanup resources
 	_, err = logging.Measure(
 		nil,
 		journey.Purge,
+		&opts,
 	)
 	if err != nil {
 		logging.Logger.Error("Purging failed: %v", err)
 	}
 
+	// Stop injecting chaos, revert what is still in effect and close latency proxy (purging still used it)
+	err = journey.StopChaos()
+	if err != nil {
+		logging.Logger.Error("Stopping chaos injection failed: %v", err)
+	}
+
 	// Tier down measurements logger
 	logging.MeasurementsStop()
+
+	// Fail if performance regressed
+	if !logging.SLOsPassed() {
+		logging.Logger.Error("Some SLOs were not met, see %s/load-test-slo-junit.xml", opts.OutputDir)
+		klog.Flush()
+		os.Exit(1)
+	}
 }
 
 // Single user journey
 func perUserThread(perUserCtx *types.PerUserContext) {
 	defer perUserCtx.PerUserWG.Done()
+	logging.ThreadStarted(logging.ThreadUser)
+	defer logging.ThreadFinished(logging.ThreadUser)
 
 	time.Sleep(perUserCtx.StartupPause)
 
@@ -231,18 +333,10 @@ func perUserThread(perUserCtx *types.PerUserContext) {
 	//watcher.Stop()
 	//os.Exit(10)
 
-	for perUserCtx.JourneyRepeatsCounter = 0; perUserCtx.JourneyRepeatsCounter < perUserCtx.Opts.JourneyRepeats; perUserCtx.JourneyRepeatsCounter++ {
+	// Counter starts from number of journeys done in previous run when resuming
+	for ; perUserCtx.JourneyRepeatsCounter < perUserCtx.Opts.JourneyRepeats; perUserCtx.JourneyRepeatsCounter++ {
 
-		// Start given number of `perApplicationThread()` threads using `journey.PerApplicationSetup()` and wait for them to finish
-		_, err = logging.Measure(
-			perUserCtx,
-			journey.PerApplicationSetup,
-			perApplicationThread,
-			perUserCtx,
-		)
-		if err != nil {
-			logging.Logger.Fatal("Per application threads setup failed: %v", err)
-		}
+		perUserJourney(perUserCtx)
 
 		// Check if we are supposed to quit based on --journey-duration
 		if time.Now().UTC().After(perUserCtx.Opts.JourneyUntil) {
@@ -265,9 +359,27 @@ func perUserThread(perUserCtx *types.PerUserContext) {
 
 }
 
+// Single run of user journey (one in closed-loop mode repeat or one open-loop mode arrival)
+func perUserJourney(perUserCtx *types.PerUserContext) {
+	// Start given number of `perApplicationThread()` threads using `journey.PerApplicationSetup()` and wait for them to finish
+	_, err := logging.Measure(
+		perUserCtx,
+		journey.PerApplicationSetup,
+		perApplicationThread,
+		perUserCtx,
+	)
+	if err != nil {
+		logging.Logger.Fatal("Per application threads setup failed: %v", err)
+	}
+
+	journey.JourneyDone(perUserCtx)
+}
+
 // Single application journey (there can be multiple parallel apps per user)
 func perApplicationThread(perApplicationCtx *types.PerApplicationContext) {
 	defer perApplicationCtx.PerApplicationWG.Done()
+	logging.ThreadStarted(logging.ThreadApplication)
+	defer logging.ThreadFinished(logging.ThreadApplication)
 	defer func() {
 		_, err := logging.Measure(
 			perApplicationCtx,
@@ -294,34 +406,8 @@ func perApplicationThread(perApplicationCtx *types.PerApplicationContext) {
 		return
 	}
 
-	// Create application
-	_, err = logging.Measure(
-		perApplicationCtx,
-		journey.HandleApplication,
-		perApplicationCtx,
-	)
-	if err != nil {
-		logging.Logger.Error("Per application thread failed: %v", err)
-		return
-	}
-
-	// Create integration test scenario
-	_, err = logging.Measure(
-		perApplicationCtx,
-		journey.HandleIntegrationTestScenario,
-		perApplicationCtx,
-	)
-	if err != nil {
-		logging.Logger.Error("Per application thread failed: %v", err)
-		return
-	}
-
-	// Create release plan and release plan admission
-	_, err = logging.Measure(
-		perApplicationCtx,
-		journey.HandleReleaseSetup,
-		perApplicationCtx,
-	)
+	// Run application steps of selected journey definition
+	err = journey.RunApplicationSteps(perApplicationCtx)
 	if err != nil {
 		logging.Logger.Error("Per application thread failed: %v", err)
 		return
@@ -343,6 +429,8 @@ func perApplicationThread(perApplicationCtx *types.PerApplicationContext) {
 // Single component journey (there can be multiple parallel comps per app)
 func perComponentThread(perComponentCtx *types.PerComponentContext) {
 	defer perComponentCtx.PerComponentWG.Done()
+	logging.ThreadStarted(logging.ThreadComponent)
+	defer logging.ThreadFinished(logging.ThreadComponent)
 	defer func() {
 		_, err := logging.Measure(
 			perComponentCtx,
@@ -369,45 +457,8 @@ func perComponentThread(perComponentCtx *types.PerComponentContext) {
 		return
 	}
 
-	// Create component
-	_, err = logging.Measure(
-		perComponentCtx,
-		journey.HandleComponent,
-		perComponentCtx,
-	)
-	if err != nil {
-		logging.Logger.Error("Per component thread failed: %v", err)
-		return
-	}
-
-	// Wait for build pipiline run
-	_, err = logging.Measure(
-		perComponentCtx,
-		journey.HandlePipelineRun,
-		perComponentCtx,
-	)
-	if err != nil {
-		logging.Logger.Error("Per component thread failed: %v", err)
-		return
-	}
-
-	// Wait for test pipiline run
-	_, err = logging.Measure(
-		perComponentCtx,
-		journey.HandleTest,
-		perComponentCtx,
-	)
-	if err != nil {
-		logging.Logger.Error("Per component thread failed: %v", err)
-		return
-	}
-
-	// Wait for release to finish
-	_, err = logging.Measure(
-		perComponentCtx,
-		journey.HandleReleaseRun,
-		perComponentCtx,
-	)
+	// Run component steps of selected journey definition
+	err = journey.RunComponentSteps(perComponentCtx)
 	if err != nil {
 		logging.Logger.Error("Per component thread failed: %v", err)
 		return
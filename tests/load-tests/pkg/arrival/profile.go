@@ -0,0 +1,181 @@
+package arrival
+
+import "encoding/json"
+import "fmt"
+import "math"
+import "os"
+import "path/filepath"
+import "time"
+
+// Types of arrival profile stages
+const Constant = "constant" // 'rate' journeys per minute for the whole stage
+const Ramp = "ramp"         // rate changes linearly from 'from' to 'to'
+const Step = "step"         // rate changes from 'from' to 'to' in 'steps' equal steps
+const Spike = "spike"       // 'rate' except for 'peak' rate between 'spikeStart' and 'spikeStart' + 'spikeDuration'
+
+// Resolution used when converting arrival rate into journey start times
+const scheduleResolution = 100 * time.Millisecond
+
+// Represents one stage of the arrival profile, rates are journeys per minute
+type Stage struct {
+	Type          string  `json:"type"`
+	Duration      string  `json:"duration"`
+	Rate          float64 `json:"rate,omitempty"`
+	From          float64 `json:"from,omitempty"`
+	To            float64 `json:"to,omitempty"`
+	Steps         int     `json:"steps,omitempty"`
+	Peak          float64 `json:"peak,omitempty"`
+	SpikeStart    string  `json:"spikeStart,omitempty"`
+	SpikeDuration string  `json:"spikeDuration,omitempty"`
+
+	duration      time.Duration
+	spikeStart    time.Duration
+	spikeDuration time.Duration
+}
+
+// Represents the arrival profile, stages are run one after another
+type Profile struct {
+	Stages []Stage `json:"stages"`
+}
+
+// Load arrival profile from JSON file and check it is sane
+func Load(filePath string) (*Profile, error) {
+	filePath = filepath.Clean(filePath)
+	jsonData, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile Profile
+	err = json.Unmarshal(jsonData, &profile)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing arrival profile %s: %v", filePath, err)
+	}
+
+	err = profile.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid arrival profile %s: %v", filePath, err)
+	}
+
+	return &profile, nil
+}
+
+// Parse stage durations and check all the stages are sane
+func (p *Profile) Validate() error {
+	if len(p.Stages) == 0 {
+		return fmt.Errorf("no stages defined")
+	}
+
+	for i := range p.Stages {
+		err := p.Stages[i].validate()
+		if err != nil {
+			return fmt.Errorf("stage %d: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Stage) validate() error {
+	var err error
+
+	s.duration, err = time.ParseDuration(s.Duration)
+	if err != nil {
+		return fmt.Errorf("failed to parse duration: %v", err)
+	}
+	if s.duration <= 0 {
+		return fmt.Errorf("duration has to be positive")
+	}
+	if s.Rate < 0 || s.From < 0 || s.To < 0 || s.Peak < 0 {
+		return fmt.Errorf("rates can not be negative")
+	}
+
+	switch s.Type {
+	case Constant, Ramp:
+	case Step:
+		if s.Steps < 1 {
+			return fmt.Errorf("step stage needs at least one step")
+		}
+	case Spike:
+		s.spikeStart, err = time.ParseDuration(s.SpikeStart)
+		if err != nil {
+			return fmt.Errorf("failed to parse spike start: %v", err)
+		}
+		s.spikeDuration, err = time.ParseDuration(s.SpikeDuration)
+		if err != nil {
+			return fmt.Errorf("failed to parse spike duration: %v", err)
+		}
+		if s.spikeStart < 0 || s.spikeDuration <= 0 || s.spikeStart+s.spikeDuration > s.duration {
+			return fmt.Errorf("spike has to fit into the stage")
+		}
+	default:
+		return fmt.Errorf("unknown stage type %q", s.Type)
+	}
+
+	return nil
+}
+
+// Arrival rate in journeys per minute at given offset from the stage start
+func (s *Stage) rateAt(offset time.Duration) float64 {
+	switch s.Type {
+	case Ramp:
+		return s.From + (s.To-s.From)*offset.Seconds()/s.duration.Seconds()
+	case Step:
+		if s.Steps == 1 {
+			return s.To
+		}
+		step := math.Min(math.Floor(offset.Seconds()/s.duration.Seconds()*float64(s.Steps)), float64(s.Steps-1))
+		return s.From + (s.To-s.From)*step/float64(s.Steps-1)
+	case Spike:
+		if offset >= s.spikeStart && offset < s.spikeStart+s.spikeDuration {
+			return s.Peak
+		}
+		return s.Rate
+	default:
+		return s.Rate
+	}
+}
+
+// Total duration of all the stages
+func (p *Profile) Duration() time.Duration {
+	var total time.Duration
+	for _, s := range p.Stages {
+		total += s.duration
+	}
+	return total
+}
+
+// Arrival rate in journeys per minute at given offset from the profile start
+func (p *Profile) RateAt(offset time.Duration) float64 {
+	for i := range p.Stages {
+		if offset < p.Stages[i].duration {
+			return p.Stages[i].rateAt(offset)
+		}
+		offset -= p.Stages[i].duration
+	}
+	return 0
+}
+
+// Compute offsets from the profile start when journeys should be started
+// Expected number of arrivals is integrated over time and journey is
+// started whenever it crosses next whole number.
+func (p *Profile) Schedule() []time.Duration {
+	var schedule []time.Duration
+	var expected float64
+
+	total := p.Duration()
+	for offset := time.Duration(0); offset < total; offset += scheduleResolution {
+		step := scheduleResolution
+		if offset+step > total {
+			step = total - offset
+		}
+		// Use rate in the middle of the interval so linear ramps are integrated exactly
+		expected += p.RateAt(offset+step/2) / 60 * step.Seconds()
+		for expected >= 1-1e-9 {
+			schedule = append(schedule, offset+step)
+			expected--
+		}
+	}
+
+	return schedule
+}
@@ -0,0 +1,86 @@
+package arrival
+
+import "testing"
+import "time"
+
+func mustValidate(t *testing.T, p *Profile) *Profile {
+	if err := p.Validate(); err != nil {
+		t.Fatalf("Unexpected validation error: %v", err)
+	}
+	return p
+}
+
+// Test number and spacing of arrivals computed for various stage types.
+func Test_Schedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  *Profile
+		arrivals int
+	}{
+		{"constant", &Profile{Stages: []Stage{{Type: Constant, Duration: "10m", Rate: 6}}}, 60},
+		{"ramp", &Profile{Stages: []Stage{{Type: Ramp, Duration: "10m", From: 0, To: 12}}}, 60},
+		{"step", &Profile{Stages: []Stage{{Type: Step, Duration: "9m", From: 2, To: 6, Steps: 3}}}, 36},
+		{"spike", &Profile{Stages: []Stage{{Type: Spike, Duration: "10m", Rate: 1, Peak: 31, SpikeStart: "2m", SpikeDuration: "1m"}}}, 40},
+		{"stages", &Profile{Stages: []Stage{{Type: Constant, Duration: "1m", Rate: 30}, {Type: Constant, Duration: "1m", Rate: 0}}}, 30},
+	}
+	for _, test := range tests {
+		schedule := mustValidate(t, test.profile).Schedule()
+		if len(schedule) != test.arrivals {
+			t.Errorf("%s: expected %d arrivals, got %d", test.name, test.arrivals, len(schedule))
+		}
+		for i := 1; i < len(schedule); i++ {
+			if schedule[i] < schedule[i-1] {
+				t.Errorf("%s: arrivals not ordered at %d", test.name, i)
+			}
+		}
+	}
+
+	schedule := mustValidate(t, &Profile{Stages: []Stage{{Type: Constant, Duration: "1m", Rate: 6}}}).Schedule()
+	if schedule[0] != 10*time.Second || schedule[5] != time.Minute {
+		t.Errorf("Unexpected constant rate schedule: %v", schedule)
+	}
+}
+
+// Test rate reported for offsets in different stages.
+func Test_RateAt(t *testing.T) {
+	p := mustValidate(t, &Profile{Stages: []Stage{
+		{Type: Ramp, Duration: "10m", From: 0, To: 10},
+		{Type: Step, Duration: "3m", From: 1, To: 3, Steps: 3},
+		{Type: Spike, Duration: "10m", Rate: 1, Peak: 5, SpikeStart: "5m", SpikeDuration: "1m"},
+	}})
+
+	checks := map[time.Duration]float64{
+		5 * time.Minute:                 5,
+		10*time.Minute + 30*time.Second: 1,
+		11*time.Minute + 30*time.Second: 2,
+		12*time.Minute + 59*time.Second: 3,
+		13 * time.Minute:                1,
+		18*time.Minute + 30*time.Second: 5,
+		24 * time.Minute:                0,
+	}
+	for offset, expected := range checks {
+		if rate := p.RateAt(offset); rate != expected {
+			t.Errorf("Expected rate %f at %v, got %f", expected, offset, rate)
+		}
+	}
+	if p.Duration() != 23*time.Minute {
+		t.Errorf("Unexpected profile duration %v", p.Duration())
+	}
+}
+
+// Test invalid profiles are refused.
+func Test_Validate(t *testing.T) {
+	profiles := []*Profile{
+		{},
+		{Stages: []Stage{{Type: Constant, Duration: "forever", Rate: 1}}},
+		{Stages: []Stage{{Type: Constant, Duration: "1m", Rate: -1}}},
+		{Stages: []Stage{{Type: "wave", Duration: "1m", Rate: 1}}},
+		{Stages: []Stage{{Type: Step, Duration: "1m", From: 1, To: 2}}},
+		{Stages: []Stage{{Type: Spike, Duration: "1m", Rate: 1, Peak: 2, SpikeStart: "50s", SpikeDuration: "20s"}}},
+	}
+	for i, p := range profiles {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Expected profile %d to be invalid", i)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package chaos
+
+import "context"
+import "fmt"
+import "math/rand"
+import "strings"
+import "sync"
+import "time"
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import kubernetes "k8s.io/client-go/kubernetes"
+
+// Represents one injection (or restoration after it), recorded so failures can be correlated with it
+type Event struct {
+	Action   string        // name of the action
+	Type     string        // type of the action, with " restore" suffix when the injection is being reverted
+	Detail   string        // what was affected, e.g. names of deleted pods
+	Duration time.Duration // for how long the injection is supposed to be in effect
+	Err      error
+}
+
+// Injects chaos actions on their schedule or at journey steps
+type Injector struct {
+	client kubernetes.Interface
+	config *Config
+	proxy  *LatencyProxy
+	record func(Event)
+
+	mu         sync.Mutex
+	injections map[string]int
+	active     map[string]bool          // actions whose injection was not restored yet
+	replicas   map[string]int32         // replicas of scaled deployments before any chaos, by namespace/name
+	latencies  map[string]time.Duration // latency of latency actions in effect, by action name
+	ctx        context.Context
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// Create injector using given client for pods and deployments and proxy for latency actions (can be nil if there are none)
+// Every injection is passed to record.
+func NewInjector(client kubernetes.Interface, config *Config, proxy *LatencyProxy, record func(Event)) *Injector {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Injector{
+		client:     client,
+		config:     config,
+		proxy:      proxy,
+		record:     record,
+		injections: map[string]int{},
+		active:     map[string]bool{},
+		replicas:   map[string]int32{},
+		latencies:  map[string]time.Duration{},
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Start injecting scheduled actions
+func (i *Injector) Start() {
+	for idx := range i.config.Actions {
+		a := &i.config.Actions[idx]
+		if a.every == 0 {
+			continue
+		}
+		i.wg.Add(1)
+		go i.schedule(a)
+	}
+}
+
+// Stop injecting and revert all injections still in effect
+func (i *Injector) Stop() {
+	// Cancel under lock so no injection starts after we begin waiting
+	i.mu.Lock()
+	i.cancel()
+	i.mu.Unlock()
+	i.wg.Wait()
+}
+
+func (i *Injector) schedule(a *Action) {
+	defer i.wg.Done()
+
+	wait := a.after
+	for {
+		select {
+		case <-i.ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		if !i.inject(a) {
+			return
+		}
+		wait = a.every
+	}
+}
+
+// Inject actions configured for given journey step, called whenever some journey step starts
+func (i *Injector) AtStep(step string) {
+	for idx := range i.config.Actions {
+		a := &i.config.Actions[idx]
+		if a.AtStep == "" || (a.AtStep != step && !strings.HasSuffix(step, "."+a.AtStep)) {
+			continue
+		}
+		i.inject(a)
+	}
+}
+
+// Inject action unless it was injected maximal number of times already, returns false when it will not be injected anymore
+// Action whose previous injection is still in effect is skipped, so injections of it never overlap.
+func (i *Injector) inject(a *Action) bool {
+	i.mu.Lock()
+	if i.ctx.Err() != nil || (a.MaxInjections > 0 && i.injections[a.Name] >= a.MaxInjections) {
+		i.mu.Unlock()
+		return false
+	}
+	if i.active[a.Name] {
+		i.mu.Unlock()
+		return true
+	}
+	i.injections[a.Name]++
+	if a.Type != DeletePods {
+		i.active[a.Name] = true
+	}
+	i.wg.Add(1)
+	i.mu.Unlock()
+	defer i.wg.Done()
+
+	var detail string
+	var restore func() error
+	var err error
+	switch a.Type {
+	case DeletePods:
+		detail, err = i.deletePods(a)
+	case Scale:
+		detail, restore, err = i.scale(a)
+	case Latency:
+		detail, restore, err = i.addLatency(a)
+	}
+	i.record(Event{Action: a.Name, Type: a.Type, Detail: detail, Duration: a.duration, Err: err})
+
+	if restore == nil {
+		i.setActive(a, false)
+	} else {
+		i.wg.Add(1)
+		go func() {
+			defer i.wg.Done()
+			// Without duration the injection stays in effect until injector stops
+			var expired <-chan time.Time
+			if a.duration > 0 {
+				expired = time.After(a.duration)
+			}
+			select {
+			case <-i.ctx.Done():
+			case <-expired:
+			}
+			i.record(Event{Action: a.Name, Type: a.Type + " restore", Detail: detail, Err: restore()})
+			i.setActive(a, false)
+		}()
+	}
+
+	return true
+}
+
+func (i *Injector) setActive(a *Action, active bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.active[a.Name] = active
+}
+
+func (i *Injector) deletePods(a *Action) (string, error) {
+	pods, err := i.client.CoreV1().Pods(a.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: a.LabelSelector})
+	if err != nil {
+		return "", fmt.Errorf("failed to list pods %s in namespace %s: %v", a.LabelSelector, a.Namespace, err)
+	}
+
+	victims := pods.Items
+	rand.Shuffle(len(victims), func(x, y int) { victims[x], victims[y] = victims[y], victims[x] })
+	if a.Count > 0 && a.Count < len(victims) {
+		victims = victims[:a.Count]
+	}
+
+	var deleted []string
+	for _, pod := range victims {
+		err = i.client.CoreV1().Pods(a.Namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{})
+		if err != nil {
+			return strings.Join(deleted, ","), fmt.Errorf("failed to delete pod %s/%s: %v", a.Namespace, pod.Name, err)
+		}
+		deleted = append(deleted, pod.Name)
+	}
+	if len(deleted) == 0 {
+		return "", fmt.Errorf("no pods %s found in namespace %s", a.LabelSelector, a.Namespace)
+	}
+
+	return strings.Join(deleted, ","), nil
+}
+
+// Scale deployment, returned function scales it back (if duration is 0, it is scaled back when injector stops)
+// Deployment is always scaled back to replicas it had before it was scaled by any action.
+func (i *Injector) scale(a *Action) (string, func() error, error) {
+	deployments := i.client.AppsV1().Deployments(a.Namespace)
+	scale, err := deployments.GetScale(context.Background(), a.Deployment, metav1.GetOptions{})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get scale of deployment %s/%s: %v", a.Namespace, a.Deployment, err)
+	}
+
+	key := a.Namespace + "/" + a.Deployment
+	i.mu.Lock()
+	original, ok := i.replicas[key]
+	if !ok {
+		original = scale.Spec.Replicas
+		i.replicas[key] = original
+	}
+	i.mu.Unlock()
+	detail := fmt.Sprintf("%s/%s %d->%d", a.Namespace, a.Deployment, original, a.Replicas)
+	scale.Spec.Replicas = a.Replicas
+	_, err = deployments.UpdateScale(context.Background(), a.Deployment, scale, metav1.UpdateOptions{})
+	if err != nil {
+		return detail, nil, fmt.Errorf("failed to scale deployment %s/%s: %v", a.Namespace, a.Deployment, err)
+	}
+
+	restore := func() error {
+		scale, err := deployments.GetScale(context.Background(), a.Deployment, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get scale of deployment %s/%s: %v", a.Namespace, a.Deployment, err)
+		}
+		scale.Spec.Replicas = original
+		_, err = deployments.UpdateScale(context.Background(), a.Deployment, scale, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to scale back deployment %s/%s: %v", a.Namespace, a.Deployment, err)
+		}
+		return nil
+	}
+
+	return detail, restore, nil
+}
+
+func (i *Injector) addLatency(a *Action) (string, func() error, error) {
+	if i.proxy == nil {
+		return "", nil, fmt.Errorf("latency proxy is not running")
+	}
+
+	i.mu.Lock()
+	i.latencies[a.Name] = a.latency
+	i.proxy.SetLatency(i.maxLatency())
+	i.mu.Unlock()
+
+	restore := func() error {
+		i.mu.Lock()
+		defer i.mu.Unlock()
+		delete(i.latencies, a.Name)
+		i.proxy.SetLatency(i.maxLatency())
+		return nil
+	}
+
+	return a.latency.String(), restore, nil
+}
+
+// Highest latency of latency actions in effect, 0 when there are none, has to be called with lock held
+func (i *Injector) maxLatency() time.Duration {
+	var latency time.Duration
+	for _, l := range i.latencies {
+		if l > latency {
+			latency = l
+		}
+	}
+	return latency
+}
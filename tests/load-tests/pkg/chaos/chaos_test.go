@@ -0,0 +1,220 @@
+package chaos
+
+import "context"
+import "io"
+import "net"
+import "sync"
+import "testing"
+import "time"
+
+import autoscalingv1 "k8s.io/api/autoscaling/v1"
+import corev1 "k8s.io/api/core/v1"
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import runtime "k8s.io/apimachinery/pkg/runtime"
+import fake "k8s.io/client-go/kubernetes/fake"
+import clienttesting "k8s.io/client-go/testing"
+
+func pod(name string, labels map[string]string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "build-service", Labels: labels}}
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recorder) record(e Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+}
+
+// Test config validation catches missing or conflicting fields.
+func Test_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		action Action
+		ok     bool
+	}{
+		{"delete pods", Action{Name: "a", Type: DeletePods, Namespace: "ns", LabelSelector: "app=x", Every: "1m"}, true},
+		{"no schedule", Action{Name: "a", Type: DeletePods, Namespace: "ns", LabelSelector: "app=x"}, false},
+		{"both schedules", Action{Name: "a", Type: DeletePods, Namespace: "ns", LabelSelector: "app=x", Every: "1m", AtStep: "HandleComponent"}, false},
+		{"scale without deployment", Action{Name: "a", Type: Scale, Namespace: "ns", AtStep: "HandleComponent"}, false},
+		{"latency without duration", Action{Name: "a", Type: Latency, Latency: "100ms", Every: "1m"}, false},
+		{"duration longer than every", Action{Name: "a", Type: Scale, Namespace: "ns", Deployment: "d", Duration: "10m", Every: "5m"}, false},
+		{"bad duration", Action{Name: "a", Type: Latency, Latency: "100ms", Duration: "soon", Every: "1m"}, false},
+		{"unknown type", Action{Name: "a", Type: "reboot", Every: "1m"}, false},
+	}
+	for _, tt := range tests {
+		config := Config{Actions: []Action{tt.action}}
+		err := config.Validate()
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: unexpected validation result: %v", tt.name, err)
+		}
+	}
+}
+
+// Test actions injected at journey steps respect count and maximal number of injections.
+func Test_AtStepDeletePods(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		pod("build-1", map[string]string{"app": "build"}),
+		pod("build-2", map[string]string{"app": "build"}),
+		pod("other", map[string]string{"app": "other"}),
+	)
+	config := &Config{Actions: []Action{{Name: "kill-build", Type: DeletePods, Namespace: "build-service", LabelSelector: "app=build", Count: 1, AtStep: "HandleComponent", MaxInjections: 3}}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Invalid config: %v", err)
+	}
+	r := &recorder{}
+	injector := NewInjector(client, config, nil, r.record)
+
+	injector.AtStep("HandlePipelineRun")
+	injector.AtStep("journey.HandleComponent")
+	injector.AtStep("HandleComponent")
+	injector.AtStep("HandleComponent")
+	injector.AtStep("HandleComponent")
+	injector.Stop()
+
+	if len(r.events) != 3 {
+		t.Fatalf("Expected 3 events, got %+v", r.events)
+	}
+	if r.events[0].Err != nil || r.events[1].Err != nil {
+		t.Errorf("Unexpected errors: %+v", r.events)
+	}
+	if r.events[2].Err == nil {
+		t.Errorf("Expected error when there are no pods left, got %+v", r.events[2])
+	}
+	pods, _ := client.CoreV1().Pods("build-service").List(context.Background(), metav1.ListOptions{})
+	if len(pods.Items) != 1 || pods.Items[0].Name != "other" {
+		t.Errorf("Unexpected pods left: %+v", pods.Items)
+	}
+}
+
+// Test scaled deployment is scaled back when injector stops.
+func Test_ScaleRestoredOnStop(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	replicas := int32(3)
+	client.PrependReactor("get", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		return true, &autoscalingv1.Scale{ObjectMeta: metav1.ObjectMeta{Name: "controller"}, Spec: autoscalingv1.ScaleSpec{Replicas: replicas}}, nil
+	})
+	client.PrependReactor("update", "deployments", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		scale := action.(clienttesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		replicas = scale.Spec.Replicas
+		return true, scale, nil
+	})
+
+	config := &Config{Actions: []Action{{Name: "stop-controller", Type: Scale, Namespace: "integration-service", Deployment: "controller", Replicas: 0, AtStep: "HandleTest"}}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Invalid config: %v", err)
+	}
+	r := &recorder{}
+	injector := NewInjector(client, config, nil, r.record)
+
+	injector.AtStep("HandleTest")
+	if replicas != 0 {
+		t.Errorf("Expected deployment scaled to 0, got %d", replicas)
+	}
+	// Injection still in effect, so the action is skipped and does not see 0 replicas as original
+	injector.AtStep("HandleTest")
+	if len(r.events) != 1 {
+		t.Errorf("Overlapping injection should be skipped, got %+v", r.events)
+	}
+	injector.Stop()
+	if replicas != 3 {
+		t.Errorf("Expected deployment scaled back to 3, got %d", replicas)
+	}
+	if len(r.events) != 2 || r.events[1].Type != "scale restore" || r.events[1].Err != nil {
+		t.Errorf("Unexpected events: %+v", r.events)
+	}
+
+	injector.AtStep("HandleTest")
+	if len(r.events) != 2 {
+		t.Errorf("Stopped injector should not inject, got %+v", r.events)
+	}
+}
+
+// Test latency is only reset once no latency action is in effect.
+func Test_OverlappingLatency(t *testing.T) {
+	proxy, err := StartLatencyProxy("127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("Failed to start proxy: %v", err)
+	}
+	defer proxy.Close()
+
+	config := &Config{Actions: []Action{
+		{Name: "slow", Type: Latency, Latency: "100ms", Duration: "50ms", AtStep: "HandleTest"},
+		{Name: "slower", Type: Latency, Latency: "300ms", Duration: "1h", AtStep: "HandleTest"},
+	}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Invalid config: %v", err)
+	}
+	r := &recorder{}
+	injector := NewInjector(fake.NewSimpleClientset(), config, proxy, r.record)
+
+	injector.AtStep("HandleTest")
+	if latency := time.Duration(proxy.latency.Load()); latency != 300*time.Millisecond {
+		t.Errorf("Expected highest latency in effect, got %v", latency)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if latency := time.Duration(proxy.latency.Load()); latency != 300*time.Millisecond {
+		t.Errorf("Expected latency of action still in effect after other one was restored, got %v", latency)
+	}
+	injector.Stop()
+	if latency := time.Duration(proxy.latency.Load()); latency != 0 {
+		t.Errorf("Expected no latency after stop, got %v", latency)
+	}
+}
+
+// Test latency proxy forwards traffic and delays it by configured latency.
+func Test_LatencyProxy(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	proxy, err := StartLatencyProxy(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to start proxy: %v", err)
+	}
+	defer proxy.Close()
+
+	roundtrip := func() time.Duration {
+		conn, err := net.Dial("tcp", proxy.Addr())
+		if err != nil {
+			t.Fatalf("Failed to connect to proxy: %v", err)
+		}
+		defer conn.Close()
+		start := time.Now()
+		_, _ = conn.Write([]byte("ping"))
+		buf := make([]byte, 4)
+		if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != "ping" {
+			t.Fatalf("Unexpected echo %q: %v", buf, err)
+		}
+		return time.Since(start)
+	}
+
+	roundtrip()
+	proxy.SetLatency(200 * time.Millisecond)
+	if took := roundtrip(); took < 200*time.Millisecond {
+		t.Errorf("Expected roundtrip delayed by latency, took %v", took)
+	}
+}
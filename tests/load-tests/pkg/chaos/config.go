@@ -0,0 +1,146 @@
+package chaos
+
+import "encoding/json"
+import "fmt"
+import "os"
+import "path/filepath"
+import "time"
+
+// Types of chaos actions
+const DeletePods = "delete-pods" // delete 'count' (0 = all) pods matching 'labelSelector' in 'namespace'
+const Scale = "scale"            // scale 'deployment' in 'namespace' to 'replicas' for 'duration'
+const Latency = "latency"        // delay API server traffic of the load test by 'latency' for 'duration'
+
+// Represents one chaos action and when to inject it
+// Action is injected either every 'every' (first time after 'after') or
+// whenever journey step 'atStep' starts, at most 'maxInjections' times
+// when it is not 0.
+type Action struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Namespace     string `json:"namespace,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+	Count         int    `json:"count,omitempty"`
+	Deployment    string `json:"deployment,omitempty"`
+	Replicas      int32  `json:"replicas,omitempty"`
+	Latency       string `json:"latency,omitempty"`
+	Duration      string `json:"duration,omitempty"`
+	Every         string `json:"every,omitempty"`
+	After         string `json:"after,omitempty"`
+	AtStep        string `json:"atStep,omitempty"`
+	MaxInjections int    `json:"maxInjections,omitempty"`
+
+	latency  time.Duration
+	duration time.Duration
+	every    time.Duration
+	after    time.Duration
+}
+
+// Represents the chaos configuration file
+type Config struct {
+	Actions []Action `json:"actions"`
+}
+
+// Load chaos configuration from JSON file and check it is sane
+func Load(filePath string) (*Config, error) {
+	filePath = filepath.Clean(filePath)
+	jsonData, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	err = json.Unmarshal(jsonData, &config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing chaos config %s: %v", filePath, err)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid chaos config %s: %v", filePath, err)
+	}
+
+	return &config, nil
+}
+
+// Parse durations and check all the actions are sane
+func (c *Config) Validate() error {
+	for i := range c.Actions {
+		err := c.Actions[i].validate()
+		if err != nil {
+			return fmt.Errorf("action %d (%s): %v", i, c.Actions[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// Does any of the actions need API server traffic to go through latency proxy?
+func (c *Config) NeedsProxy() bool {
+	for _, a := range c.Actions {
+		if a.Type == Latency {
+			return true
+		}
+	}
+	return false
+}
+
+func parseOptionalDuration(value string, target *time.Duration) error {
+	if value == "" {
+		*target = 0
+		return nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	if parsed < 0 {
+		return fmt.Errorf("duration %s can not be negative", value)
+	}
+	*target = parsed
+	return nil
+}
+
+func (a *Action) validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("action has no name")
+	}
+
+	if err := parseOptionalDuration(a.Latency, &a.latency); err != nil {
+		return fmt.Errorf("failed to parse latency: %v", err)
+	}
+	if err := parseOptionalDuration(a.Duration, &a.duration); err != nil {
+		return fmt.Errorf("failed to parse duration: %v", err)
+	}
+	if err := parseOptionalDuration(a.Every, &a.every); err != nil {
+		return fmt.Errorf("failed to parse every: %v", err)
+	}
+	if err := parseOptionalDuration(a.After, &a.after); err != nil {
+		return fmt.Errorf("failed to parse after: %v", err)
+	}
+
+	if (a.every == 0) == (a.AtStep == "") {
+		return fmt.Errorf("exactly one of 'every' and 'atStep' has to be set")
+	}
+	if a.every > 0 && a.duration > a.every {
+		return fmt.Errorf("'duration' can not be longer than 'every', injections would overlap")
+	}
+
+	switch a.Type {
+	case DeletePods:
+		if a.Namespace == "" || a.LabelSelector == "" {
+			return fmt.Errorf("delete-pods action needs namespace and labelSelector")
+		}
+	case Scale:
+		if a.Namespace == "" || a.Deployment == "" || a.Replicas < 0 {
+			return fmt.Errorf("scale action needs namespace, deployment and non-negative replicas")
+		}
+	case Latency:
+		if a.latency == 0 || a.duration == 0 {
+			return fmt.Errorf("latency action needs latency and duration")
+		}
+	default:
+		return fmt.Errorf("unknown action type %q", a.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package chaos
+
+import "fmt"
+import "io"
+import "net"
+import "net/url"
+import "os"
+import "sync"
+import "sync/atomic"
+import "time"
+
+import clientcmd "k8s.io/client-go/tools/clientcmd"
+
+// TCP proxy delaying traffic sent to the target by configurable latency
+// It works on TCP level so TLS connections to the API server pass through
+// it untouched.
+type LatencyProxy struct {
+	listener net.Listener
+	target   string
+	latency  atomic.Int64
+	wg       sync.WaitGroup
+}
+
+// Start proxy on random local port forwarding connections to target (host:port)
+func StartLatencyProxy(target string) (*LatencyProxy, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	p := &LatencyProxy{listener: listener, target: target}
+	p.wg.Add(1)
+	go p.serve()
+	return p, nil
+}
+
+// Address the proxy listens on
+func (p *LatencyProxy) Addr() string {
+	return p.listener.Addr().String()
+}
+
+// Set latency added to every chunk of data sent to the target, 0 disables it
+func (p *LatencyProxy) SetLatency(latency time.Duration) {
+	p.latency.Store(int64(latency))
+}
+
+// Stop accepting new connections
+func (p *LatencyProxy) Close() error {
+	err := p.listener.Close()
+	p.wg.Wait()
+	return err
+}
+
+func (p *LatencyProxy) serve() {
+	defer p.wg.Done()
+	for {
+		conn, err := p.listener.Accept()
+		if err != nil {
+			return
+		}
+		go p.forward(conn)
+	}
+}
+
+func (p *LatencyProxy) forward(client net.Conn) {
+	defer client.Close()
+
+	server, err := net.Dial("tcp", p.target)
+	if err != nil {
+		return
+	}
+	defer server.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		p.copyDelayed(server, client)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(client, server)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// Copy data from src to dst, waiting for current latency before every write
+func (p *LatencyProxy) copyDelayed(dst io.Writer, src io.Reader) {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			if latency := time.Duration(p.latency.Load()); latency > 0 {
+				time.Sleep(latency)
+			}
+			if _, werr := dst.Write(buf[:n]); werr != nil {
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Start latency proxy in front of the API server of current kubeconfig context
+// and point KUBECONFIG environment variable to a copy of the kubeconfig using
+// the proxy, so all the clients created later go through it.
+func ProxyKubeconfig(outfile string) (*LatencyProxy, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	config, err := rules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("current kubeconfig context %q not found", config.CurrentContext)
+	}
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig cluster %q not found", kubeContext.Cluster)
+	}
+
+	server, err := url.Parse(cluster.Server)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse API server URL %s: %v", cluster.Server, err)
+	}
+	target := server.Host
+	if server.Port() == "" {
+		target = net.JoinHostPort(server.Hostname(), "443")
+	}
+
+	proxy, err := StartLatencyProxy(target)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.TLSServerName == "" {
+		cluster.TLSServerName = server.Hostname()
+	}
+	server.Host = proxy.Addr()
+	cluster.Server = server.String()
+
+	// Copy keeps the credentials, so it must not be readable by others
+	err = clientcmd.WriteToFile(*config, outfile)
+	if err == nil {
+		err = os.Chmod(outfile, 0600)
+	}
+	if err != nil {
+		_ = proxy.Close()
+		return nil, fmt.Errorf("failed to write proxied kubeconfig: %v", err)
+	}
+	err = os.Setenv("KUBECONFIG", outfile)
+	if err != nil {
+		_ = proxy.Close()
+		return nil, err
+	}
+
+	return proxy, nil
+}
@@ -0,0 +1,224 @@
+package checkpoint
+
+import "encoding/json"
+import "fmt"
+import "os"
+import "path/filepath"
+import "sort"
+import "sync"
+
+// Kinds of resources recorded in the checkpoint
+const Application = "Application"
+const IntegrationTestScenario = "IntegrationTestScenario"
+const ReleasePlan = "ReleasePlan"
+const ReleasePlanAdmission = "ReleasePlanAdmission"
+const Component = "Component"
+const Snapshot = "Snapshot"
+const Release = "Release"
+
+// Represents user thread state needed to resume or purge it
+type User struct {
+	Index            int    `json:"index"`
+	Username         string `json:"username"`
+	Namespace        string `json:"namespace"`
+	ComponentRepoUrl string `json:"componentRepoUrl,omitempty"` // fork URL, empty when not forked yet
+	JourneysDone     int    `json:"journeysDone"`               // number of finished journey repeats
+}
+
+// Represents resource created by some journey, Application and Component are -1 when not applicable
+type Resource struct {
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	User        int    `json:"user"`
+	Repeat      int    `json:"repeat"`
+	Application int    `json:"application"`
+	Component   int    `json:"component"`
+}
+
+// Checkpoint of the load test progress, stored to JSON file on every change
+// All the methods are safe for concurrent use and do nothing on nil checkpoint.
+type Checkpoint struct {
+	mu   sync.Mutex
+	path string
+
+	RunPrefix string     `json:"runPrefix"`
+	Stage     bool       `json:"stage"`
+	Users     []User     `json:"users"`
+	Resources []Resource `json:"resources"`
+}
+
+// Create empty checkpoint stored to given file
+func New(path, runPrefix string, stage bool) *Checkpoint {
+	return &Checkpoint{
+		path:      filepath.Clean(path),
+		RunPrefix: runPrefix,
+		Stage:     stage,
+		Users:     []User{},
+		Resources: []Resource{},
+	}
+}
+
+// Load checkpoint from given file, further changes are stored to the same file
+func Load(path string) (*Checkpoint, error) {
+	path = filepath.Clean(path)
+	jsonData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Checkpoint{}
+	err = json.Unmarshal(jsonData, c)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing checkpoint %s: %v", path, err)
+	}
+	c.path = path
+
+	return c, nil
+}
+
+// Store checkpoint to its file, file is replaced atomically so crash never leaves it half written
+func (c *Checkpoint) save() error {
+	jsonData, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	err = os.WriteFile(tmp, jsonData, 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+func (c *Checkpoint) user(index int) *User {
+	for i := range c.Users {
+		if c.Users[i].Index == index {
+			return &c.Users[i]
+		}
+	}
+	c.Users = append(c.Users, User{Index: index})
+	sort.Slice(c.Users, func(i, j int) bool {
+		return c.Users[i].Index < c.Users[j].Index
+	})
+	return c.user(index)
+}
+
+// Get state of user with given index, second value is false when user is not in the checkpoint
+func (c *Checkpoint) User(index int) (User, bool) {
+	if c == nil {
+		return User{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, u := range c.Users {
+		if u.Index == index {
+			return u, true
+		}
+	}
+	return User{}, false
+}
+
+// Get state of all users in the checkpoint
+func (c *Checkpoint) AllUsers() []User {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]User{}, c.Users...)
+}
+
+// Record user was provisioned
+func (c *Checkpoint) SetUser(index int, username, namespace string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u := c.user(index)
+	u.Username = username
+	u.Namespace = namespace
+	return c.save()
+}
+
+// Record component repository of user was forked
+func (c *Checkpoint) SetComponentRepoUrl(index int, url string) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.user(index).ComponentRepoUrl = url
+	return c.save()
+}
+
+// Record journey repeat of user has finished
+func (c *Checkpoint) JourneyDone(index, repeat int) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u := c.user(index)
+	if repeat+1 > u.JourneysDone {
+		u.JourneysDone = repeat + 1
+	}
+	return c.save()
+}
+
+// Record resource was created, recording the same resource again does nothing
+func (c *Checkpoint) AddResource(r Resource) error {
+	if c == nil || r.Name == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, existing := range c.Resources {
+		if existing == r {
+			return nil
+		}
+	}
+	c.Resources = append(c.Resources, r)
+	return c.save()
+}
+
+// Get resources created by given user in all the journey repeats
+func (c *Checkpoint) AllResourcesOf(user int) []Resource {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resources []Resource
+	for _, r := range c.Resources {
+		if r.User == user {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+// Get resources created by given user in given journey repeat
+func (c *Checkpoint) ResourcesOf(user, repeat int) []Resource {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var resources []Resource
+	for _, r := range c.Resources {
+		if r.User == user && r.Repeat == repeat {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
@@ -0,0 +1,49 @@
+package checkpoint
+
+import "path/filepath"
+import "testing"
+
+// Test progress recorded to checkpoint survives loading it from file.
+func Test_Checkpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+
+	c := New(path, "testuser", false)
+	if err := c.SetUser(1, "testuser-0001", "testuser-0001"); err != nil {
+		t.Fatalf("Failed to record user: %v", err)
+	}
+	_ = c.SetUser(0, "testuser-0000", "testuser-0000")
+	_ = c.SetComponentRepoUrl(1, "https://github.com/org/repo-testuser-0001")
+	_ = c.JourneyDone(1, 0)
+	_ = c.JourneyDone(1, 1)
+	_ = c.JourneyDone(1, 0)
+	app := Resource{Kind: Application, Name: "app-abc", User: 1, Repeat: 1, Application: 0, Component: -1}
+	_ = c.AddResource(app)
+	_ = c.AddResource(app)
+	_ = c.AddResource(Resource{Kind: Component, Name: "", User: 1, Repeat: 1, Application: 0, Component: 0})
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load checkpoint: %v", err)
+	}
+	if loaded.RunPrefix != "testuser" || len(loaded.AllUsers()) != 2 || loaded.AllUsers()[0].Index != 0 {
+		t.Errorf("Unexpected users: %+v", loaded.AllUsers())
+	}
+	u, ok := loaded.User(1)
+	if !ok || u.Namespace != "testuser-0001" || u.ComponentRepoUrl == "" || u.JourneysDone != 2 {
+		t.Errorf("Unexpected user: %+v", u)
+	}
+	if _, ok = loaded.User(5); ok {
+		t.Errorf("Expected unknown user to be missing")
+	}
+	if resources := loaded.ResourcesOf(1, 1); len(resources) != 1 || resources[0] != app {
+		t.Errorf("Unexpected resources: %+v", resources)
+	}
+	if resources := loaded.AllResourcesOf(1); len(resources) != 1 || resources[0] != app {
+		t.Errorf("Unexpected resources of user: %+v", resources)
+	}
+
+	var disabled *Checkpoint
+	if err = disabled.AddResource(app); err != nil || disabled.AllUsers() != nil {
+		t.Errorf("Expected nil checkpoint to do nothing")
+	}
+}
@@ -0,0 +1,140 @@
+package informer
+
+import "context"
+import "fmt"
+import "sync"
+import "time"
+
+import unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+import dynamic "k8s.io/client-go/dynamic"
+import dynamicinformer "k8s.io/client-go/dynamic/dynamicinformer"
+import cache "k8s.io/client-go/tools/cache"
+
+// Resources journey handlers wait for
+var PipelineRuns = schema.GroupVersionResource{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"}
+var Snapshots = schema.GroupVersionResource{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "snapshots"}
+var Releases = schema.GroupVersionResource{Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releases"}
+
+// Decides if object is the one we are waiting for, error stops the wait
+type Match func(obj *unstructured.Unstructured) (bool, error)
+
+// Shared informers, one watch per namespace and resource no matter how many
+// threads wait for objects in it
+type Cache struct {
+	mu         sync.Mutex
+	namespaces map[string]*namespaceInformers
+}
+
+type namespaceInformers struct {
+	factory dynamicinformer.DynamicSharedInformerFactory
+	stop    chan struct{}
+}
+
+func NewCache() *Cache {
+	return &Cache{namespaces: map[string]*namespaceInformers{}}
+}
+
+// Get informer for resource in namespace, starting it when nobody asked for it yet
+// Client is only used when namespace is seen for the first time.
+func (c *Cache) informer(client dynamic.Interface, namespace string, resource schema.GroupVersionResource) (cache.SharedIndexInformer, chan struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ni, ok := c.namespaces[namespace]
+	if !ok {
+		ni = &namespaceInformers{
+			factory: dynamicinformer.NewFilteredDynamicSharedInformerFactory(client, 0, namespace, nil),
+			stop:    make(chan struct{}),
+		}
+		c.namespaces[namespace] = ni
+	}
+
+	informer := ni.factory.ForResource(resource).Informer()
+	ni.factory.Start(ni.stop)
+	return informer, ni.stop
+}
+
+// Wait until object of given resource in namespace matches, return copy of it
+// Returns as soon as informer delivers the matching event, objects already
+// present in the cache are checked as well. Wait ends when ctx is done too.
+func (c *Cache) WaitFor(ctx context.Context, client dynamic.Interface, namespace string, resource schema.GroupVersionResource, timeout time.Duration, match Match) (*unstructured.Unstructured, error) {
+	informer, stop := c.informer(client, namespace, resource)
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		obj *unstructured.Unstructured
+		err error
+	}
+	found := make(chan result, 1)
+
+	check := func(obj interface{}) {
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return
+		}
+		matches, err := match(u)
+		if !matches && err == nil {
+			return
+		}
+		select {
+		case found <- result{u.DeepCopy(), err}:
+		default:
+		}
+	}
+
+	// Newly added handler gets add notifications for all objects already in cache
+	handle, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    check,
+		UpdateFunc: func(_, newObj interface{}) { check(newObj) },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch %s in namespace %s: %v", resource.Resource, namespace, err)
+	}
+	defer func() { _ = informer.RemoveEventHandler(handle) }()
+
+	select {
+	case r := <-found:
+		return r.obj, r.err
+	case <-stop:
+		return nil, fmt.Errorf("informers for namespace %s were stopped while waiting for %s", namespace, resource.Resource)
+	case <-waitCtx.Done():
+		// Parent context ending (even by its own deadline) means the journeys were stopped, not that we timed out
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("waiting for %s in namespace %s was cancelled: %v", resource.Resource, namespace, ctx.Err())
+		}
+		if !informer.HasSynced() {
+			return nil, fmt.Errorf("timed out waiting for %s in namespace %s, informer did not sync", resource.Resource, namespace)
+		}
+		return nil, fmt.Errorf("timed out waiting for %s in namespace %s after %v", resource.Resource, namespace, timeout)
+	}
+}
+
+// Stop informers of namespace (e.g. when it is being purged)
+func (c *Cache) StopNamespace(namespace string) {
+	c.mu.Lock()
+	ni, ok := c.namespaces[namespace]
+	delete(c.namespaces, namespace)
+	c.mu.Unlock()
+
+	if ok {
+		close(ni.stop)
+		ni.factory.Shutdown()
+	}
+}
+
+// Stop all the informers
+func (c *Cache) Stop() {
+	c.mu.Lock()
+	namespaces := make([]string, 0, len(c.namespaces))
+	for namespace := range c.namespaces {
+		namespaces = append(namespaces, namespace)
+	}
+	c.mu.Unlock()
+
+	for _, namespace := range namespaces {
+		c.StopNamespace(namespace)
+	}
+}
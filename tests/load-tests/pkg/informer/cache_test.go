@@ -0,0 +1,149 @@
+package informer
+
+import "context"
+import "fmt"
+import "strings"
+import "testing"
+import "time"
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+import runtime "k8s.io/apimachinery/pkg/runtime"
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+import fake "k8s.io/client-go/dynamic/fake"
+
+func pipelineRun(name, component, succeeded string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("tekton.dev/v1")
+	u.SetKind("PipelineRun")
+	u.SetNamespace("user-ns")
+	u.SetName(name)
+	u.SetLabels(map[string]string{"appstudio.openshift.io/component": component})
+	if succeeded != "" {
+		_ = unstructured.SetNestedSlice(u.Object, []interface{}{map[string]interface{}{"type": "Succeeded", "status": succeeded}}, "status", "conditions")
+	}
+	return u
+}
+
+func succeeded(u *unstructured.Unstructured) (bool, error) {
+	conditions, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition := c.(map[string]interface{})
+		if condition["type"] == "Succeeded" && condition["status"] == "True" {
+			return true, nil
+		}
+		if condition["type"] == "Succeeded" && condition["status"] == "False" {
+			return false, fmt.Errorf("pipeline run %s failed", u.GetName())
+		}
+	}
+	return false, nil
+}
+
+func newClient(objects ...runtime.Object) *fake.FakeDynamicClient {
+	return fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{PipelineRuns: "PipelineRunList"}, objects...)
+}
+
+// Test waiting for existing object, for object created later and for condition appearing on update.
+func Test_WaitFor(t *testing.T) {
+	client := newClient(pipelineRun("existing", "comp-a", "True"))
+	c := NewCache()
+	defer c.Stop()
+
+	obj, err := c.WaitFor(context.Background(), client, "user-ns", PipelineRuns, 5*time.Second, succeeded)
+	if err != nil || obj.GetName() != "existing" {
+		t.Fatalf("Expected existing pipeline run, got %v: %v", obj, err)
+	}
+
+	prs := client.Resource(PipelineRuns).Namespace("user-ns")
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = prs.Create(context.Background(), pipelineRun("later", "comp-b", ""), metav1.CreateOptions{})
+		time.Sleep(100 * time.Millisecond)
+		_, _ = prs.Update(context.Background(), pipelineRun("later", "comp-b", "False"), metav1.UpdateOptions{})
+	}()
+
+	obj, err = c.WaitFor(context.Background(), client, "user-ns", PipelineRuns, 5*time.Second, func(u *unstructured.Unstructured) (bool, error) {
+		return u.GetLabels()["appstudio.openshift.io/component"] == "comp-b", nil
+	})
+	if err != nil || obj.GetName() != "later" {
+		t.Fatalf("Expected created pipeline run, got %v: %v", obj, err)
+	}
+
+	_, err = c.WaitFor(context.Background(), client, "user-ns", PipelineRuns, 5*time.Second, func(u *unstructured.Unstructured) (bool, error) {
+		if u.GetName() != "later" {
+			return false, nil
+		}
+		return succeeded(u)
+	})
+	if err == nil {
+		t.Errorf("Expected failed pipeline run to stop the wait with error")
+	}
+}
+
+// Test wait times out when nothing matches and stopping namespace ends waits in it.
+func Test_WaitForTimeoutAndStop(t *testing.T) {
+	client := newClient()
+	c := NewCache()
+
+	_, err := c.WaitFor(context.Background(), client, "user-ns", PipelineRuns, 200*time.Millisecond, succeeded)
+	if err == nil {
+		t.Errorf("Expected timeout")
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := c.WaitFor(context.Background(), client, "other-ns", PipelineRuns, time.Minute, succeeded)
+		done <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+	c.Stop()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("Expected error when informers are stopped")
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("Wait did not end after informers were stopped")
+	}
+}
+
+// Test cancelling context of the journey ends the wait before timeout.
+func Test_WaitForCancelled(t *testing.T) {
+	client := newClient()
+	c := NewCache()
+	defer c.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(200 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	_, err := c.WaitFor(ctx, client, "user-ns", PipelineRuns, time.Minute, succeeded)
+	if err == nil || !strings.Contains(err.Error(), "cancelled") {
+		t.Errorf("Expected cancelled wait, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("Wait did not end after context was cancelled")
+	}
+}
+
+// Test wait ends when deadline of the parent context passes before the wait timeout.
+func Test_WaitForParentDeadline(t *testing.T) {
+	client := newClient()
+	c := NewCache()
+	defer c.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := c.WaitFor(ctx, client, "user-ns", PipelineRuns, time.Minute, succeeded)
+	if err == nil || !strings.Contains(err.Error(), "cancelled") || !strings.Contains(err.Error(), "deadline exceeded") {
+		t.Errorf("Expected wait cancelled by parent deadline, got %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("Wait did not end when parent context expired")
+	}
+}
@@ -0,0 +1,144 @@
+package journey
+
+import "encoding/json"
+import "os"
+import "sync"
+import "time"
+
+import arrival "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/arrival"
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
+
+// Journey started later than this after its planned start time is reported as late
+const arrivalLateThreshold = time.Second
+
+// Statistics about journey starts in open-loop mode, stored to 'load-test-arrivals.json'
+type ArrivalStats struct {
+	Planned     int     `json:"planned"`
+	Started     int     `json:"started"`
+	Late        int     `json:"late"`
+	Dropped     int     `json:"dropped"`
+	MaxLateness float64 `json:"maxLatenessSeconds"`
+}
+
+// Start journeys at the arrival rate defined in '--arrival-profile' file
+// Each journey runs `fn` on one of idle users and there are never more
+// than '--max-in-flight' journeys running. When no journey can be started
+// until the next planned start (or end of the profile), start is dropped.
+func PerUserArrivalSetup(fn func(*types.PerUserContext), opts *options.Opts) (string, error) {
+	profile, err := arrival.Load(opts.ArrivalProfile)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = initUsers(opts)
+	if err != nil || opts.PurgeOnly {
+		return "", err
+	}
+
+	idleUsers := make(chan *types.PerUserContext, len(PerUserContexts))
+	for _, perUserCtx := range PerUserContexts {
+		idleUsers <- perUserCtx
+	}
+	inFlight := make(chan struct{}, opts.MaxInFlight)
+
+	schedule := profile.Schedule()
+	stats := ArrivalStats{Planned: len(schedule)}
+	journeysWG := &sync.WaitGroup{}
+
+	logging.Logger.Info("Starting %d journeys in %v with at most %d in flight", len(schedule), profile.Duration(), opts.MaxInFlight)
+
+	start := time.Now()
+	for i, offset := range schedule {
+		planned := start.Add(offset)
+		time.Sleep(time.Until(planned))
+
+		// Wait for free slot until the next planned start at most
+		deadline := start.Add(profile.Duration())
+		if i+1 < len(schedule) {
+			deadline = start.Add(schedule[i+1])
+		}
+		if !acquireSlot(inFlight, deadline) {
+			stats.Dropped++
+			logging.ObserveArrival("dropped")
+			logging.Logger.Warning("Dropped journey planned at %v, %d journeys in flight", offset, len(inFlight))
+			continue
+		}
+
+		lateness := time.Since(planned)
+		if lateness > arrivalLateThreshold {
+			stats.Late++
+			logging.ObserveArrival("late")
+			logging.Logger.Warning("Journey planned at %v started %v late", offset, lateness)
+		} else {
+			logging.ObserveArrival("started")
+		}
+		if lateness.Seconds() > stats.MaxLateness {
+			stats.MaxLateness = lateness.Seconds()
+		}
+		stats.Started++
+
+		perUserCtx := <-idleUsers
+		journeysWG.Add(1)
+		go func() {
+			defer journeysWG.Done()
+			defer func() { <-inFlight }()
+			defer func() { idleUsers <- perUserCtx }()
+			logging.ThreadStarted(logging.ThreadUser)
+			defer logging.ThreadFinished(logging.ThreadUser)
+
+			fn(perUserCtx)
+			perUserCtx.JourneyRepeatsCounter++
+		}()
+	}
+
+	journeysWG.Wait()
+
+	logging.Logger.Info("Arrivals planned: %d, started: %d, late: %d, dropped: %d", stats.Planned, stats.Started, stats.Late, stats.Dropped)
+	err = writeArrivalStats(opts.OutputDir, &stats)
+	if err != nil {
+		logging.Logger.Error("Failed to store arrival stats: %v", err)
+	}
+
+	// Collect info about PVCs
+	for _, perUserCtx := range PerUserContexts {
+		_, err = logging.Measure(
+			perUserCtx,
+			HandlePersistentVolumeClaim,
+			perUserCtx,
+		)
+		if err != nil {
+			logging.Logger.Error("Thread failed: %v", err)
+		}
+	}
+
+	return "", nil
+}
+
+// Take in-flight slot, waiting for it until deadline at most
+func acquireSlot(inFlight chan struct{}, deadline time.Time) bool {
+	select {
+	case inFlight <- struct{}{}:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case inFlight <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// Dump arrival stats to JSON file in output directory
+func writeArrivalStats(directory string, stats *ArrivalStats) error {
+	jsonStats, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(directory+"/load-test-arrivals.json", jsonStats, 0600)
+}
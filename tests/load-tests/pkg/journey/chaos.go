@@ -0,0 +1,110 @@
+package journey
+
+import "fmt"
+import "os"
+import "path/filepath"
+
+import chaos "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/chaos"
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+
+import kubernetes "k8s.io/client-go/kubernetes"
+import config "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+// Injector of chaos actions for this run, nil when chaos is disabled
+var CurrentInjector *chaos.Injector
+
+// Latency proxy all the cluster clients go through, nil when there are no latency actions
+var currentProxy *chaos.LatencyProxy
+
+// Temporary directory with kubeconfig pointing to the latency proxy, it contains cluster credentials
+var proxyKubeconfigDir string
+
+// Load chaos configuration and prepare injector
+// Has to be called before any cluster client is created, because when
+// latency actions are configured, KUBECONFIG is pointed to a proxy.
+func SetupChaos(opts *options.Opts) error {
+	if opts.ChaosConfig == "" {
+		return nil
+	}
+
+	chaosConfig, err := chaos.Load(opts.ChaosConfig)
+	if err != nil {
+		return err
+	}
+
+	// Injector itself talks to the cluster directly, not via latency proxy
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get cluster config: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster client: %v", err)
+	}
+
+	var proxy *chaos.LatencyProxy
+	if chaosConfig.NeedsProxy() {
+		// Kubeconfig contains credentials, so keep it out of the output directory collected as artifacts
+		proxyKubeconfigDir, err = os.MkdirTemp("", "load-test-chaos-")
+		if err != nil {
+			return fmt.Errorf("failed to create directory for proxied kubeconfig: %v", err)
+		}
+		proxy, err = chaos.ProxyKubeconfig(filepath.Join(proxyKubeconfigDir, "kubeconfig"))
+		if err != nil {
+			_ = os.RemoveAll(proxyKubeconfigDir)
+			return fmt.Errorf("failed to start latency proxy: %v", err)
+		}
+		logging.Logger.Info("API server traffic goes through latency proxy on %s", proxy.Addr())
+	}
+
+	currentProxy = proxy
+	CurrentInjector = chaos.NewInjector(client, chaosConfig, proxy, recordChaos)
+	return nil
+}
+
+// Log chaos injection as a measurement so failures can be correlated with it
+func recordChaos(e chaos.Event) {
+	if e.Err != nil {
+		logging.Logger.Warning("Chaos action %s (%s) failed: %v", e.Action, e.Type, e.Err)
+	} else {
+		logging.Logger.Info("Chaos action %s (%s) injected: %s", e.Action, e.Type, e.Detail)
+	}
+	params := map[string]string{"action": e.Action, "detail": e.Detail}
+	logging.LogMeasurement("chaos."+e.Type, -1, -1, -1, -1, params, e.Duration, "", e.Err)
+}
+
+// Start injecting scheduled chaos actions
+func StartChaos() {
+	if CurrentInjector != nil {
+		CurrentInjector.Start()
+	}
+}
+
+// Stop injecting chaos actions, revert ones still in effect, close latency proxy and remove its kubeconfig
+// Cluster clients go through the proxy, so it has to be called once they are not used anymore.
+func StopChaos() error {
+	if CurrentInjector != nil {
+		CurrentInjector.Stop()
+	}
+	if currentProxy != nil {
+		err := currentProxy.Close()
+		if err != nil {
+			return fmt.Errorf("failed to close latency proxy: %v", err)
+		}
+	}
+	if proxyKubeconfigDir != "" {
+		err := os.RemoveAll(proxyKubeconfigDir)
+		if err != nil {
+			return fmt.Errorf("failed to remove proxied kubeconfig: %v", err)
+		}
+	}
+	return nil
+}
+
+// Inject chaos actions configured for journey step that is starting
+func stepStarted(step string) {
+	if CurrentInjector != nil {
+		CurrentInjector.AtStep(step)
+	}
+}
@@ -0,0 +1,154 @@
+package journey
+
+import "fmt"
+import "os"
+
+import checkpoint "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/checkpoint"
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
+
+// Checkpoint of created resources and journey progress of this run
+var CurrentCheckpoint *checkpoint.Checkpoint
+
+// Start new checkpoint in output directory or load the existing one when resuming
+// Checkpoint of previous run is only replaced when asked to, it is the only record of what to purge.
+func SetupCheckpoint(opts *options.Opts) error {
+	path := opts.OutputDir + "/load-test-checkpoint.json"
+
+	if !opts.Resume {
+		if _, err := os.Stat(path); err == nil && !opts.OverwriteCheckpoint {
+			return fmt.Errorf("checkpoint %s of previous run exists, use --resume to continue or purge it, or --overwrite-checkpoint to start new one", path)
+		}
+		CurrentCheckpoint = checkpoint.New(path, opts.RunPrefix, opts.Stage)
+		return nil
+	}
+
+	loaded, err := checkpoint.Load(path)
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint to resume from: %v", err)
+	}
+	if loaded.RunPrefix != opts.RunPrefix || loaded.Stage != opts.Stage {
+		return fmt.Errorf("checkpoint %s is for run prefix %q (stage %t), not for %q (stage %t)", path, loaded.RunPrefix, loaded.Stage, opts.RunPrefix, opts.Stage)
+	}
+	logging.Logger.Info("Resuming run %s from checkpoint with %d users", loaded.RunPrefix, len(loaded.AllUsers()))
+	CurrentCheckpoint = loaded
+	return nil
+}
+
+// Restore user thread progress from checkpoint (if there is any) and record the user
+func restoreUser(ctx *types.PerUserContext) {
+	if u, ok := CurrentCheckpoint.User(ctx.UserIndex); ok {
+		ctx.ComponentRepoUrl = u.ComponentRepoUrl
+		ctx.JourneyRepeatsCounter = u.JourneysDone
+		if u.JourneysDone > 0 {
+			restoreFirstJourney(ctx)
+		}
+		logging.Logger.Debug("Restored user %s from checkpoint, %d journeys done", ctx.Username, u.JourneysDone)
+	}
+
+	err := CurrentCheckpoint.SetUser(ctx.UserIndex, ctx.Username, ctx.Namespace)
+	if err != nil {
+		logging.Logger.Error("Failed to store checkpoint: %v", err)
+	}
+}
+
+// Recreate application and component contexts of the first journey, journey repeats reusing applications or components need them
+func restoreFirstJourney(ctx *types.PerUserContext) {
+	for applicationIndex := 0; applicationIndex < ctx.Opts.ApplicationsCount; applicationIndex++ {
+		perApplicationCtx := &types.PerApplicationContext{
+			ApplicationIndex:   applicationIndex,
+			JourneyRepeatIndex: 0,
+			ParentContext:      ctx,
+		}
+		for componentIndex := 0; componentIndex < ctx.Opts.ComponentsCount; componentIndex++ {
+			perApplicationCtx.PerComponentContexts = append(perApplicationCtx.PerComponentContexts, &types.PerComponentContext{
+				ComponentIndex: componentIndex,
+				ParentContext:  perApplicationCtx,
+			})
+		}
+		ctx.PerApplicationContexts = append(ctx.PerApplicationContexts, perApplicationCtx)
+	}
+
+	for _, r := range CurrentCheckpoint.ResourcesOf(ctx.UserIndex, 0) {
+		if r.Application < 0 || r.Application >= len(ctx.PerApplicationContexts) {
+			continue
+		}
+		perApplicationCtx := ctx.PerApplicationContexts[r.Application]
+		switch r.Kind {
+		case checkpoint.Application:
+			perApplicationCtx.ApplicationName = r.Name
+		case checkpoint.IntegrationTestScenario:
+			perApplicationCtx.IntegrationTestScenarioName = r.Name
+		case checkpoint.ReleasePlan:
+			perApplicationCtx.ReleasePlanName = r.Name
+		case checkpoint.ReleasePlanAdmission:
+			perApplicationCtx.ReleasePlanAdmissionName = r.Name
+		case checkpoint.Component:
+			if r.Component >= 0 && r.Component < len(perApplicationCtx.PerComponentContexts) {
+				perApplicationCtx.PerComponentContexts[r.Component].ComponentName = r.Name
+			}
+		}
+	}
+}
+
+// Record fork of component repository of the user
+func recordFork(ctx *types.PerUserContext) {
+	err := CurrentCheckpoint.SetComponentRepoUrl(ctx.UserIndex, ctx.ComponentRepoUrl)
+	if err != nil {
+		logging.Logger.Error("Failed to store checkpoint: %v", err)
+	}
+}
+
+// Record resources created by application thread so far
+func recordApplication(ctx *types.PerApplicationContext) {
+	names := map[string]string{
+		checkpoint.Application:             ctx.ApplicationName,
+		checkpoint.IntegrationTestScenario: ctx.IntegrationTestScenarioName,
+		checkpoint.ReleasePlan:             ctx.ReleasePlanName,
+		checkpoint.ReleasePlanAdmission:    ctx.ReleasePlanAdmissionName,
+	}
+	for kind, name := range names {
+		err := CurrentCheckpoint.AddResource(checkpoint.Resource{
+			Kind:        kind,
+			Name:        name,
+			User:        ctx.ParentContext.UserIndex,
+			Repeat:      ctx.JourneyRepeatIndex,
+			Application: ctx.ApplicationIndex,
+			Component:   -1,
+		})
+		if err != nil {
+			logging.Logger.Error("Failed to store checkpoint: %v", err)
+		}
+	}
+}
+
+// Record resources created by component thread so far
+func recordComponent(ctx *types.PerComponentContext) {
+	names := map[string]string{
+		checkpoint.Component: ctx.ComponentName,
+		checkpoint.Snapshot:  ctx.SnapshotName,
+		checkpoint.Release:   ctx.ReleaseName,
+	}
+	for kind, name := range names {
+		err := CurrentCheckpoint.AddResource(checkpoint.Resource{
+			Kind:        kind,
+			Name:        name,
+			User:        ctx.ParentContext.ParentContext.UserIndex,
+			Repeat:      ctx.ParentContext.JourneyRepeatIndex,
+			Application: ctx.ParentContext.ApplicationIndex,
+			Component:   ctx.ComponentIndex,
+		})
+		if err != nil {
+			logging.Logger.Error("Failed to store checkpoint: %v", err)
+		}
+	}
+}
+
+// Record current journey repeat of the user has finished
+func JourneyDone(ctx *types.PerUserContext) {
+	err := CurrentCheckpoint.JourneyDone(ctx.UserIndex, ctx.JourneyRepeatsCounter)
+	if err != nil {
+		logging.Logger.Error("Failed to store checkpoint: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package journey
+
+import "encoding/json"
+import "fmt"
+import "os"
+import "path/filepath"
+
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
+
+// Steps that can be used in per application part of journey definition
+var applicationSteps = map[string]func(*types.PerApplicationContext) error{
+	"HandleApplication":             HandleApplication,
+	"HandleIntegrationTestScenario": HandleIntegrationTestScenario,
+	"HandleReleaseSetup":            HandleReleaseSetup,
+}
+
+// Steps that can be used in per component part of journey definition
+var componentSteps = map[string]func(*types.PerComponentContext) error{
+	"HandleComponent":       HandleComponent,
+	"HandleComponentCommit": HandleComponentCommit,
+	"HandlePipelineRun":     HandlePipelineRun,
+	"HandleTest":            HandleTest,
+	"HandleReleaseRun":      HandleReleaseRun,
+}
+
+// Represents named list of steps done by every application and component thread
+type Definition struct {
+	Name             string   `json:"name"`
+	ApplicationSteps []string `json:"applicationSteps"`
+	ComponentSteps   []string `json:"componentSteps"`
+}
+
+// Journey we run when no other journey definition is selected
+var DefaultDefinition = Definition{
+	Name:             "default",
+	ApplicationSteps: []string{"HandleApplication", "HandleIntegrationTestScenario", "HandleReleaseSetup"},
+	ComponentSteps:   []string{"HandleComponent", "HandlePipelineRun", "HandleTest", "HandleReleaseRun"},
+}
+
+// Journey definition used by all the threads
+var CurrentDefinition = DefaultDefinition
+
+// Add step usable in per application part of journey definitions
+func RegisterApplicationStep(name string, fn func(*types.PerApplicationContext) error) {
+	applicationSteps[name] = fn
+}
+
+// Add step usable in per component part of journey definitions
+func RegisterComponentStep(name string, fn func(*types.PerComponentContext) error) {
+	componentSteps[name] = fn
+}
+
+// Check all the steps of journey definition are known
+func (d *Definition) Validate() error {
+	for _, step := range d.ApplicationSteps {
+		if _, ok := applicationSteps[step]; !ok {
+			return fmt.Errorf("journey %s: unknown application step %q", d.Name, step)
+		}
+	}
+	for _, step := range d.ComponentSteps {
+		if _, ok := componentSteps[step]; !ok {
+			return fmt.Errorf("journey %s: unknown component step %q", d.Name, step)
+		}
+	}
+	return nil
+}
+
+// Load JSON file with a list of journey definitions and select the one with given name
+// Empty file path selects journey from built-in definitions ("default" only for now).
+func LoadDefinition(filePath, name string) (*Definition, error) {
+	definitions := []Definition{DefaultDefinition}
+
+	if filePath != "" {
+		filePath = filepath.Clean(filePath)
+		jsonData, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		var loaded []Definition
+		err = json.Unmarshal(jsonData, &loaded)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing journey definitions %s: %v", filePath, err)
+		}
+		definitions = append(loaded, definitions...)
+	}
+
+	for i := range definitions {
+		if definitions[i].Name == name {
+			err := definitions[i].Validate()
+			if err != nil {
+				return nil, err
+			}
+			return &definitions[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("journey %s not defined", name)
+}
+
+// Run application steps of current journey definition, stop at first failure
+func RunApplicationSteps(ctx *types.PerApplicationContext) error {
+	return runApplicationSteps(ctx, CurrentDefinition.ApplicationSteps)
+}
+
+// Run component steps of current journey definition, stop at first failure
+func RunComponentSteps(ctx *types.PerComponentContext) error {
+	return runComponentSteps(ctx, CurrentDefinition.ComponentSteps)
+}
+
+func runApplicationSteps(ctx *types.PerApplicationContext, steps []string) error {
+	for _, step := range steps {
+		stepStarted(step)
+		_, err := logging.Measure(
+			ctx,
+			applicationSteps[step],
+			ctx,
+		)
+		recordApplication(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runComponentSteps(ctx *types.PerComponentContext, steps []string) error {
+	for _, step := range steps {
+		stepStarted(step)
+		_, err := logging.Measure(
+			ctx,
+			componentSteps[step],
+			ctx,
+		)
+		recordComponent(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,15 +6,36 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+	phases "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/phases"
 	types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
 
 	framework "github.com/konflux-ci/e2e-tests/pkg/framework"
 
+	corev1 "k8s.io/api/core/v1"
 	k8s_api_errors "k8s.io/apimachinery/pkg/api/errors"
+	k8s_types "k8s.io/apimachinery/pkg/types"
 )
 
+// UIDs of PipelineRuns whose phases were already collected
+// Reused components list PipelineRuns of earlier journey repeats again, their
+// phases must not be logged twice.
+var phasesCollected = map[k8s_types.UID]bool{}
+var phasesCollectedLock sync.Mutex
+
+// Returns true only the first time it is called for the PipelineRun
+func firstPhasesCollection(uid k8s_types.UID) bool {
+	phasesCollectedLock.Lock()
+	defer phasesCollectedLock.Unlock()
+	if phasesCollected[uid] {
+		return false
+	}
+	phasesCollected[uid] = true
+	return true
+}
+
 
 func getDirName(baseDir, namespace, iteration string) string {
 	return filepath.Join(baseDir, "collected-data", namespace, iteration) + "/"
@@ -91,10 +112,14 @@ func collectPodLogs(f *framework.Framework, dirPath, namespace, application stri
 	return nil
 }
 
-func collectPipelineRunJSONs(f *framework.Framework, dirPath, namespace, application, component, release string) error {
+// Store PipelineRuns and TaskRuns JSONs and compute their timing phases along the way
+// Phases are only computed for PipelineRuns not collected by earlier journey repeats.
+func collectPipelineRunJSONs(f *framework.Framework, dirPath, namespace, application, component, release string) ([]phases.Phase, error) {
+	var collected []phases.Phase
+
 	prs, err := f.AsKubeDeveloper.HasController.GetComponentPipelineRunsWithType(component, application, namespace, "", "", "")
 	if err != nil {
-		return fmt.Errorf("failed to list PipelineRuns %s/%s/%s: %v", namespace, application, component, err)
+		return nil, fmt.Errorf("failed to list PipelineRuns %s/%s/%s: %v", namespace, application, component, err)
 	}
 
 	if release != "" {
@@ -110,20 +135,26 @@ func collectPipelineRunJSONs(f *framework.Framework, dirPath, namespace, applica
 	}
 
 	for _, pr := range *prs {
+		pr := pr
 		prJSON, err := json.Marshal(pr)
 		if err != nil {
-			return fmt.Errorf("failed to dump PipelineRun JSON: %v", err)
+			return nil, fmt.Errorf("failed to dump PipelineRun JSON: %v", err)
 		}
 
 		err = writeToFile(dirPath, "collected-pipelinerun-"+pr.Name+".json", prJSON)
 		if err != nil {
-			return fmt.Errorf("failed to write PipelineRun: %v", err)
+			return nil, fmt.Errorf("failed to write PipelineRun: %v", err)
+		}
+
+		collectPhases := firstPhasesCollection(pr.UID)
+		if collectPhases {
+			collected = append(collected, phases.PipelineRunPhases(&pr)...)
 		}
 
 		for _, chr := range pr.Status.ChildReferences {
 			tr, err := f.AsKubeDeveloper.TektonController.GetTaskRun(chr.Name, namespace)
 			if err != nil {
-				return fmt.Errorf("failed to list TaskRuns %s/%s: %v", namespace, pr.Name, err)
+				return nil, fmt.Errorf("failed to list TaskRuns %s/%s: %v", namespace, pr.Name, err)
 			}
 
 			if tr.Kind == "" {
@@ -137,17 +168,55 @@ func collectPipelineRunJSONs(f *framework.Framework, dirPath, namespace, applica
 			var trJSON []byte
 			trJSON, err = json.Marshal(tr)
 			if err != nil {
-				return fmt.Errorf("failed to dump TaskRun JSON: %v", err)
+				return nil, fmt.Errorf("failed to dump TaskRun JSON: %v", err)
 			}
 
 			err = writeToFile(dirPath, "collected-taskrun-"+tr.Name+".json", trJSON)
 			if err != nil {
-				return fmt.Errorf("failed to write TaskRun: %v", err)
+				return nil, fmt.Errorf("failed to write TaskRun: %v", err)
+			}
+
+			// Pod might be already pruned, then we just do not know pod related phases
+			var pod *corev1.Pod
+			if tr.Status.PodName != "" {
+				pod, err = f.AsKubeAdmin.CommonController.GetPod(namespace, tr.Status.PodName)
+				if err != nil {
+					logging.Logger.Debug("Failed to get Pod %s/%s of TaskRun %s: %v", namespace, tr.Status.PodName, tr.Name, err)
+					pod = nil
+				}
+			}
+			if collectPhases {
+				collected = append(collected, phases.TaskRunPhases(&pr, tr, pod)...)
 			}
 		}
 	}
 
-	return nil
+	return collected, nil
+}
+
+// Store timing phases of PipelineRuns and TaskRuns as measurements
+func logPhases(ctx *types.PerComponentContext, collected []phases.Phase) {
+	for _, p := range collected {
+		params := map[string]string{
+			"pipelineRun":     p.PipelineRun,
+			"pipelineRunType": p.PipelineRunType,
+		}
+		if p.TaskRun != "" {
+			params["taskRun"] = p.TaskRun
+			params["task"] = p.Task
+		}
+		logging.LogMeasurement(
+			"phase."+p.Name,
+			ctx.ParentContext.ParentContext.UserIndex,
+			ctx.ParentContext.ApplicationIndex,
+			ctx.ComponentIndex,
+			ctx.ParentContext.JourneyRepeatIndex,
+			params,
+			p.Duration,
+			"",
+			nil,
+		)
+	}
 }
 
 func collectApplicationJSONs(f *framework.Framework, dirPath, namespace, application string) error {
@@ -301,12 +370,15 @@ func HandlePerApplicationCollection(ctx *types.PerApplicationContext) error {
 		return logging.Logger.Fail(105, "Failed to create dir: %v", err)
 	}
 
-	err = collectPodLogs(ctx.Framework, dirPath, ctx.ParentContext.Namespace, ctx.ApplicationName)
+	// Collect even when journeys were stopped
+	f := unboundFramework(ctx.Framework)
+
+	err = collectPodLogs(f, dirPath, ctx.ParentContext.Namespace, ctx.ApplicationName)
 	if err != nil {
 		return logging.Logger.Fail(106, "Failed to collect pod logs: %v", err)
 	}
 
-	err = collectApplicationJSONs(ctx.Framework, dirPath, ctx.ParentContext.Namespace, ctx.ApplicationName)
+	err = collectApplicationJSONs(f, dirPath, ctx.ParentContext.Namespace, ctx.ApplicationName)
 	if err != nil {
 		return logging.Logger.Fail(107, "Failed to collect application JSONs: %v", err)
 	}
@@ -329,22 +401,26 @@ func HandlePerComponentCollection(ctx *types.PerComponentContext) error {
 		return logging.Logger.Fail(100, "Failed to create dir: %v", err)
 	}
 
-	err = collectPodLogs(ctx.Framework, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ComponentName)
+	// Collect even when journeys were stopped
+	f := unboundFramework(ctx.Framework)
+
+	err = collectPodLogs(f, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ComponentName)
 	if err != nil {
 		return logging.Logger.Fail(101, "Failed to collect pod logs: %v", err)
 	}
 
-	err = collectPipelineRunJSONs(ctx.Framework, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ParentContext.ApplicationName, ctx.ComponentName, ctx.ReleaseName)
+	collected, err := collectPipelineRunJSONs(f, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ParentContext.ApplicationName, ctx.ComponentName, ctx.ReleaseName)
 	if err != nil {
 		return logging.Logger.Fail(102, "Failed to collect pipeline run JSONs: %v", err)
 	}
+	logPhases(ctx, collected)
 
-	err = collectComponentJSONs(ctx.Framework, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ComponentName)
+	err = collectComponentJSONs(f, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ComponentName)
 	if err != nil {
 		return logging.Logger.Fail(103, "Failed to collect component JSONs: %v", err)
 	}
 
-	err = collectReleaseRelatedJSONs(ctx.Framework, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ParentContext.ApplicationName, ctx.ComponentName, ctx.SnapshotName, ctx.ParentContext.ReleasePlanName, ctx.ParentContext.ReleasePlanAdmissionName, ctx.ReleaseName)
+	err = collectReleaseRelatedJSONs(f, dirPath, ctx.ParentContext.ParentContext.Namespace, ctx.ParentContext.ApplicationName, ctx.ComponentName, ctx.SnapshotName, ctx.ParentContext.ReleasePlanName, ctx.ParentContext.ReleasePlanAdmissionName, ctx.ReleaseName)
 	if err != nil {
 		return logging.Logger.Fail(104, "Failed to collect release related JSONs: %v", err)
 	}
@@ -480,3 +480,32 @@ func HandleComponent(ctx *types.PerComponentContext) error {
 
 	return nil
 }
+
+// Push new commit to the repository of already existing component to trigger its build
+// Pushed revision is remembered, so following HandlePipelineRun waits for build of it.
+func HandleComponentCommit(ctx *types.PerComponentContext) error {
+	if ctx.ComponentName == "" {
+		return logging.Logger.Fail(68, "No component to push commit for in namespace %s, HandleComponent has to run first", ctx.ParentContext.ParentContext.Namespace)
+	}
+
+	iface, err := logging.Measure(
+		ctx,
+		doHarmlessCommit,
+		ctx.Framework,
+		ctx.ParentContext.ParentContext.ComponentRepoUrl,
+		ctx.ParentContext.ParentContext.Opts.ComponentRepoRevision,
+	)
+	if err != nil {
+		return logging.Logger.Fail(69, "Commiting to repo for component %s in namespace %s failed: %v", ctx.ComponentName, ctx.ParentContext.ParentContext.Namespace, err)
+	}
+
+	var ok bool
+	ctx.CommitSHA, ok = iface.(string)
+	if !ok {
+		return logging.Logger.Fail(74, "Type assertion failed on commit SHA: %+v", iface)
+	}
+
+	logging.Logger.Debug("Pushed commit %s for component %s in namespace %s", ctx.CommitSHA, ctx.ComponentName, ctx.ParentContext.ParentContext.Namespace)
+
+	return nil
+}
@@ -39,8 +39,9 @@ func HandlePersistentVolumeClaim(ctx *types.PerUserContext) error {
 
 	logging.Logger.Debug("Collecting persistent volume claim wait times in namespace %s", ctx.Namespace)
 
+	// Collect even when journeys were stopped
 	err = collectPersistentVolumeClaims(
-		ctx.Framework,
+		unboundFramework(ctx.Framework),
 		ctx.Namespace,
 	)
 	if err != nil {
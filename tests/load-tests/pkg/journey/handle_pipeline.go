@@ -1,50 +1,70 @@
 package journey
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"strings"
 	"time"
 
+	informer "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/informer"
 	logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 	types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
 
 	framework "github.com/konflux-ci/e2e-tests/pkg/framework"
 
-	utils "github.com/konflux-ci/e2e-tests/pkg/utils"
-
 	pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	dynamic "k8s.io/client-go/dynamic"
 )
 
-func validatePipelineRunCreation(f *framework.Framework, namespace, appName, compName string) error {
-	interval := time.Second * 20
-	timeout := time.Minute * 30
-	var pr *pipeline.PipelineRun
+// Labels of build PipelineRun of the component, of the build of given commit when sha is known
+func buildPipelineRunLabels(appName, compName, sha string) map[string]string {
+	labels := map[string]string{
+		"appstudio.openshift.io/component":      compName,
+		"appstudio.openshift.io/application":    appName,
+		"pipelines.appstudio.openshift.io/type": "build",
+	}
+	if sha != "" {
+		labels["pipelinesascode.tekton.dev/sha"] = sha
+	}
+	return labels
+}
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.HasController.GetComponentPipelineRunWithType(compName, appName, namespace, "build", "", "")
-		if err != nil {
-			logging.Logger.Debug("Unable to get created PipelineRun for component %s in namespace %s: %v", compName, namespace, err)
-			return false, nil
-		}
+// Wait for build PipelineRun of the component that was not observed yet and remember it
+// PipelineRuns of earlier builds are still around when new commit is pushed, so they
+// are excluded by the pushed revision (when known) and by being observed already.
+func selectBuildPipelineRun(ctx context.Context, client dynamic.Interface, namespace, appName, compName, sha string, observed map[string]bool, timeout time.Duration) (string, error) {
+	// Condition runs in informer goroutine, so it gets its own copy
+	seen := maps.Clone(observed)
 
-		logging.Logger.Debug("Build PipelineRun %s for component %s in namespace %s created", pr.GetName(), compName, namespace)
-		return true, nil
-	}, interval, timeout)
+	pr, err := waitForIn(ctx, client, namespace, informer.PipelineRuns, buildPipelineRunLabels(appName, compName, sha), timeout, func(pr *pipeline.PipelineRun) (bool, error) {
+		return !seen[string(pr.GetUID())], nil
+	})
+	if err != nil {
+		return "", err
+	}
 
-	return err
+	observed[string(pr.GetUID())] = true
+	return pr.GetName(), nil
+}
+
+func validatePipelineRunCreation(ctx context.Context, f *framework.Framework, namespace, appName, compName, sha string, observed map[string]bool) (string, error) {
+	timeout := time.Minute * 30
+
+	name, err := selectBuildPipelineRun(ctx, f.AsKubeDeveloper.CommonController.DynamicClient(), namespace, appName, compName, sha, observed, timeout)
+	if err != nil {
+		return "", err
+	}
+
+	logging.Logger.Debug("Build PipelineRun %s for component %s in namespace %s created", name, compName, namespace)
+	return name, nil
 }
 
-func validatePipelineRunCondition(f *framework.Framework, namespace, appName, compName string) error {
-	interval := time.Second * 20
+func validatePipelineRunCondition(ctx context.Context, f *framework.Framework, namespace, appName, compName, prName string) error {
 	timeout := time.Minute * 60
-	var pr *pipeline.PipelineRun
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.HasController.GetComponentPipelineRunWithType(compName, appName, namespace, "build", "", "")
-		if err != nil {
-			logging.Logger.Debug("Unable to get created PipelineRun for component %s in namespace %s: %v", compName, namespace, err)
+	_, err := waitFor(ctx, f, namespace, informer.PipelineRuns, buildPipelineRunLabels(appName, compName, ""), timeout, func(pr *pipeline.PipelineRun) (bool, error) {
+		if pr.GetName() != prName {
 			return false, nil
 		}
 
@@ -69,43 +89,30 @@ func validatePipelineRunCondition(f *framework.Framework, namespace, appName, co
 
 		logging.Logger.Trace("Still waiting for pipeline run condition for component %s in namespace %s", compName, namespace)
 		return false, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
 
-func validatePipelineRunSignature(f *framework.Framework, namespace, appName, compName string) error {
-	interval := time.Second * 20
+func validatePipelineRunSignature(ctx context.Context, f *framework.Framework, namespace, appName, compName, prName string) error {
 	timeout := time.Minute * 60
-	var pr *pipeline.PipelineRun
-
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.HasController.GetComponentPipelineRunWithType(compName, appName, namespace, "build", "", "")
-		if err != nil {
-			logging.Logger.Debug("Unable to get created PipelineRun for component %s in namespace %s: %v", compName, namespace, err)
-			return false, nil
-		}
 
-		// Check if there are some annotations
-		if len(pr.Annotations) == 0 {
-			logging.Logger.Debug("PipelineRun for component %s in namespace %s lacks metadata annotations", compName, namespace)
+	_, err := waitFor(ctx, f, namespace, informer.PipelineRuns, buildPipelineRunLabels(appName, compName, ""), timeout, func(pr *pipeline.PipelineRun) (bool, error) {
+		if pr.GetName() != prName {
 			return false, nil
 		}
 
 		// Check for right annotation
-		if _, exists := pr.Annotations["chains.tekton.dev/signed"]; exists {
-			if pr.Annotations["chains.tekton.dev/signed"] == "true" {
+		if signed, exists := pr.Annotations["chains.tekton.dev/signed"]; exists {
+			if signed == "true" {
 				return true, nil
-			} else {
-				logging.Logger.Debug("PipelineRun for component %s in namespace %s still not signed", compName, namespace)
-				return false, nil
 			}
+			logging.Logger.Debug("PipelineRun for component %s in namespace %s still not signed", compName, namespace)
 		} else {
 			logging.Logger.Debug("PipelineRun for component %s in namespace %s do not have 'chains.tekton.dev/signed' annotation", compName, namespace)
-			return false, nil
 		}
-	}, interval, timeout)
+		return false, nil
+	})
 
 	return err
 }
@@ -116,30 +123,44 @@ func HandlePipelineRun(ctx *types.PerComponentContext) error {
 	}
 
 	var err error
+	var ok bool
+
+	if ctx.ObservedUIDs == nil {
+		ctx.ObservedUIDs = map[string]bool{}
+	}
 
 	logging.Logger.Debug("Waiting for build pipeline run for component %s in namespace %s to be created", ctx.ComponentName, ctx.ParentContext.ParentContext.Namespace)
 
-	_, err = logging.Measure(
+	result, err := logging.Measure(
 		ctx,
 		validatePipelineRunCreation,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ParentContext.ApplicationName,
 		ctx.ComponentName,
+		ctx.CommitSHA,
+		ctx.ObservedUIDs,
 	)
 	if err != nil {
 		return logging.Logger.Fail(70, "Build Pipeline Run failed creation: %v", err)
 	}
+	ctx.PipelineRunName, ok = result.(string)
+	if !ok {
+		return logging.Logger.Fail(73, "Build Pipeline Run name type assertion failed")
+	}
 
 	logging.Logger.Debug("Waiting for build pipeline run for component %s in namespace %s to finish", ctx.ComponentName, ctx.ParentContext.ParentContext.Namespace)
 
 	_, err = logging.Measure(
 		ctx,
 		validatePipelineRunCondition,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ParentContext.ApplicationName,
 		ctx.ComponentName,
+		ctx.PipelineRunName,
 	)
 	if err != nil {
 		return logging.Logger.Fail(71, "Build Pipeline Run failed run: %v", err)
@@ -150,10 +171,12 @@ func HandlePipelineRun(ctx *types.PerComponentContext) error {
 	_, err = logging.Measure(
 		ctx,
 		validatePipelineRunSignature,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ParentContext.ApplicationName,
 		ctx.ComponentName,
+		ctx.PipelineRunName,
 	)
 	if err != nil {
 		return logging.Logger.Fail(72, "Build Pipeline Run failed signing: %v", err)
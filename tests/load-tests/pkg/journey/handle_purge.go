@@ -1,14 +1,59 @@
 package journey
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	checkpoint "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/checkpoint"
+	informer "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/informer"
+	loadtestutils "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/loadtestutils"
 	logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+	options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
 
 	framework "github.com/konflux-ci/e2e-tests/pkg/framework"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// Resources of kinds recorded in the checkpoint
+var checkpointResources = map[string]schema.GroupVersionResource{
+	checkpoint.Application:             {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "applications"},
+	checkpoint.IntegrationTestScenario: {Group: "appstudio.redhat.com", Version: "v1beta2", Resource: "integrationtestscenarios"},
+	checkpoint.ReleasePlan:             {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releaseplans"},
+	checkpoint.ReleasePlanAdmission:    {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "releaseplanadmissions"},
+	checkpoint.Component:               {Group: "appstudio.redhat.com", Version: "v1alpha1", Resource: "components"},
+	checkpoint.Snapshot:                informer.Snapshots,
+	checkpoint.Release:                 informer.Releases,
+}
+
+// Delete resources recorded in the checkpoint for the user and the fork of its component repository
+func purgeRecorded(target purgeTarget) error {
+	client := target.framework.AsKubeDeveloper.CommonController.DynamicClient()
+	for _, r := range CurrentCheckpoint.AllResourcesOf(target.index) {
+		gvr, ok := checkpointResources[r.Kind]
+		if !ok {
+			continue
+		}
+		err := client.Resource(gvr).Namespace(target.namespace).Delete(context.Background(), r.Name, metav1.DeleteOptions{})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("error when deleting %s %s in namespace %s: %v", r.Kind, r.Name, target.namespace, err)
+		}
+	}
+
+	if target.componentRepoUrl != "" {
+		err := DeleteFork(target.framework, target.componentRepoUrl)
+		if err != nil {
+			return fmt.Errorf("error when deleting fork %s of user %s: %v", target.componentRepoUrl, target.username, err)
+		}
+	}
+
+	logging.Logger.Debug("Finished purging resources recorded for user %s", target.username)
+	return nil
+}
+
 func purgeStage(f *framework.Framework, namespace string) error {
 	var err error
 
@@ -51,22 +96,78 @@ func purgeCi(f *framework.Framework, username string) error {
 	return nil
 }
 
-func Purge() error {
-	if !PerUserContexts[0].Opts.Purge {
+// Target of purging, either user thread of this run or user from checkpoint
+type purgeTarget struct {
+	framework        *framework.Framework
+	index            int
+	username         string
+	namespace        string
+	componentRepoUrl string
+}
+
+// Collect users to purge: all the user threads of this run plus users
+// recorded in the checkpoint only (e.g. when previous run crashed)
+func purgeTargets(opts *options.Opts) ([]purgeTarget, error) {
+	var targets []purgeTarget
+	known := map[int]bool{}
+
+	for _, ctx := range PerUserContexts {
+		if ctx.Framework == nil {
+			continue
+		}
+		targets = append(targets, purgeTarget{unboundFramework(ctx.Framework), ctx.UserIndex, ctx.Username, ctx.Namespace, ctx.ComponentRepoUrl})
+		known[ctx.UserIndex] = true
+	}
+
+	var stageUsers []loadtestutils.User
+	for _, u := range CurrentCheckpoint.AllUsers() {
+		if known[u.Index] || u.Username == "" {
+			continue
+		}
+		if opts.Stage && stageUsers == nil {
+			var err error
+			stageUsers, err = loadtestutils.LoadStageUsers("users.json")
+			if err != nil {
+				return nil, fmt.Errorf("failed to load Stage users: %v", err)
+			}
+		}
+		f, namespace, err := provisionFramework(stageUsers, u.Index, u.Username, opts.Stage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to provision framework for user %s from checkpoint: %v", u.Username, err)
+		}
+		targets = append(targets, purgeTarget{f, u.Index, u.Username, namespace, u.ComponentRepoUrl})
+	}
+
+	return targets, nil
+}
+
+func Purge(opts *options.Opts) error {
+	if !opts.Purge {
 		return nil
 	}
 
+	targets, err := purgeTargets(opts)
+	if err != nil {
+		return err
+	}
+
 	errCounter := 0
 
-	for _, ctx := range PerUserContexts {
-		if ctx.Opts.Stage {
-			err := purgeStage(ctx.Framework, ctx.Namespace)
+	for _, target := range targets {
+		err := purgeRecorded(target)
+		if err != nil {
+			logging.Logger.Error("Error when purging recorded resources: %v", err)
+			errCounter++
+		}
+
+		if opts.Stage {
+			err := purgeStage(target.framework, target.namespace)
 			if err != nil {
 				logging.Logger.Error("Error when purging Stage: %v", err)
 				errCounter++
 			}
 		} else {
-			err := purgeCi(ctx.Framework, ctx.Username)
+			err := purgeCi(target.framework, target.username)
 			if err != nil {
 				logging.Logger.Error("Error when purging CI: %v", err)
 				errCounter++
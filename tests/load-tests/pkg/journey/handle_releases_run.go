@@ -1,79 +1,69 @@
 package journey
 
+import "context"
 import "fmt"
 import "strings"
 import "time"
 
+import informer "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/informer"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
 
 import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
-import utils "github.com/konflux-ci/e2e-tests/pkg/utils"
+import releaseApi "github.com/konflux-ci/release-service/api/v1alpha1"
 import pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
 
 // Wait for Release CR to be created
-func validateReleaseCreation(f *framework.Framework, namespace, snapshotName string) (string, error) {
+func validateReleaseCreation(ctx context.Context, f *framework.Framework, namespace, snapshotName string) (string, error) {
 	logging.Logger.Debug("Waiting for release for snapshot %s in namespace %s to be created", snapshotName, namespace)
 
-	var releaseName string
-
-	interval := time.Second * 10
 	timeout := time.Minute * 5
 
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		release, err := f.AsKubeDeveloper.ReleaseController.GetRelease("", snapshotName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Can not get release for snapshot %s in namespace %s: %v\n", snapshotName, namespace, err)
-			return false, nil
-		}
+	release, err := waitFor(ctx, f, namespace, informer.Releases, nil, timeout, func(release *releaseApi.Release) (bool, error) {
+		return release.Spec.Snapshot == snapshotName, nil
+	})
+	if err != nil {
+		return "", err
+	}
 
-		releaseName = release.Name
+	return release.Name, nil
+}
 
-		return true, nil
-	}, interval, timeout)
 
-	return releaseName, err
+// Labels of release PipelineRun of the release
+func releasePipelineRunLabels(namespace, releaseName string) map[string]string {
+	return map[string]string{
+		"release.appstudio.openshift.io/name":      releaseName,
+		"release.appstudio.openshift.io/namespace": namespace,
+	}
 }
 
 
 // Wait for release pipeline run to be created
-func validateReleasePipelineRunCreation(f *framework.Framework, namespace, releaseName string) error {
+func validateReleasePipelineRunCreation(ctx context.Context, f *framework.Framework, namespace, releaseName string) error {
 	logging.Logger.Debug("Waiting for release pipeline for release %s in namespace %s to be created", releaseName, namespace)
 
-	var pr *pipeline.PipelineRun
-
-	interval := time.Second * 10
 	timeout := time.Minute * 5
 
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.ReleaseController.GetPipelineRunInNamespace(namespace, releaseName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Pipelinerun for release %s in namespace %s not created yet: %v\n", releaseName, namespace, err)
-			return false, nil
-		}
-
-		logging.Logger.Debug("Release PipelineRun %s for release %s in namespace %s created", pr.GetName(), releaseName, namespace)
+	pr, err := waitFor(ctx, f, namespace, informer.PipelineRuns, releasePipelineRunLabels(namespace, releaseName), timeout, func(pr *pipeline.PipelineRun) (bool, error) {
 		return true, nil
-	}, interval, timeout)
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	logging.Logger.Debug("Release PipelineRun %s for release %s in namespace %s created", pr.GetName(), releaseName, namespace)
+	return nil
 }
 
 
 // Wait for release pipeline run to succeed
-func validateReleasePipelineRunCondition(f *framework.Framework, namespace, releaseName string) error {
+func validateReleasePipelineRunCondition(ctx context.Context, f *framework.Framework, namespace, releaseName string) error {
 	logging.Logger.Debug("Waiting for release pipeline for release %s in namespace %s to finish", releaseName, namespace)
 
-	interval := time.Second * 10
 	timeout := time.Minute * 10
 
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pipelineRun, err := f.AsKubeDeveloper.ReleaseController.GetPipelineRunInNamespace(namespace, releaseName, namespace)
-		if err != nil {
-			logging.Logger.Debug("PipelineRun for release %s in namespace %s not created yet: %v\n", releaseName, namespace, err)
-			return false, nil
-		}
-
+	_, err := waitFor(ctx, f, namespace, informer.PipelineRuns, releasePipelineRunLabels(namespace, releaseName), timeout, func(pipelineRun *pipeline.PipelineRun) (bool, error) {
 		// Check if there are some conditions
 		if len(pipelineRun.Status.Conditions) == 0 {
 			logging.Logger.Debug("PipelineRun %s in namespace %s lacks status conditions\n", pipelineRun.GetName(), pipelineRun.GetNamespace())
@@ -94,23 +84,20 @@ func validateReleasePipelineRunCondition(f *framework.Framework, namespace, rele
 		}
 
 		return false, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
 
 
 // Wait for Release CR to have a succeeding status
-func validateReleaseCondition(f *framework.Framework, namespace, releaseName string) error {
+func validateReleaseCondition(ctx context.Context, f *framework.Framework, namespace, releaseName string) error {
 	logging.Logger.Debug("Waiting for release %s in namespace %s to finish", releaseName, namespace)
 
-	interval := time.Second * 10
 	timeout := time.Minute * 5
 
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		release, err := f.AsKubeDeveloper.ReleaseController.GetRelease(releaseName, "", namespace)
-		if err != nil {
-			logging.Logger.Debug("Can not get release %s in namespace %s: %v", releaseName, namespace, err)
+	_, err := waitFor(ctx, f, namespace, informer.Releases, nil, timeout, func(release *releaseApi.Release) (bool, error) {
+		if release.Name != releaseName {
 			return false, nil
 		}
 
@@ -139,7 +126,7 @@ func validateReleaseCondition(f *framework.Framework, namespace, releaseName str
 
 		logging.Logger.Debug("Release %s in namespace %s missing expected condition", releaseName, namespace)
 		return false, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
@@ -158,6 +145,7 @@ func HandleReleaseRun(ctx *types.PerComponentContext) error {
 	iface, err = logging.Measure(
 		ctx,
 		validateReleaseCreation,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.SnapshotName,
@@ -174,6 +162,7 @@ func HandleReleaseRun(ctx *types.PerComponentContext) error {
 	_, err = logging.Measure(
 		ctx,
 		validateReleasePipelineRunCreation,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ReleaseName,
@@ -185,6 +174,7 @@ func HandleReleaseRun(ctx *types.PerComponentContext) error {
 	_, err = logging.Measure(
 		ctx,
 		validateReleasePipelineRunCondition,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ReleaseName,
@@ -196,6 +186,7 @@ func HandleReleaseRun(ctx *types.PerComponentContext) error {
 	_, err = logging.Measure(
 		ctx,
 		validateReleaseCondition,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ReleaseName,
@@ -213,6 +213,23 @@ func ForkRepo(f *framework.Framework, repoUrl, repoRevision, suffix, targetOrgNa
 	}
 }
 
+// Delete fork created by ForkRepo, fork that does not exist anymore is fine
+func DeleteFork(f *framework.Framework, forkUrl string) error {
+	name, err := getRepoNameFromRepoUrl(forkUrl)
+	if err != nil {
+		return err
+	}
+
+	if strings.Contains(forkUrl, "gitlab.") {
+		org, err := getRepoOrgFromRepoUrl(forkUrl)
+		if err != nil {
+			return err
+		}
+		return f.AsKubeAdmin.CommonController.Gitlab.DeleteRepositoryIfExists(org + "/" + name)
+	}
+	return f.AsKubeAdmin.CommonController.Github.DeleteRepositoryIfExists(name)
+}
+
 // Template PaC files
 func templateFiles(f *framework.Framework, repoUrl, repoRevision, sourceRepo, sourceRepoDir string, placeholders *map[string]string) (*map[string]string, error) {
 	// Template files we care about
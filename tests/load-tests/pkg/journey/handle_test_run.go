@@ -1,78 +1,84 @@
 package journey
 
+import "context"
 import "fmt"
+import "maps"
 import "strings"
 import "time"
 
+import informer "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/informer"
 import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
 import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
 
 import appstudioApi "github.com/konflux-ci/application-api/api/v1alpha1"
 import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
-import utils "github.com/konflux-ci/e2e-tests/pkg/utils"
 import pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+import dynamic "k8s.io/client-go/dynamic"
 
-func validateSnapshotCreation(f *framework.Framework, namespace, compName string) (string, error) {
-	logging.Logger.Debug("Waiting for snapshot for component %s in namespace %s to be created", compName, namespace)
-
-	interval := time.Second * 20
-	timeout := time.Minute * 5
-	var snap *appstudioApi.Snapshot
+// Labels of snapshot of the component, of the snapshot created by given build when it is known
+func snapshotLabels(compName, buildPipelineRunName string) map[string]string {
+	labels := map[string]string{"appstudio.openshift.io/component": compName}
+	if buildPipelineRunName != "" {
+		labels["appstudio.openshift.io/build-pipelinerun"] = buildPipelineRunName
+	}
+	return labels
+}
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		snap, err = f.AsKubeDeveloper.IntegrationController.GetSnapshot("", "", compName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Unable to get created Snapshot for component %s in namespace %s: %v", compName, namespace, err)
-			return false, nil
-		}
-		return true, nil
-	}, interval, timeout)
+// Wait for snapshot of the component that was not observed yet and remember it
+// Snapshots of earlier builds are excluded the same way as in selectBuildPipelineRun.
+func selectSnapshot(ctx context.Context, client dynamic.Interface, namespace, compName, buildPipelineRunName string, observed map[string]bool, timeout time.Duration) (string, error) {
+	seen := maps.Clone(observed)
 
+	snap, err := waitForIn(ctx, client, namespace, informer.Snapshots, snapshotLabels(compName, buildPipelineRunName), timeout, func(snap *appstudioApi.Snapshot) (bool, error) {
+		return !seen[string(snap.GetUID())], nil
+	})
 	if err != nil {
 		return "", err
 	}
 
-	return snap.Name, err
+	observed[string(snap.GetUID())] = true
+	return snap.Name, nil
 }
 
-func validateTestPipelineRunCreation(f *framework.Framework, namespace, itsName, snapName string) error {
-	logging.Logger.Debug("Waiting for test pipeline run for ITS %s and snapshot %s in namespace %s to be created", itsName, snapName, namespace)
+func validateSnapshotCreation(ctx context.Context, f *framework.Framework, namespace, compName, buildPipelineRunName string, observed map[string]bool) (string, error) {
+	logging.Logger.Debug("Waiting for snapshot for component %s in namespace %s to be created", compName, namespace)
 
-	interval := time.Second * 20
 	timeout := time.Minute * 5
-	var pr *pipeline.PipelineRun
 
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.IntegrationController.GetIntegrationPipelineRun(itsName, snapName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Unable to get created test PipelineRun for integration test pipeline %s in namespace %s: %v", itsName, namespace, err)
-			return false, nil
-		}
+	return selectSnapshot(ctx, f.AsKubeDeveloper.CommonController.DynamicClient(), namespace, compName, buildPipelineRunName, observed, timeout)
+}
+
+// Labels of test PipelineRun of the integration test scenario and snapshot
+func testPipelineRunLabels(itsName, snapName string) map[string]string {
+	return map[string]string{
+		"pipelines.appstudio.openshift.io/type": "test",
+		"test.appstudio.openshift.io/scenario":  itsName,
+		"appstudio.openshift.io/snapshot":       snapName,
+	}
+}
+
+func validateTestPipelineRunCreation(ctx context.Context, f *framework.Framework, namespace, itsName, snapName string) error {
+	logging.Logger.Debug("Waiting for test pipeline run for ITS %s and snapshot %s in namespace %s to be created", itsName, snapName, namespace)
 
-		logging.Logger.Debug("Test PipelineRun %s for its %s and snap %s in namespace %s created", pr.GetName(), itsName, snapName, namespace)
+	timeout := time.Minute * 5
+
+	pr, err := waitFor(ctx, f, namespace, informer.PipelineRuns, testPipelineRunLabels(itsName, snapName), timeout, func(pr *pipeline.PipelineRun) (bool, error) {
 		return true, nil
-	}, interval, timeout)
+	})
+	if err != nil {
+		return err
+	}
 
-	return err
+	logging.Logger.Debug("Test PipelineRun %s for its %s and snap %s in namespace %s created", pr.GetName(), itsName, snapName, namespace)
+	return nil
 }
 
-func validateTestPipelineRunCondition(f *framework.Framework, namespace, itsName, snapName string) error {
+func validateTestPipelineRunCondition(ctx context.Context, f *framework.Framework, namespace, itsName, snapName string) error {
 	logging.Logger.Debug("Waiting for test pipeline run for ITS %s and snapshot %s in namespace %s to finish", itsName, snapName, namespace)
 
-	interval := time.Second * 20
 	timeout := time.Minute * 10
-	var pr *pipeline.PipelineRun
-
-	// TODO It would be much better to watch this resource for a condition
-	err := utils.WaitUntilWithInterval(func() (done bool, err error) {
-		pr, err = f.AsKubeDeveloper.IntegrationController.GetIntegrationPipelineRun(itsName, snapName, namespace)
-		if err != nil {
-			logging.Logger.Debug("Unable to get created test PipelineRun for integration test pipeline %s in namespace %s: %v", snapName, namespace, err)
-			return false, nil
-		}
 
+	_, err := waitFor(ctx, f, namespace, informer.PipelineRuns, testPipelineRunLabels(itsName, snapName), timeout, func(pr *pipeline.PipelineRun) (bool, error) {
 		// Check if there are some conditions
 		if len(pr.Status.Conditions) == 0 {
 			logging.Logger.Debug("PipelineRun for integration test pipeline %s in namespace %s lacks status conditions", snapName, namespace)
@@ -91,7 +97,7 @@ func validateTestPipelineRunCondition(f *framework.Framework, namespace, itsName
 
 		logging.Logger.Trace("Still waiting for test pipeline run for integration test pipeline %s in namespace %s", snapName, namespace)
 		return false, nil
-	}, interval, timeout)
+	})
 
 	return err
 }
@@ -104,12 +110,19 @@ func HandleTest(ctx *types.PerComponentContext) error {
 	var err error
 	var ok bool
 
+	if ctx.ObservedUIDs == nil {
+		ctx.ObservedUIDs = map[string]bool{}
+	}
+
 	result1, err1 := logging.Measure(
 		ctx,
 		validateSnapshotCreation,
+		ctx.ParentContext.ParentContext.Context,
 		ctx.Framework,
 		ctx.ParentContext.ParentContext.Namespace,
 		ctx.ComponentName,
+		ctx.PipelineRunName,
+		ctx.ObservedUIDs,
 	)
 	if err1 != nil {
 		return logging.Logger.Fail(80, "Snapshot failed creation: %v", err1)
@@ -127,6 +140,7 @@ func HandleTest(ctx *types.PerComponentContext) error {
 		_, err = logging.Measure(
 			ctx,
 			validateTestPipelineRunCreation,
+			ctx.ParentContext.ParentContext.Context,
 			ctx.Framework,
 			ctx.ParentContext.ParentContext.Namespace,
 			ctx.ParentContext.IntegrationTestScenarioName,
@@ -141,6 +155,7 @@ func HandleTest(ctx *types.PerComponentContext) error {
 		_, err = logging.Measure(
 			ctx,
 			validateTestPipelineRunCondition,
+			ctx.ParentContext.ParentContext.Context,
 			ctx.Framework,
 			ctx.ParentContext.ParentContext.Namespace,
 			ctx.ParentContext.IntegrationTestScenarioName,
@@ -1,5 +1,6 @@
 package journey
 
+import "context"
 import "fmt"
 import "time"
 import "strings"
@@ -36,6 +37,11 @@ func provisionFramework(stageUsers []loadtestutils.User, threadIndex int, userna
 	return f, f.UserNamespace, nil
 }
 
+// Framework whose API calls are not cancelled with the journeys, for collecting and purging once they were stopped
+func unboundFramework(f *framework.Framework) *framework.Framework {
+	return f.WithContext(context.Background())
+}
+
 func HandleUser(ctx *types.PerUserContext) error {
 	var err error
 
@@ -54,6 +60,7 @@ func HandleUser(ctx *types.PerUserContext) error {
 	if err != nil {
 		return logging.Logger.Fail(10, "Unable to provision user %s: %v", ctx.Username, err)
 	}
+	ctx.Framework = ctx.Framework.WithContext(ctx.Context)
 
 	return nil
 }
@@ -70,6 +77,7 @@ func HandleNewFrameworkForApp(ctx *types.PerApplicationContext) error {
 	if err != nil {
 		return logging.Logger.Fail(11, "Unable to provision framework for user %s: %v", ctx.ParentContext.Username, err)
 	}
+	ctx.Framework = ctx.Framework.WithContext(ctx.ParentContext.Context)
 
 	return nil
 }
@@ -86,6 +94,7 @@ func HandleNewFrameworkForComp(ctx *types.PerComponentContext) error {
 	if err != nil {
 		return logging.Logger.Fail(12, "Unable to provision framework for user %s: %v", ctx.ParentContext.ParentContext.Username, err)
 	}
+	ctx.Framework = ctx.Framework.WithContext(ctx.ParentContext.ParentContext.Context)
 
 	return nil
 }
@@ -0,0 +1,88 @@
+package journey
+
+import "context"
+import "os"
+import "os/signal"
+import "syscall"
+import "time"
+
+import informer "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/informer"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+
+import framework "github.com/konflux-ci/e2e-tests/pkg/framework"
+import unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+import runtime "k8s.io/apimachinery/pkg/runtime"
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+import dynamic "k8s.io/client-go/dynamic"
+
+// Shared informers all journey handlers wait for PipelineRuns, Snapshots and Releases with
+var Informers = informer.NewCache()
+
+// Context of all the journeys, cancelled when the run is stopped (see SetupJourneysContext) or when informers are stopped
+var journeysCtx, cancelJourneys = context.WithCancel(context.Background())
+
+// Derive context of all the journeys from interrupt signals and '--journey-duration'
+// First SIGINT or SIGTERM stops waits and API calls of in-flight journeys so the
+// run continues with collecting and purging, the next one terminates the test.
+// In closed-loop mode journeys still running when '--journey-duration' passes are
+// stopped as well.
+func SetupJourneysContext(opts *options.Opts) {
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signalCtx.Done()
+		stopSignals()
+	}()
+
+	ctx, cancelDeadline := context.WithCancel(signalCtx)
+	if opts.ArrivalProfile == "" && opts.ReplayTrace == "" {
+		ctx, cancelDeadline = context.WithDeadline(signalCtx, opts.JourneyUntil)
+	}
+
+	journeysCtx = ctx
+	cancelJourneys = func() {
+		cancelDeadline()
+		stopSignals()
+	}
+}
+
+// Stop all the informers, nothing will be waited for anymore
+func StopInformers() {
+	cancelJourneys()
+	Informers.Stop()
+}
+
+// Wait until object of given resource with given labels in namespace satisfies condition
+// Objects are converted to T for the condition check. This watches the namespace
+// (once for all the threads) instead of polling it, so we return as soon as the
+// object changes.
+func waitFor[T any](ctx context.Context, f *framework.Framework, namespace string, resource schema.GroupVersionResource, labels map[string]string, timeout time.Duration, cond func(obj *T) (bool, error)) (*T, error) {
+	return waitForIn(ctx, f.AsKubeDeveloper.CommonController.DynamicClient(), namespace, resource, labels, timeout, cond)
+}
+
+// Same as waitFor, with the client to watch the namespace with
+func waitForIn[T any](ctx context.Context, client dynamic.Interface, namespace string, resource schema.GroupVersionResource, labels map[string]string, timeout time.Duration, cond func(obj *T) (bool, error)) (*T, error) {
+	obj, err := Informers.WaitFor(ctx, client, namespace, resource, timeout, func(u *unstructured.Unstructured) (bool, error) {
+		objLabels := u.GetLabels()
+		for k, v := range labels {
+			if objLabels[k] != v {
+				return false, nil
+			}
+		}
+		var typed T
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &typed)
+		if err != nil {
+			return false, err
+		}
+		return cond(&typed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var typed T
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &typed)
+	if err != nil {
+		return nil, err
+	}
+	return &typed, nil
+}
@@ -28,6 +28,9 @@ func initUserThread(perUserCtx *types.PerUserContext) {
 		logging.Logger.Error("Thread failed: %v", err)
 		return
 	}
+
+	// Continue where previous run stopped if resuming
+	restoreUser(perUserCtx)
 }
 
 // Helper function to compute duration to delay startup of some threads based on StartupDelay and StartupJitter command-line options
@@ -43,10 +46,17 @@ func computeStartupPause(index int, delay, jitter time.Duration) time.Duration {
 	}
 }
 
-// Start all the user journey threads
-// TODO split this to two functions and get PurgeOnly code out
-func PerUserSetup(fn func(*types.PerUserContext), opts *options.Opts) (string, error) {
+// Initialize all the user contexts, create users (if necessary) and fork their repositories
+// Returns wait group shared by all the user contexts
+func initUsers(opts *options.Opts) (*sync.WaitGroup, error) {
 	perUserWG := &sync.WaitGroup{}
+
+	// Purge works from the checkpoint alone, no need to provision users again
+	if opts.PurgeOnly && opts.Resume {
+		logging.Logger.Info("Skipping users initialization as we will purge resources from the checkpoint")
+		return perUserWG, nil
+	}
+
 	perUserWG.Add(opts.Concurrency)
 
 	var stageUsers []loadtestutils.User
@@ -66,6 +76,7 @@ func PerUserSetup(fn func(*types.PerUserContext), opts *options.Opts) (string, e
 
 		perUserCtx := &types.PerUserContext{
 			PerUserWG:        perUserWG,
+			Context:          journeysCtx,
 			UserIndex:        userIndex,
 			StartupPause:     startupPause,
 			Opts:             opts,
@@ -87,19 +98,34 @@ func PerUserSetup(fn func(*types.PerUserContext), opts *options.Opts) (string, e
 	// If we are supposed to only purge resources, now when frameworks are initialized, we are done
 	if opts.PurgeOnly {
 		logging.Logger.Info("Skipping rest of user journey as we were asked to just purge resources")
-		return "", nil
+		return perUserWG, nil
 	}
 
 	// Fork repositories sequentially as GitHub do not allow more than 3 running forks in parallel anyway
 	for _, perUserCtx := range PerUserContexts {
+		if perUserCtx.ComponentRepoUrl != "" {
+			logging.Logger.Debug("Skipping forking for user %s, using %s from checkpoint", perUserCtx.Username, perUserCtx.ComponentRepoUrl)
+			continue
+		}
 		_, err = logging.Measure(
 			perUserCtx,
 			HandleRepoForking,
 			perUserCtx,
 		)
 		if err != nil {
-			return "", err
+			return perUserWG, err
 		}
+		recordFork(perUserCtx)
+	}
+
+	return perUserWG, nil
+}
+
+// Start all the user journey threads
+func PerUserSetup(fn func(*types.PerUserContext), opts *options.Opts) (string, error) {
+	perUserWG, err := initUsers(opts)
+	if err != nil || opts.PurgeOnly {
+		return "", err
 	}
 
 	perUserWG.Add(opts.Concurrency)
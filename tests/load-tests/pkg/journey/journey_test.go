@@ -90,3 +90,25 @@ func Test_getRepoIdFromRepoUrl(t *testing.T) {
 		}
 	}
 }
+
+// Test journey definitions are selected by name and unknown steps are refused.
+func Test_LoadDefinition(t *testing.T) {
+	definition, err := LoadDefinition("", "default")
+	if err != nil || definition.Name != "default" || len(definition.ComponentSteps) != 4 {
+		t.Errorf("Failed loading built-in default journey: %+v, %v", definition, err)
+	}
+
+	definition, err = LoadDefinition("../../journeys-example.json", "build-only")
+	if err != nil || len(definition.ApplicationSteps) != 1 || len(definition.ComponentSteps) != 2 {
+		t.Errorf("Failed loading build-only journey: %+v, %v", definition, err)
+	}
+
+	if _, err = LoadDefinition("../../journeys-example.json", "missing"); err == nil {
+		t.Errorf("Expected missing journey to fail")
+	}
+
+	definition = &Definition{Name: "broken", ComponentSteps: []string{"HandleNothing"}}
+	if err = definition.Validate(); err == nil {
+		t.Errorf("Expected unknown step to fail validation")
+	}
+}
@@ -0,0 +1,249 @@
+package journey
+
+import "fmt"
+import "sync"
+import "sync/atomic"
+import "time"
+
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+import replay "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/replay"
+import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
+
+// Journey steps performing each of trace actions
+// Push waits for the build of the pushed revision, not for builds replayed before it.
+var replayApplicationSteps = []string{"HandleApplication", "HandleIntegrationTestScenario", "HandleReleaseSetup"}
+var replayComponentSteps = map[string][]string{
+	replay.CreateComponent: {"HandleComponent", "HandlePipelineRun", "HandleTest"},
+	replay.Push:            {"HandleComponentCommit", "HandlePipelineRun", "HandleTest"},
+	replay.Release:         {"HandleReleaseRun"},
+}
+
+// Application of some trace tenant, created on its first event
+type replayApplication struct {
+	mu         sync.Mutex
+	ctx        *types.PerApplicationContext
+	created    bool
+	err        error
+	components map[string]*replayComponent
+}
+
+// Component of some trace application, created on its first event
+// Lock is held for the whole action, so actions on one component do not overlap.
+type replayComponent struct {
+	mu      sync.Mutex
+	ctx     *types.PerComponentContext
+	created bool
+}
+
+// Maps trace tenants, applications and components to contexts of load test threads
+type replayer struct {
+	mu           sync.Mutex
+	users        map[string]*types.PerUserContext
+	applications map[string]*replayApplication
+	failed       atomic.Int64
+}
+
+// Replay events from '--replay-trace' file, compressed by '--replay-speedup'
+// Every trace tenant is mapped to one of the users (tenants share users when
+// there are more tenants than '--concurrency') and every action is performed
+// by the journey steps from `replayComponentSteps` at its (compressed) time.
+func PerUserReplaySetup(opts *options.Opts) (string, error) {
+	trace, err := replay.Load(opts.ReplayTrace)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = initUsers(opts)
+	if err != nil || opts.PurgeOnly {
+		return "", err
+	}
+
+	r := &replayer{users: map[string]*types.PerUserContext{}, applications: map[string]*replayApplication{}}
+	tenants := trace.Tenants()
+	if len(tenants) > len(PerUserContexts) {
+		logging.Logger.Warning("Trace has %d tenants but there are only %d users, some users will act as multiple tenants", len(tenants), len(PerUserContexts))
+	}
+	for i, tenant := range tenants {
+		r.users[tenant] = PerUserContexts[i%len(PerUserContexts)]
+	}
+
+	logging.Logger.Info("Replaying %d events of %d tenants in %v", len(trace.Events), len(tenants), trace.Duration(opts.ReplaySpeedup))
+
+	eventsWG := &sync.WaitGroup{}
+	start := time.Now()
+	for i := range trace.Events {
+		event := trace.Events[i]
+		time.Sleep(time.Until(start.Add(event.Offset(opts.ReplaySpeedup))))
+
+		eventsWG.Add(1)
+		go func() {
+			defer eventsWG.Done()
+			err := r.dispatch(&event)
+			if err != nil {
+				r.failed.Add(1)
+				logging.Logger.Error("Replaying %s of %s/%s/%s failed: %v", event.Action, event.Tenant, event.Application, event.Component, err)
+			}
+		}()
+	}
+
+	eventsWG.Wait()
+
+	logging.Logger.Info("Replayed %d events, %d failed", len(trace.Events), r.failed.Load())
+
+	r.collect()
+
+	return "", nil
+}
+
+// Get application of the event, creating its context when it is seen for the first time
+func (r *replayer) application(event *replay.Event) *replayApplication {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := event.Tenant + "/" + event.Application
+	app, ok := r.applications[key]
+	if !ok {
+		perUserCtx := r.users[event.Tenant]
+		app = &replayApplication{
+			ctx: &types.PerApplicationContext{
+				ApplicationIndex:   len(perUserCtx.PerApplicationContexts),
+				JourneyRepeatIndex: perUserCtx.JourneyRepeatsCounter,
+				ParentContext:      perUserCtx,
+			},
+			components: map[string]*replayComponent{},
+		}
+		perUserCtx.PerApplicationContexts = append(perUserCtx.PerApplicationContexts, app.ctx)
+		r.applications[key] = app
+	}
+	return app
+}
+
+// Get component of the event, creating its context when it is seen for the first time
+func (r *replayer) component(app *replayApplication, event *replay.Event) *replayComponent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comp, ok := app.components[event.Component]
+	if !ok {
+		comp = &replayComponent{
+			ctx: &types.PerComponentContext{
+				ComponentIndex: len(app.ctx.PerComponentContexts),
+				ParentContext:  app.ctx,
+			},
+		}
+		app.ctx.PerComponentContexts = append(app.ctx.PerComponentContexts, comp.ctx)
+		app.components[event.Component] = comp
+	}
+	return comp
+}
+
+// Create application unless it was created already (or failed to be created)
+func (r *replayer) ensureApplication(app *replayApplication) error {
+	app.mu.Lock()
+	defer app.mu.Unlock()
+
+	if app.created {
+		return app.err
+	}
+	app.created = true
+
+	logging.ThreadStarted(logging.ThreadApplication)
+	defer logging.ThreadFinished(logging.ThreadApplication)
+
+	_, app.err = logging.Measure(
+		app.ctx,
+		HandleNewFrameworkForApp,
+		app.ctx,
+	)
+	if app.err == nil {
+		app.err = runApplicationSteps(app.ctx, replayApplicationSteps)
+	}
+	return app.err
+}
+
+// Perform the event action using journey steps
+func (r *replayer) dispatch(event *replay.Event) error {
+	app := r.application(event)
+	err := r.ensureApplication(app)
+	if err != nil {
+		return fmt.Errorf("application not available: %v", err)
+	}
+	if event.Action == replay.CreateApplication {
+		return nil
+	}
+
+	comp := r.component(app, event)
+	comp.mu.Lock()
+	defer comp.mu.Unlock()
+
+	logging.ThreadStarted(logging.ThreadComponent)
+	defer logging.ThreadFinished(logging.ThreadComponent)
+
+	if !comp.created {
+		_, err = logging.Measure(
+			comp.ctx,
+			HandleNewFrameworkForComp,
+			comp.ctx,
+		)
+		if err != nil {
+			return err
+		}
+		comp.created = true
+
+		// Trace might start when component already existed, so onboard it first
+		if event.Action != replay.CreateComponent {
+			logging.Logger.Debug("Component %s/%s/%s was not created in the trace, creating it before %s", event.Tenant, event.Application, event.Component, event.Action)
+			err = runComponentSteps(comp.ctx, replayComponentSteps[replay.CreateComponent])
+			if err != nil {
+				return err
+			}
+		}
+	} else if event.Action == replay.CreateComponent {
+		logging.Logger.Warning("Component %s/%s/%s already created, ignoring repeated creation", event.Tenant, event.Application, event.Component)
+		return nil
+	}
+
+	return runComponentSteps(comp.ctx, replayComponentSteps[event.Action])
+}
+
+// Collect data about all the replayed applications and components
+func (r *replayer) collect() {
+	for _, app := range r.applications {
+		for _, comp := range app.components {
+			if comp.ctx.Framework == nil {
+				continue
+			}
+			_, err := logging.Measure(
+				comp.ctx,
+				HandlePerComponentCollection,
+				comp.ctx,
+			)
+			if err != nil {
+				logging.Logger.Error("Per component collection failed: %v", err)
+			}
+		}
+		if app.ctx.Framework == nil {
+			continue
+		}
+		_, err := logging.Measure(
+			app.ctx,
+			HandlePerApplicationCollection,
+			app.ctx,
+		)
+		if err != nil {
+			logging.Logger.Error("Per application collection failed: %v", err)
+		}
+	}
+
+	for _, perUserCtx := range PerUserContexts {
+		_, err := logging.Measure(
+			perUserCtx,
+			HandlePersistentVolumeClaim,
+			perUserCtx,
+		)
+		if err != nil {
+			logging.Logger.Error("Thread failed: %v", err)
+		}
+	}
+}
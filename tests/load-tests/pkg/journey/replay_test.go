@@ -0,0 +1,77 @@
+package journey
+
+import "context"
+import "testing"
+import "time"
+
+import informer "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/informer"
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+import runtime "k8s.io/apimachinery/pkg/runtime"
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+import k8stypes "k8s.io/apimachinery/pkg/types"
+import fake "k8s.io/client-go/dynamic/fake"
+
+func buildPipelineRun(name, sha, succeeded string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("tekton.dev/v1")
+	u.SetKind("PipelineRun")
+	u.SetNamespace("replay-ns")
+	u.SetName(name)
+	u.SetUID(k8stypes.UID("uid-" + name))
+	u.SetLabels(buildPipelineRunLabels("app", "comp", sha))
+	if succeeded != "" {
+		_ = unstructured.SetNestedSlice(u.Object, []interface{}{map[string]interface{}{"type": "Succeeded", "status": succeeded}}, "status", "conditions")
+	}
+	return u
+}
+
+// Test build of replayed push is not satisfied by PipelineRun of the initial build.
+func Test_selectBuildPipelineRunAfterPush(t *testing.T) {
+	client := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{informer.PipelineRuns: "PipelineRunList"})
+	prs := client.Resource(informer.PipelineRuns).Namespace("replay-ns")
+	defer Informers.StopNamespace("replay-ns")
+
+	// Component creation: the first build is waited for
+	_, _ = prs.Create(context.Background(), buildPipelineRun("comp-on-push-first", "aaa", "True"), metav1.CreateOptions{})
+	observed := map[string]bool{}
+	name, err := selectBuildPipelineRun(context.Background(), client, "replay-ns", "app", "comp", "", observed, 5*time.Second)
+	if err != nil || name != "comp-on-push-first" {
+		t.Fatalf("Expected first build, got %s: %v", name, err)
+	}
+
+	// Push: neither finished first build nor unknown revision may match
+	_, err = selectBuildPipelineRun(context.Background(), client, "replay-ns", "app", "comp", "", observed, 300*time.Millisecond)
+	if err == nil {
+		t.Errorf("Expected observed build not to match again")
+	}
+
+	done := make(chan string)
+	go func() {
+		name, err := selectBuildPipelineRun(context.Background(), client, "replay-ns", "app", "comp", "bbb", observed, 5*time.Second)
+		if err != nil {
+			t.Errorf("Waiting for build of pushed commit failed: %v", err)
+		}
+		done <- name
+	}()
+
+	select {
+	case name := <-done:
+		t.Fatalf("Push completed with %s before its build was created", name)
+	case <-time.After(300 * time.Millisecond):
+	}
+
+	_, _ = prs.Create(context.Background(), buildPipelineRun("comp-on-push-second", "bbb", ""), metav1.CreateOptions{})
+	select {
+	case name := <-done:
+		if name != "comp-on-push-second" {
+			t.Errorf("Expected build of pushed commit, got %s", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Build of pushed commit was not found")
+	}
+	if !observed["uid-comp-on-push-second"] {
+		t.Errorf("Expected build of pushed commit to be observed")
+	}
+}
@@ -0,0 +1,65 @@
+package journey
+
+import "fmt"
+
+import logging "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/logging"
+import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
+import sampler "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/sampler"
+
+import dynamic "k8s.io/client-go/dynamic"
+import kubernetes "k8s.io/client-go/kubernetes"
+import metadata "k8s.io/client-go/metadata"
+import config "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+// Sampler of cluster resources for this run, nil when sampling is disabled
+var CurrentSampler *sampler.Sampler
+
+// Load cluster sampling configuration and prepare sampler writing to 'load-test-cluster-samples.csv'
+// Call it before chaos is set up, so sampling does not go through latency proxy.
+func SetupSampling(opts *options.Opts) error {
+	if opts.ClusterSamplingConfig == "" {
+		return nil
+	}
+
+	samplingConfig, err := sampler.Load(opts.ClusterSamplingConfig)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get cluster config: %v", err)
+	}
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create cluster client: %v", err)
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamic cluster client: %v", err)
+	}
+	metadataClient, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create metadata cluster client: %v", err)
+	}
+
+	output := opts.OutputDir + "/load-test-cluster-samples.csv"
+	CurrentSampler = sampler.NewSampler(client, dynamicClient, metadataClient, samplingConfig, opts.ClusterSamplingInterval, output, func(err error) {
+		logging.Logger.Warning("Cluster sampling: %v", err)
+	})
+	return nil
+}
+
+// Start sampling cluster resources
+func StartSampling() {
+	if CurrentSampler != nil {
+		CurrentSampler.Start()
+	}
+}
+
+// Stop sampling cluster resources
+func StopSampling() {
+	if CurrentSampler != nil {
+		CurrentSampler.Stop()
+	}
+}
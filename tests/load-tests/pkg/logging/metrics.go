@@ -0,0 +1,132 @@
+package logging
+
+import "context"
+import "errors"
+import "fmt"
+import "net/http"
+import "time"
+
+import prometheus "github.com/prometheus/client_golang/prometheus"
+import collectors "github.com/prometheus/client_golang/prometheus/collectors"
+import promhttp "github.com/prometheus/client_golang/prometheus/promhttp"
+
+// Kinds of threads tracked by the active threads gauge
+const ThreadUser = "user"
+const ThreadApplication = "application"
+const ThreadComponent = "component"
+
+var metricsRegistry = prometheus.NewRegistry()
+
+var metricsServer *http.Server // HTTP server exposing /metrics, nil when disabled
+
+// Duration of measured functions, buckets go from 100ms to ~3.6h
+var measurementDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "loadtest_measurement_duration_seconds",
+		Help:    "Duration of measured load test functions.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 18),
+	},
+	[]string{"metric", "user", "application", "component", "result"},
+)
+
+var errorsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "loadtest_errors_total",
+		Help: "Number of load test failures by error code.",
+	},
+	[]string{"code"},
+)
+
+var arrivalsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "loadtest_arrivals_total",
+		Help: "Number of journey starts in open-loop mode by status (started, late, dropped).",
+	},
+	[]string{"status"},
+)
+
+var activeThreads = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "loadtest_active_threads",
+		Help: "Number of running per user, per application and per component threads.",
+	},
+	[]string{"kind"},
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		measurementDuration,
+		errorsTotal,
+		arrivalsTotal,
+		activeThreads,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Start HTTP server exposing metrics on /metrics of given address (e.g. ":9090"), empty address disables it
+func MetricsStart(address string) {
+	if address == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{Registry: metricsRegistry}))
+	metricsServer = &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		err := metricsServer.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			Logger.Error("Metrics server failed: %v", err)
+		}
+	}()
+	Logger.Info("Serving metrics on %s/metrics", address)
+}
+
+// Shut down the metrics HTTP server if it is running
+func MetricsStop() {
+	if metricsServer == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := metricsServer.Shutdown(ctx)
+	if err != nil {
+		Logger.Error("Failed to shut down metrics server: %v", err)
+	}
+	metricsServer = nil
+}
+
+// Record thread of given kind (ThreadUser, ThreadApplication or ThreadComponent) was started
+func ThreadStarted(kind string) {
+	activeThreads.WithLabelValues(kind).Inc()
+}
+
+// Record thread of given kind (ThreadUser, ThreadApplication or ThreadComponent) has finished
+func ThreadFinished(kind string) {
+	activeThreads.WithLabelValues(kind).Dec()
+}
+
+// Record journey start in open-loop mode with given status (started, late or dropped)
+func ObserveArrival(status string) {
+	arrivalsTotal.WithLabelValues(status).Inc()
+}
+
+// Update metrics with measurement comming via measurements channel
+func observeMeasurement(e *MeasurementEntry) {
+	result := "success"
+	if e.Error != nil {
+		result = "failure"
+	}
+	measurementDuration.WithLabelValues(e.Metric, fmt.Sprintf("%d", e.PerUserId), fmt.Sprintf("%d", e.PerAppId), fmt.Sprintf("%d", e.PerCompId), result).Observe(e.Duration.Seconds())
+}
+
+// Update metrics with failure comming via errors channel
+func observeError(e *ErrorEntry) {
+	errorsTotal.WithLabelValues(fmt.Sprintf("%d", e.Code)).Inc()
+}
@@ -11,6 +11,7 @@ import "os"
 import "encoding/csv"
 import "sync"
 
+import results "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/results"
 import types "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/types"
 
 var measurementsQueue chan MeasurementEntry // channel to send measurements to
@@ -21,6 +22,11 @@ var errorsOutput string       // path to CSV where to save measurements
 
 var writerWaitGroup sync.WaitGroup
 
+var resultsCollector *results.Collector // aggregates measurements for the summary
+var resultsDirectory string             // where to store the summary and SLO JUnit file
+var slos []results.SLO                  // SLOs to evaluate when measurements are stopped
+var slosPassed = true                   // verdict of SLO evaluation
+
 var batchSize int // when we accumulate this many of records, we dump them to CSV (this is to batch writest to the file, possibly make it faster)
 
 // Represents the data about measurement we want to store to CSV
@@ -54,9 +60,13 @@ func (e *ErrorEntry) GetSliceOfStrings() []string {
 }
 
 // Initialize channels and start functions that are processing records
-func MeasurementsStart(directory string) {
+// Throughput in the results summary is reported in windows of given size.
+func MeasurementsStart(directory string, window time.Duration) {
 	batchSize = 3
 
+	resultsCollector = results.NewCollector(window)
+	resultsDirectory = directory
+
 	writerWaitGroup.Add(2)
 
 	measurementsQueue = make(chan MeasurementEntry)
@@ -69,10 +79,46 @@ func MeasurementsStart(directory string) {
 }
 
 // Close channels and wait to ensure any remaining records are written to CSV
+// Then store the results summary and evaluate SLOs.
 func MeasurementsStop() {
 	close(measurementsQueue)
 	close(errorsQueue)
 	writerWaitGroup.Wait()
+	MetricsStop()
+	writeResults()
+}
+
+// Set SLOs the measurements are evaluated against when they are stopped
+func SetSLOs(s []results.SLO) {
+	slos = s
+}
+
+// Were all the SLOs met? Only meaningful after MeasurementsStop.
+func SLOsPassed() bool {
+	return slosPassed
+}
+
+// Store summary of all the measurements and SLO verdicts to JSON and JUnit files
+func writeResults() {
+	summary := resultsCollector.Summarize()
+
+	if len(slos) > 0 {
+		slosPassed = summary.Evaluate(slos)
+		for _, v := range summary.Verdicts {
+			if !v.Passed {
+				Logger.Warning("SLO not met: %s", v.String())
+			}
+		}
+		err := summary.WriteJUnit(resultsDirectory + "/load-test-slo-junit.xml")
+		if err != nil {
+			Logger.Error("Error writing SLO JUnit file: %v", err)
+		}
+	}
+
+	err := summary.Write(resultsDirectory + "/load-test-summary.json")
+	if err != nil {
+		Logger.Error("Error writing results summary: %v", err)
+	}
 }
 
 // Append slice to a CSV file
@@ -109,6 +155,8 @@ func measurementsWriter() {
 			// Handle channel closure
 			break
 		}
+		observeMeasurement(&event)
+		resultsCollector.Observe(event.Metric, event.Timestamp, event.Duration, event.Error)
 		batch = append(batch, event.GetSliceOfStrings())
 		counter++
 		if len(batch) == batchSize {
@@ -145,6 +193,7 @@ func errorsWriter() {
 			// Handle channel closure
 			break
 		}
+		observeError(&event)
 		batch = append(batch, event.GetSliceOfStrings())
 		counter++
 		if len(batch) == batchSize {
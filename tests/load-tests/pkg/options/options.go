@@ -10,7 +10,11 @@ import "sync"
 // Struct to hold command line options
 type Opts struct {
 	ApplicationsCount                int
+	ArrivalProfile                   string
 	BuildPipelineSelectorBundle      string
+	ChaosConfig                      string
+	ClusterSamplingConfig            string
+	ClusterSamplingInterval          time.Duration
 	ComponentContainerContext        string
 	ComponentContainerFile           string
 	ComponentRepoRevision            string
@@ -19,7 +23,9 @@ type Opts struct {
 	Concurrency                      int
 	FailFast                         bool
 	ForkTarget                       string
+	JourneyConfig                    string
 	JourneyDuration                  string
+	JourneyName                      string
 	JourneyRepeats                   int
 	JourneyUntil                     time.Time
 	JourneyReuseApplications         bool
@@ -27,6 +33,8 @@ type Opts struct {
 	LogDebug                         bool
 	LogInfo                          bool
 	LogTrace                         bool
+	MaxInFlight                      int
+	MetricsAddress                   string
 	OutputDir                        string
 	ReleaseOciStorage                string
 	PipelineImagePullSecrets         []string
@@ -34,6 +42,7 @@ type Opts struct {
 	PipelineRepoTemplating           bool
 	PipelineRepoTemplatingSourceDir  string
 	PipelineRepoTemplatingSource     string
+	OverwriteCheckpoint              bool
 	Purge                            bool
 	PurgeOnly                        bool
 	QuayRepo                         string
@@ -42,9 +51,14 @@ type Opts struct {
 	ReleasePipelineServiceAccount    string
 	ReleasePipelineUrl               string
 	ReleasePolicy                    string
+	ReplaySpeedup                    float64
+	ReplayTrace                      string
+	ResultsWindow                    time.Duration
+	Resume                           bool
 	RunPrefix                        string
 	SerializeComponentOnboarding     bool
 	SerializeComponentOnboardingLock sync.Mutex
+	SLOConfig                        string
 	Stage                            bool
 	StartupDelay                     time.Duration
 	StartupJitter                    time.Duration
@@ -111,6 +125,29 @@ func (o *Opts) ProcessOptions() error {
 		}
 	}
 
+	// In open-loop mode every in-flight journey needs its own user
+	if o.ArrivalProfile != "" {
+		if o.MaxInFlight <= 0 || o.MaxInFlight > o.Concurrency {
+			fmt.Print("Warning: Setting max in-flight journeys to concurrency as every journey needs its own user\n")
+			o.MaxInFlight = o.Concurrency
+		}
+	}
+
+	// Sampling cluster too often would add noticeable load on its own
+	if o.ClusterSamplingConfig != "" && o.ClusterSamplingInterval < time.Second {
+		return fmt.Errorf("cluster sampling interval has to be at least 1s, got %v", o.ClusterSamplingInterval)
+	}
+
+	// Replay mode drives journeys by itself
+	if o.ReplayTrace != "" {
+		if o.ArrivalProfile != "" {
+			return fmt.Errorf("options '--replay-trace' and '--arrival-profile' can not be used together")
+		}
+		if o.ReplaySpeedup <= 0 {
+			return fmt.Errorf("replay speedup has to be positive, got %v", o.ReplaySpeedup)
+		}
+	}
+
 	// If we are supposed to reuse components on additional journeys, we have to reuse applications
 	if o.JourneyRepeats > 1 {
 		if o.JourneyReuseComponents {
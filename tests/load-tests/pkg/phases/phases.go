@@ -0,0 +1,146 @@
+package phases
+
+import "strings"
+import "time"
+
+import pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+import corev1 "k8s.io/api/core/v1"
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// Phases of PipelineRun life we measure
+const Queued = "queued"                // PipelineRun created -> started by Tekton
+const Execution = "execution"          // PipelineRun started -> completed
+const Signing = "signing"              // PipelineRun completed -> signed by Tekton Chains
+const PodScheduling = "pod-scheduling" // TaskRun pod created -> scheduled to a node
+const ImagePull = "image-pull"         // TaskRun pod scheduled -> first of its containers started (dominated by pulling images)
+const StepExecution = "step-execution" // first step of TaskRun started -> last step finished
+
+// Represents duration of one phase of PipelineRun or of one of its TaskRuns
+type Phase struct {
+	Name            string
+	PipelineRun     string
+	PipelineRunType string // build, test, release or "" if we do not know
+	TaskRun         string // empty for phases of PipelineRun itself
+	Task            string // name of the pipeline task TaskRun is running
+	Duration        time.Duration
+}
+
+// Type of PipelineRun as set by Konflux services
+func pipelineRunType(pr *pipeline.PipelineRun) string {
+	if t, ok := pr.Labels["pipelines.appstudio.openshift.io/type"]; ok {
+		return t
+	}
+	if _, ok := pr.Labels["release.appstudio.openshift.io/name"]; ok {
+		return "release"
+	}
+	return ""
+}
+
+// Duration between two timestamps if both are known and in the right order
+func between(start, end *metav1.Time) (time.Duration, bool) {
+	if start == nil || end == nil || start.IsZero() || end.IsZero() || end.Before(start) {
+		return 0, false
+	}
+	return end.Sub(start.Time), true
+}
+
+// When did Tekton Chains last update the object, it does that when signing it
+func signedTime(obj metav1.Object) *metav1.Time {
+	var signed *metav1.Time
+	for _, mf := range obj.GetManagedFields() {
+		if !strings.Contains(mf.Manager, "chains") || mf.Time == nil {
+			continue
+		}
+		if signed == nil || signed.Before(mf.Time) {
+			signed = mf.Time
+		}
+	}
+	return signed
+}
+
+// Compute phases of PipelineRun itself from its status, phases with unknown timestamps are skipped
+func PipelineRunPhases(pr *pipeline.PipelineRun) []Phase {
+	var out []Phase
+	add := func(name string, start, end *metav1.Time) {
+		if d, ok := between(start, end); ok {
+			out = append(out, Phase{Name: name, PipelineRun: pr.Name, PipelineRunType: pipelineRunType(pr), Duration: d})
+		}
+	}
+
+	created := pr.CreationTimestamp
+	add(Queued, &created, pr.Status.StartTime)
+	add(Execution, pr.Status.StartTime, pr.Status.CompletionTime)
+	if pr.Annotations["chains.tekton.dev/signed"] == "true" {
+		add(Signing, pr.Status.CompletionTime, signedTime(pr))
+	}
+
+	return out
+}
+
+// Earliest time some of the pod containers (init containers included) started
+func firstContainerStart(pod *corev1.Pod) *metav1.Time {
+	var first *metav1.Time
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, cs := range statuses {
+		var started *metav1.Time
+		if cs.State.Running != nil {
+			started = &cs.State.Running.StartedAt
+		} else if cs.State.Terminated != nil {
+			started = &cs.State.Terminated.StartedAt
+		} else if cs.LastTerminationState.Terminated != nil {
+			started = &cs.LastTerminationState.Terminated.StartedAt
+		}
+		if started == nil || started.IsZero() {
+			continue
+		}
+		if first == nil || started.Before(first) {
+			first = started
+		}
+	}
+	return first
+}
+
+// When was the pod scheduled to a node
+func scheduledTime(pod *corev1.Pod) *metav1.Time {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodScheduled && c.Status == corev1.ConditionTrue {
+			return &c.LastTransitionTime
+		}
+	}
+	return nil
+}
+
+// Compute phases of TaskRun of given PipelineRun from TaskRun status and status of its pod
+// Pod can be nil (e.g. when it was already pruned), pod related phases are skipped then.
+func TaskRunPhases(pr *pipeline.PipelineRun, tr *pipeline.TaskRun, pod *corev1.Pod) []Phase {
+	var out []Phase
+	add := func(name string, start, end *metav1.Time) {
+		if d, ok := between(start, end); ok {
+			out = append(out, Phase{Name: name, PipelineRun: pr.Name, PipelineRunType: pipelineRunType(pr), TaskRun: tr.Name, Task: tr.Labels["tekton.dev/pipelineTask"], Duration: d})
+		}
+	}
+
+	if pod != nil {
+		created := pod.CreationTimestamp
+		scheduled := scheduledTime(pod)
+		add(PodScheduling, &created, scheduled)
+		add(ImagePull, scheduled, firstContainerStart(pod))
+	}
+
+	var firstStart, lastFinish *metav1.Time
+	for i := range tr.Status.Steps {
+		terminated := tr.Status.Steps[i].Terminated
+		if terminated == nil || terminated.StartedAt.IsZero() || terminated.FinishedAt.IsZero() {
+			continue
+		}
+		if firstStart == nil || terminated.StartedAt.Before(firstStart) {
+			firstStart = &terminated.StartedAt
+		}
+		if lastFinish == nil || lastFinish.Before(&terminated.FinishedAt) {
+			lastFinish = &terminated.FinishedAt
+		}
+	}
+	add(StepExecution, firstStart, lastFinish)
+
+	return out
+}
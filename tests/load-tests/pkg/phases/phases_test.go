@@ -0,0 +1,84 @@
+package phases
+
+import "testing"
+import "time"
+
+import pipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+import corev1 "k8s.io/api/core/v1"
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+var base = time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+
+func at(seconds int) metav1.Time {
+	return metav1.NewTime(base.Add(time.Duration(seconds) * time.Second))
+}
+
+func ptr(t metav1.Time) *metav1.Time {
+	return &t
+}
+
+func durations(phases []Phase) map[string]time.Duration {
+	out := map[string]time.Duration{}
+	for _, p := range phases {
+		out[p.Name] = p.Duration
+	}
+	return out
+}
+
+// Test phases are computed from PipelineRun, TaskRun and Pod timestamps and unknown ones are skipped.
+func Test_Phases(t *testing.T) {
+	pr := &pipeline.PipelineRun{}
+	pr.Name = "comp-on-push-abc"
+	pr.CreationTimestamp = at(0)
+	pr.Labels = map[string]string{"pipelines.appstudio.openshift.io/type": "build"}
+	pr.Annotations = map[string]string{"chains.tekton.dev/signed": "true"}
+	pr.ManagedFields = []metav1.ManagedFieldsEntry{
+		{Manager: "pipelines-as-code-controller", Time: ptr(at(1))},
+		{Manager: "tekton-chains-controller", Time: ptr(at(130))},
+	}
+	pr.Status.StartTime = ptr(at(5))
+	pr.Status.CompletionTime = ptr(at(120))
+
+	got := durations(PipelineRunPhases(pr))
+	expected := map[string]time.Duration{Queued: 5 * time.Second, Execution: 115 * time.Second, Signing: 10 * time.Second}
+	for name, d := range expected {
+		if got[name] != d {
+			t.Errorf("PipelineRun phase %s: expected %v, got %v", name, d, got[name])
+		}
+	}
+
+	tr := &pipeline.TaskRun{}
+	tr.Name = "comp-on-push-abc-build"
+	tr.Labels = map[string]string{"tekton.dev/pipelineTask": "build"}
+	tr.Status.Steps = []pipeline.StepState{
+		{Name: "prepare", ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: at(20), FinishedAt: at(25)}}},
+		{Name: "build", ContainerState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: at(25), FinishedAt: at(80)}}},
+		{Name: "skipped"},
+	}
+	pod := &corev1.Pod{}
+	pod.CreationTimestamp = at(10)
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: at(12)}}
+	pod.Status.InitContainerStatuses = []corev1.ContainerStatus{{State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{StartedAt: at(18), FinishedAt: at(19)}}}}
+
+	phases := TaskRunPhases(pr, tr, pod)
+	got = durations(phases)
+	expected = map[string]time.Duration{PodScheduling: 2 * time.Second, ImagePull: 6 * time.Second, StepExecution: 60 * time.Second}
+	for name, d := range expected {
+		if got[name] != d {
+			t.Errorf("TaskRun phase %s: expected %v, got %v", name, d, got[name])
+		}
+	}
+	if phases[0].Task != "build" || phases[0].PipelineRunType != "build" {
+		t.Errorf("Unexpected phase metadata: %+v", phases[0])
+	}
+
+	if len(TaskRunPhases(pr, tr, nil)) != 1 {
+		t.Errorf("Expected only step execution phase without pod")
+	}
+
+	pr.Annotations = nil
+	pr.Status.CompletionTime = nil
+	if got := durations(PipelineRunPhases(pr)); len(got) != 1 {
+		t.Errorf("Expected only queued phase for running unsigned PipelineRun, got %v", got)
+	}
+}
@@ -0,0 +1,130 @@
+package replay
+
+import "bufio"
+import "encoding/json"
+import "fmt"
+import "os"
+import "path/filepath"
+import "sort"
+import "strings"
+import "time"
+
+// Actions recorded in the trace
+const CreateApplication = "create-application" // application (with its test scenario and release setup) created
+const CreateComponent = "create-component"     // component onboarded, which triggers its first build
+const Push = "push"                            // commit pushed to component repository, which triggers a build
+const Release = "release"                      // release of the latest component build requested
+
+// Represents one recorded tenant activity
+// Tenant, application and component are only identifiers used to group
+// events in the trace, they are not used as names of created resources.
+type Event struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Action      string    `json:"action"`
+	Tenant      string    `json:"tenant"`
+	Application string    `json:"application"`
+	Component   string    `json:"component,omitempty"`
+
+	offset time.Duration
+}
+
+// Represents the recorded trace with events ordered by timestamp
+type Trace struct {
+	Events []Event
+}
+
+// Load trace from file with one JSON event per line (empty lines and lines starting with '#' are ignored)
+func Load(filePath string) (*Trace, error) {
+	filePath = filepath.Clean(filePath)
+	fd, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	var trace Trace
+	scanner := bufio.NewScanner(fd)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var event Event
+		err = json.Unmarshal([]byte(line), &event)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing trace %s line %d: %v", filePath, lineNumber, err)
+		}
+		trace.Events = append(trace.Events, event)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading trace %s: %v", filePath, err)
+	}
+
+	err = trace.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid trace %s: %v", filePath, err)
+	}
+
+	return &trace, nil
+}
+
+// Check all the events are sane, order them by timestamp and compute their offsets from the first one
+func (t *Trace) Validate() error {
+	if len(t.Events) == 0 {
+		return fmt.Errorf("trace has no events")
+	}
+
+	for i, e := range t.Events {
+		if e.Timestamp.IsZero() {
+			return fmt.Errorf("event %d has no timestamp", i)
+		}
+		if e.Tenant == "" || e.Application == "" {
+			return fmt.Errorf("event %d needs tenant and application", i)
+		}
+		switch e.Action {
+		case CreateApplication:
+		case CreateComponent, Push, Release:
+			if e.Component == "" {
+				return fmt.Errorf("event %d (%s) needs component", i, e.Action)
+			}
+		default:
+			return fmt.Errorf("event %d has unknown action %q", i, e.Action)
+		}
+	}
+
+	sort.SliceStable(t.Events, func(i, j int) bool {
+		return t.Events[i].Timestamp.Before(t.Events[j].Timestamp)
+	})
+	first := t.Events[0].Timestamp
+	for i := range t.Events {
+		t.Events[i].offset = t.Events[i].Timestamp.Sub(first)
+	}
+
+	return nil
+}
+
+// When should the event be replayed relative to replay start when trace is compressed by speedup (2 = twice as fast)
+func (e *Event) Offset(speedup float64) time.Duration {
+	return time.Duration(float64(e.offset) / speedup)
+}
+
+// How long it takes to replay the whole trace compressed by speedup
+func (t *Trace) Duration(speedup float64) time.Duration {
+	return t.Events[len(t.Events)-1].Offset(speedup)
+}
+
+// Tenants in the order they appear in the trace
+func (t *Trace) Tenants() []string {
+	var tenants []string
+	seen := map[string]bool{}
+	for _, e := range t.Events {
+		if !seen[e.Tenant] {
+			seen[e.Tenant] = true
+			tenants = append(tenants, e.Tenant)
+		}
+	}
+	return tenants
+}
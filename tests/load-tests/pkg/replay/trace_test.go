@@ -0,0 +1,61 @@
+package replay
+
+import "os"
+import "path/filepath"
+import "testing"
+import "time"
+
+// Test trace is loaded, ordered by timestamp and compressed.
+func Test_Load(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	content := `# exported from audit log
+{"timestamp": "2026-03-01T10:10:00Z", "action": "push", "tenant": "team-b", "application": "app", "component": "api"}
+{"timestamp": "2026-03-01T10:00:00Z", "action": "create-component", "tenant": "team-b", "application": "app", "component": "api"}
+
+{"timestamp": "2026-03-01T10:00:00Z", "action": "create-application", "tenant": "team-a", "application": "app"}
+{"timestamp": "2026-03-01T11:00:00Z", "action": "release", "tenant": "team-a", "application": "app", "component": "ui"}
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("Failed to write trace: %v", err)
+	}
+
+	trace, err := Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load trace: %v", err)
+	}
+	if len(trace.Events) != 4 || trace.Events[0].Action != CreateComponent || trace.Events[3].Action != Release {
+		t.Errorf("Unexpected events order: %+v", trace.Events)
+	}
+	if got := trace.Events[2].Offset(10); got != time.Minute {
+		t.Errorf("Expected push replayed after 1m with speedup 10, got %v", got)
+	}
+	if got := trace.Duration(60); got != time.Minute {
+		t.Errorf("Expected trace replayed in 1m with speedup 60, got %v", got)
+	}
+	if tenants := trace.Tenants(); len(tenants) != 2 || tenants[0] != "team-b" {
+		t.Errorf("Unexpected tenants: %v", tenants)
+	}
+}
+
+// Test invalid events are rejected.
+func Test_Validate(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		event Event
+	}{
+		{"no timestamp", Event{Action: Push, Tenant: "t", Application: "a", Component: "c"}},
+		{"no tenant", Event{Timestamp: ts, Action: Push, Application: "a", Component: "c"}},
+		{"push without component", Event{Timestamp: ts, Action: Push, Tenant: "t", Application: "a"}},
+		{"unknown action", Event{Timestamp: ts, Action: "delete", Tenant: "t", Application: "a"}},
+	}
+	for _, tt := range tests {
+		trace := Trace{Events: []Event{tt.event}}
+		if err := trace.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", tt.name)
+		}
+	}
+	if err := (&Trace{}).Validate(); err == nil {
+		t.Errorf("Expected error for empty trace")
+	}
+}
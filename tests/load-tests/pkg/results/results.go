@@ -0,0 +1,178 @@
+package results
+
+import "encoding/json"
+import "math"
+import "os"
+import "path/filepath"
+import "sort"
+import "sync"
+import "time"
+
+// Default size of time windows throughput is reported for
+const DefaultWindow = 5 * time.Minute
+
+// Summary of all measurements of one metric, durations are in seconds
+// Duration statistics are computed from passed measurements only as failed
+// ones are often immediate and would skew them.
+type MetricSummary struct {
+	Metric     string   `json:"metric"`
+	Count      int      `json:"count"`
+	Pass       int      `json:"pass"`
+	Fail       int      `json:"fail"`
+	Min        float64  `json:"min"`
+	Max        float64  `json:"max"`
+	Mean       float64  `json:"mean"`
+	P50        float64  `json:"p50"`
+	P90        float64  `json:"p90"`
+	P95        float64  `json:"p95"`
+	P99        float64  `json:"p99"`
+	Throughput []Window `json:"throughput"`
+}
+
+// Number of measurements finished in a time window
+type Window struct {
+	Start     time.Time `json:"start"`
+	Count     int       `json:"count"`
+	PerMinute float64   `json:"perMinute"`
+}
+
+// Represents the summary we store to JSON file at the end of the test
+type Summary struct {
+	Start    time.Time       `json:"start"`
+	End      time.Time       `json:"end"`
+	Window   string          `json:"window"`
+	Metrics  []MetricSummary `json:"metrics"`
+	Verdicts []Verdict       `json:"slos,omitempty"`
+}
+
+type metricData struct {
+	durations []float64 // durations of passed measurements
+	finished  []time.Time
+	fail      int
+}
+
+// Collects measurements so they can be summarized at the end of the test
+type Collector struct {
+	mu      sync.Mutex
+	start   time.Time
+	window  time.Duration
+	metrics map[string]*metricData
+}
+
+// Create collector reporting throughput in windows of given size starting now
+func NewCollector(window time.Duration) *Collector {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Collector{
+		start:   time.Now(),
+		window:  window,
+		metrics: map[string]*metricData{},
+	}
+}
+
+// Record one measurement of a metric finished at given time
+func (c *Collector) Observe(metric string, finished time.Time, duration time.Duration, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, ok := c.metrics[metric]
+	if !ok {
+		data = &metricData{}
+		c.metrics[metric] = data
+	}
+	data.finished = append(data.finished, finished)
+	if err != nil {
+		data.fail++
+	} else {
+		data.durations = append(data.durations, duration.Seconds())
+	}
+}
+
+// Compute summary of all the metrics collected so far, metrics are sorted by name
+func (c *Collector) Summarize() *Summary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	summary := &Summary{
+		Start:   c.start,
+		End:     time.Now(),
+		Window:  c.window.String(),
+		Metrics: []MetricSummary{},
+	}
+	for metric, data := range c.metrics {
+		summary.Metrics = append(summary.Metrics, c.summarizeMetric(metric, data, summary.End))
+	}
+	sort.Slice(summary.Metrics, func(i, j int) bool {
+		return summary.Metrics[i].Metric < summary.Metrics[j].Metric
+	})
+
+	return summary
+}
+
+func (c *Collector) summarizeMetric(metric string, data *metricData, end time.Time) MetricSummary {
+	s := MetricSummary{
+		Metric: metric,
+		Count:  len(data.finished),
+		Pass:   len(data.durations),
+		Fail:   data.fail,
+	}
+
+	if len(data.durations) > 0 {
+		sorted := append([]float64{}, data.durations...)
+		sort.Float64s(sorted)
+		var sum float64
+		for _, d := range sorted {
+			sum += d
+		}
+		s.Min = sorted[0]
+		s.Max = sorted[len(sorted)-1]
+		s.Mean = sum / float64(len(sorted))
+		s.P50 = percentile(sorted, 50)
+		s.P90 = percentile(sorted, 90)
+		s.P95 = percentile(sorted, 95)
+		s.P99 = percentile(sorted, 99)
+	}
+
+	// Cover whole test and any measurement recorded with a later timestamp
+	for _, f := range data.finished {
+		if f.After(end) {
+			end = f
+		}
+	}
+	windows := int(end.Sub(c.start)/c.window) + 1
+	s.Throughput = make([]Window, windows)
+	for i := range s.Throughput {
+		s.Throughput[i].Start = c.start.Add(time.Duration(i) * c.window)
+	}
+	for _, f := range data.finished {
+		i := int(f.Sub(c.start) / c.window)
+		if i < 0 {
+			i = 0
+		}
+		s.Throughput[i].Count++
+	}
+	for i := range s.Throughput {
+		s.Throughput[i].PerMinute = float64(s.Throughput[i].Count) / c.window.Minutes()
+	}
+
+	return s
+}
+
+// Nearest-rank percentile of sorted values
+func percentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// Store summary as pretty JSON
+func (s *Summary) Write(outfile string) error {
+	jsonSummary, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(outfile), jsonSummary, 0600)
+}
@@ -0,0 +1,75 @@
+package results
+
+import "encoding/json"
+import "errors"
+import "os"
+import "path/filepath"
+import "strings"
+import "testing"
+import "time"
+
+func collect(durations ...int) *Collector {
+	c := NewCollector(time.Minute)
+	for _, d := range durations {
+		c.Observe("github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/journey.HandleComponent", c.start.Add(time.Duration(d)*time.Second), time.Duration(d)*time.Second, nil)
+	}
+	return c
+}
+
+// Test statistics and throughput windows computed for a metric.
+func Test_Summarize(t *testing.T) {
+	c := collect(1, 2, 3, 4, 5, 6, 7, 8, 9, 100)
+	c.Observe("github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/journey.HandleComponent", c.start.Add(time.Second), time.Second, errors.New("failed"))
+
+	summary := c.Summarize()
+	if len(summary.Metrics) != 1 {
+		t.Fatalf("Expected one metric, got %+v", summary.Metrics)
+	}
+	m := summary.Metrics[0]
+	if m.Count != 11 || m.Pass != 10 || m.Fail != 1 {
+		t.Errorf("Unexpected counts: %+v", m)
+	}
+	if m.Min != 1 || m.Max != 100 || m.Mean != 14.5 || m.P50 != 5 || m.P90 != 9 || m.P99 != 100 {
+		t.Errorf("Unexpected statistics: %+v", m)
+	}
+	if len(m.Throughput) < 2 || m.Throughput[0].Count != 10 || m.Throughput[1].Count != 1 {
+		t.Errorf("Unexpected throughput: %+v", m.Throughput)
+	}
+}
+
+// Test SLO thresholds turn into verdicts and JUnit test cases.
+func Test_Evaluate(t *testing.T) {
+	var slos []SLO
+	err := json.Unmarshal([]byte(`[
+		{"metric": "journey.HandleComponent", "p50": "10s", "max": "1m", "minPassRate": 0.9},
+		{"metric": "journey.HandleReleaseRun", "p95": "10m"}
+	]`), &slos)
+	if err != nil {
+		t.Fatalf("Failed to parse SLOs: %v", err)
+	}
+
+	summary := collect(1, 2, 3, 100).Summarize()
+	if summary.Evaluate(slos) {
+		t.Errorf("Expected SLOs to fail")
+	}
+	passed := map[string]bool{}
+	for _, v := range summary.Verdicts {
+		passed[v.Check] = v.Passed
+	}
+	if !passed["p50"] || passed["max"] || !passed["pass rate"] || passed["measured"] {
+		t.Errorf("Unexpected verdicts: %+v", summary.Verdicts)
+	}
+
+	junit := filepath.Join(t.TempDir(), "junit.xml")
+	if err = summary.WriteJUnit(junit); err != nil {
+		t.Fatalf("Failed to write JUnit: %v", err)
+	}
+	data, _ := os.ReadFile(junit)
+	if !strings.Contains(string(data), `tests="4" disabled="0" errors="0" failures="2"`) {
+		t.Errorf("Unexpected JUnit: %s", data)
+	}
+
+	if !collect(1, 2).Summarize().Evaluate(slos[:1]) {
+		t.Errorf("Expected SLOs to pass")
+	}
+}
@@ -0,0 +1,207 @@
+package results
+
+import "encoding/json"
+import "encoding/xml"
+import "fmt"
+import "os"
+import "path/filepath"
+import "strings"
+import "time"
+
+import reporters "github.com/onsi/ginkgo/v2/reporters"
+
+// Duration which is stored in JSON as a string like "5m30s"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return err
+	}
+	d.Duration, err = time.ParseDuration(s)
+	return err
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Thresholds one metric has to meet, unset thresholds are not checked
+// Metric matches either full metric name or its part after last "/", so
+// e.g. "journey.HandleComponent" can be used.
+type SLO struct {
+	Metric      string    `json:"metric"`
+	P50         *Duration `json:"p50,omitempty"`
+	P90         *Duration `json:"p90,omitempty"`
+	P95         *Duration `json:"p95,omitempty"`
+	P99         *Duration `json:"p99,omitempty"`
+	Max         *Duration `json:"max,omitempty"`
+	Mean        *Duration `json:"mean,omitempty"`
+	MinPassRate *float64  `json:"minPassRate,omitempty"`
+	MinCount    *int      `json:"minCount,omitempty"`
+}
+
+// Result of checking one threshold of an SLO
+type Verdict struct {
+	Metric    string `json:"metric"`
+	Check     string `json:"check"`
+	Threshold string `json:"threshold"`
+	Actual    string `json:"actual"`
+	Passed    bool   `json:"passed"`
+}
+
+func (v *Verdict) String() string {
+	return fmt.Sprintf("%s %s: %s (threshold %s)", v.Metric, v.Check, v.Actual, v.Threshold)
+}
+
+// Load JSON file with a list of SLOs
+func LoadSLOs(filePath string) ([]SLO, error) {
+	filePath = filepath.Clean(filePath)
+	jsonData, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var slos []SLO
+	err = json.Unmarshal(jsonData, &slos)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing SLOs %s: %v", filePath, err)
+	}
+	for i, slo := range slos {
+		if slo.Metric == "" {
+			return nil, fmt.Errorf("SLO %d in %s has no metric", i, filePath)
+		}
+	}
+
+	return slos, nil
+}
+
+func (slo *SLO) matches(metric string) bool {
+	return metric == slo.Metric || metric[strings.LastIndex(metric, "/")+1:] == slo.Metric
+}
+
+// Check SLOs against the summary and store verdicts in it, returns true when all the SLOs are met
+// SLO which does not match any measured metric fails as we can not prove it was met.
+func (s *Summary) Evaluate(slos []SLO) bool {
+	passed := true
+	s.Verdicts = nil
+
+	for i := range slos {
+		slo := &slos[i]
+		matched := false
+		for j := range s.Metrics {
+			if slo.matches(s.Metrics[j].Metric) {
+				matched = true
+				s.Verdicts = append(s.Verdicts, slo.evaluate(&s.Metrics[j])...)
+			}
+		}
+		if !matched {
+			s.Verdicts = append(s.Verdicts, Verdict{Metric: slo.Metric, Check: "measured", Threshold: "at least one measurement", Actual: "no measurements", Passed: false})
+		}
+	}
+
+	for _, v := range s.Verdicts {
+		passed = passed && v.Passed
+	}
+	return passed
+}
+
+func (slo *SLO) evaluate(m *MetricSummary) []Verdict {
+	var verdicts []Verdict
+
+	durations := []struct {
+		check     string
+		threshold *Duration
+		actual    float64
+	}{
+		{"p50", slo.P50, m.P50},
+		{"p90", slo.P90, m.P90},
+		{"p95", slo.P95, m.P95},
+		{"p99", slo.P99, m.P99},
+		{"max", slo.Max, m.Max},
+		{"mean", slo.Mean, m.Mean},
+	}
+	for _, d := range durations {
+		if d.threshold == nil {
+			continue
+		}
+		actual := time.Duration(d.actual * float64(time.Second))
+		verdicts = append(verdicts, Verdict{
+			Metric:    m.Metric,
+			Check:     d.check,
+			Threshold: d.threshold.String(),
+			Actual:    actual.String(),
+			Passed:    m.Pass > 0 && actual <= d.threshold.Duration,
+		})
+	}
+
+	if slo.MinPassRate != nil {
+		rate := 0.0
+		if m.Count > 0 {
+			rate = float64(m.Pass) / float64(m.Count)
+		}
+		verdicts = append(verdicts, Verdict{
+			Metric:    m.Metric,
+			Check:     "pass rate",
+			Threshold: fmt.Sprintf("%.3f", *slo.MinPassRate),
+			Actual:    fmt.Sprintf("%.3f", rate),
+			Passed:    rate >= *slo.MinPassRate,
+		})
+	}
+
+	if slo.MinCount != nil {
+		verdicts = append(verdicts, Verdict{
+			Metric:    m.Metric,
+			Check:     "count",
+			Threshold: fmt.Sprintf("%d", *slo.MinCount),
+			Actual:    fmt.Sprintf("%d", m.Count),
+			Passed:    m.Count >= *slo.MinCount,
+		})
+	}
+
+	return verdicts
+}
+
+// Store SLO verdicts as JUnit file, one test case per checked threshold
+func (s *Summary) WriteJUnit(outfile string) error {
+	suite := reporters.JUnitTestSuite{
+		Name:      "load-test-slo",
+		Package:   "load-test",
+		Time:      s.End.Sub(s.Start).Seconds(),
+		Timestamp: s.Start.Format("2006-01-02T15:04:05"),
+	}
+	for i := range s.Verdicts {
+		v := &s.Verdicts[i]
+		testCase := reporters.JUnitTestCase{
+			Name:      fmt.Sprintf("%s %s", v.Metric, v.Check),
+			Classname: suite.Name,
+			Status:    "passed",
+		}
+		if !v.Passed {
+			testCase.Status = "failed"
+			testCase.Failure = &reporters.JUnitFailure{
+				Message:     v.String(),
+				Type:        "failed",
+				Description: v.String(),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+	}
+
+	suites := reporters.JUnitTestSuites{
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		Time:       suite.Time,
+		TestSuites: []reporters.JUnitTestSuite{suite},
+	}
+	xmlData, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Clean(outfile), append([]byte(xml.Header), xmlData...), 0600)
+}
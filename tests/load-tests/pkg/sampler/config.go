@@ -0,0 +1,95 @@
+package sampler
+
+import "encoding/json"
+import "fmt"
+import "os"
+import "path/filepath"
+
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+
+// Controller pods to sample CPU and memory usage of (from metrics API)
+type PodTarget struct {
+	Namespace     string `json:"namespace"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// Resource to count objects of per namespace
+type ObjectTarget struct {
+	Group    string `json:"group"`
+	Version  string `json:"version"`
+	Resource string `json:"resource"`
+}
+
+// Controller metrics endpoint (reached via API server service proxy) to read workqueue depths from
+type WorkqueueTarget struct {
+	Namespace string `json:"namespace"`
+	Service   string `json:"service"`
+	Port      string `json:"port"`             // port name or number
+	Scheme    string `json:"scheme,omitempty"` // defaults to "http"
+	Path      string `json:"path,omitempty"`   // defaults to "/metrics"
+	Metric    string `json:"metric,omitempty"` // defaults to "workqueue_depth", queues are told apart by its "name" label
+}
+
+// Represents the cluster sampling configuration file
+type Config struct {
+	Pods            []PodTarget       `json:"pods"`
+	Objects         []ObjectTarget    `json:"objects"`
+	NamespacePrefix string            `json:"namespacePrefix,omitempty"` // only count objects in namespaces with this prefix
+	Workqueues      []WorkqueueTarget `json:"workqueues"`
+}
+
+// Load cluster sampling configuration from JSON file and check it is sane
+func Load(filePath string) (*Config, error) {
+	filePath = filepath.Clean(filePath)
+	jsonData, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	err = json.Unmarshal(jsonData, &config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cluster sampling config %s: %v", filePath, err)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster sampling config %s: %v", filePath, err)
+	}
+
+	return &config, nil
+}
+
+// Check all the targets are sane and fill in defaults
+func (c *Config) Validate() error {
+	for i, p := range c.Pods {
+		if p.Namespace == "" {
+			return fmt.Errorf("pods target %d needs namespace", i)
+		}
+	}
+	for i, o := range c.Objects {
+		if o.Version == "" || o.Resource == "" {
+			return fmt.Errorf("objects target %d needs version and resource", i)
+		}
+	}
+	for i := range c.Workqueues {
+		w := &c.Workqueues[i]
+		if w.Namespace == "" || w.Service == "" || w.Port == "" {
+			return fmt.Errorf("workqueues target %d needs namespace, service and port", i)
+		}
+		if w.Scheme == "" {
+			w.Scheme = "http"
+		}
+		if w.Path == "" {
+			w.Path = "/metrics"
+		}
+		if w.Metric == "" {
+			w.Metric = "workqueue_depth"
+		}
+	}
+	return nil
+}
+
+func (o *ObjectTarget) gvr() schema.GroupVersionResource {
+	return schema.GroupVersionResource{Group: o.Group, Version: o.Version, Resource: o.Resource}
+}
@@ -0,0 +1,304 @@
+package sampler
+
+import "bytes"
+import "context"
+import "encoding/csv"
+import "fmt"
+import "os"
+import "path/filepath"
+import "sort"
+import "strconv"
+import "strings"
+import "sync"
+import "time"
+
+import expfmt "github.com/prometheus/common/expfmt"
+import model "github.com/prometheus/common/model"
+import resource "k8s.io/apimachinery/pkg/api/resource"
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+import dynamic "k8s.io/client-go/dynamic"
+import labels "k8s.io/apimachinery/pkg/labels"
+import kubernetes "k8s.io/client-go/kubernetes"
+import metadata "k8s.io/client-go/metadata"
+import metadatainformer "k8s.io/client-go/metadata/metadatainformer"
+import cache "k8s.io/client-go/tools/cache"
+
+// Kinds of samples
+const PodCPU = "pod-cpu"                 // CPU usage of the pod in cores
+const PodMemory = "pod-memory"           // memory usage (working set) of the pod in bytes
+const Objects = "objects"                // number of objects of the resource in the namespace
+const WorkqueueDepth = "workqueue-depth" // depth of the controller workqueue
+
+// How long to wait for objects to be listed before they are counted for the first time
+const objectsSyncTimeout = 5 * time.Minute
+
+var podMetrics = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+// Represents one sampled value
+type Sample struct {
+	Timestamp time.Time
+	Kind      string
+	Namespace string
+	Name      string // pod name, resource name or workqueue name
+	Value     float64
+}
+
+// Helper function to convert struct to slice of string which is needed when converting to CSV
+func (s *Sample) GetSliceOfStrings() []string {
+	return []string{s.Timestamp.Format(time.RFC3339Nano), s.Kind, s.Namespace, s.Name, strconv.FormatFloat(s.Value, 'f', -1, 64)}
+}
+
+// Periodically samples cluster resources and appends them to CSV file
+type Sampler struct {
+	client   kubernetes.Interface
+	dynamic  dynamic.Interface
+	objects  metadatainformer.SharedInformerFactory
+	config   *Config
+	interval time.Duration
+	output   string
+	report   func(error)
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// Create sampler writing samples to output CSV file every interval, sampling errors are passed to report
+// Objects are counted from metadata informers, so they are listed once and then
+// only watched instead of being listed cluster-wide on every sample.
+func NewSampler(client kubernetes.Interface, dynamicClient dynamic.Interface, metadataClient metadata.Interface, config *Config, interval time.Duration, output string, report func(error)) *Sampler {
+	objects := metadatainformer.NewSharedInformerFactory(metadataClient, 0)
+	for _, target := range config.Objects {
+		objects.ForResource(target.gvr())
+	}
+	return &Sampler{
+		client:   client,
+		dynamic:  dynamicClient,
+		objects:  objects,
+		config:   config,
+		interval: interval,
+		output:   output,
+		report:   report,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start sampling in background
+func (s *Sampler) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			s.sampleAndWrite()
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop sampling, one last sample is taken so the end of the run is covered
+func (s *Sampler) Stop() {
+	close(s.stop)
+	s.wg.Wait()
+	s.sampleAndWrite()
+	s.objects.Shutdown()
+}
+
+func (s *Sampler) sampleAndWrite() {
+	samples, errs := s.Sample(context.Background())
+	for _, err := range errs {
+		s.report(err)
+	}
+	err := s.write(samples)
+	if err != nil {
+		s.report(fmt.Errorf("failed to write cluster samples: %v", err))
+	}
+}
+
+// Take one sample of all the configured targets
+func (s *Sampler) Sample(ctx context.Context) ([]Sample, []error) {
+	var samples []Sample
+	var errs []error
+	now := time.Now()
+
+	for _, target := range s.config.Pods {
+		found, err := s.samplePods(ctx, now, target)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		samples = append(samples, found...)
+	}
+	for _, target := range s.config.Objects {
+		found, err := s.sampleObjects(ctx, now, target)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		samples = append(samples, found...)
+	}
+	for _, target := range s.config.Workqueues {
+		found, err := s.sampleWorkqueues(ctx, now, target)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		samples = append(samples, found...)
+	}
+
+	return samples, errs
+}
+
+// CPU and memory usage of pods from metrics API, summed over pod containers
+func (s *Sampler) samplePods(ctx context.Context, now time.Time, target PodTarget) ([]Sample, error) {
+	list, err := s.dynamic.Resource(podMetrics).Namespace(target.Namespace).List(ctx, metav1.ListOptions{LabelSelector: target.LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics of pods %s in namespace %s: %v", target.LabelSelector, target.Namespace, err)
+	}
+
+	var samples []Sample
+	for _, item := range list.Items {
+		var cpu, memory float64
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, c := range containers {
+			usage, ok := c.(map[string]interface{})["usage"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if q, err := resource.ParseQuantity(fmt.Sprint(usage["cpu"])); err == nil {
+				cpu += float64(q.MilliValue()) / 1000
+			}
+			if q, err := resource.ParseQuantity(fmt.Sprint(usage["memory"])); err == nil {
+				memory += float64(q.Value())
+			}
+		}
+		samples = append(samples,
+			Sample{Timestamp: now, Kind: PodCPU, Namespace: item.GetNamespace(), Name: item.GetName(), Value: cpu},
+			Sample{Timestamp: now, Kind: PodMemory, Namespace: item.GetNamespace(), Name: item.GetName(), Value: memory},
+		)
+	}
+	return samples, nil
+}
+
+// Count objects of resource per namespace from informer cache, only metadata is watched to keep it cheap
+func (s *Sampler) sampleObjects(ctx context.Context, now time.Time, target ObjectTarget) ([]Sample, error) {
+	informer := s.objects.ForResource(target.gvr())
+	s.objects.Start(s.stop)
+	if !informer.Informer().HasSynced() {
+		syncCtx, cancel := context.WithTimeout(ctx, objectsSyncTimeout)
+		defer cancel()
+		go func() {
+			select {
+			case <-s.stop:
+				cancel()
+			case <-syncCtx.Done():
+			}
+		}()
+		if !cache.WaitForCacheSync(syncCtx.Done(), informer.Informer().HasSynced) {
+			return nil, fmt.Errorf("failed to list %s: informer did not sync", target.Resource)
+		}
+	}
+
+	items, err := informer.Lister().List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %v", target.Resource, err)
+	}
+	counts := map[string]int{}
+	for _, item := range items {
+		object, ok := item.(metav1.Object)
+		if ok && strings.HasPrefix(object.GetNamespace(), s.config.NamespacePrefix) {
+			counts[object.GetNamespace()]++
+		}
+	}
+
+	namespaces := make([]string, 0, len(counts))
+	for namespace := range counts {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	samples := make([]Sample, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		samples = append(samples, Sample{Timestamp: now, Kind: Objects, Namespace: namespace, Name: target.Resource, Value: float64(counts[namespace])})
+	}
+	return samples, nil
+}
+
+// Read workqueue depths from controller metrics endpoint
+func (s *Sampler) sampleWorkqueues(ctx context.Context, now time.Time, target WorkqueueTarget) ([]Sample, error) {
+	data, err := s.client.CoreV1().Services(target.Namespace).ProxyGet(target.Scheme, target.Service, target.Port, target.Path, nil).DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics of service %s/%s: %v", target.Namespace, target.Service, err)
+	}
+
+	depths, err := parseWorkqueueDepths(data, target.Metric)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics of service %s/%s: %v", target.Namespace, target.Service, err)
+	}
+
+	samples := make([]Sample, 0, len(depths))
+	for name, depth := range depths {
+		samples = append(samples, Sample{Timestamp: now, Kind: WorkqueueDepth, Namespace: target.Namespace, Name: name, Value: depth})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].Name < samples[j].Name })
+	return samples, nil
+}
+
+// Parse metrics in Prometheus text format and return values of given metric by its "name" label
+// Metric without "name" label is reported under the metric name.
+func parseWorkqueueDepths(data []byte, metric string) (map[string]float64, error) {
+	parser := expfmt.NewTextParser(model.UTF8Validation)
+	families, err := parser.TextToMetricFamilies(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	depths := map[string]float64{}
+	family, ok := families[metric]
+	if !ok {
+		return depths, nil
+	}
+	for _, m := range family.GetMetric() {
+		name := metric
+		for _, label := range m.GetLabel() {
+			if label.GetName() == "name" {
+				name = label.GetValue()
+			}
+		}
+		switch {
+		case m.Gauge != nil:
+			depths[name] += m.GetGauge().GetValue()
+		case m.Untyped != nil:
+			depths[name] += m.GetUntyped().GetValue()
+		case m.Counter != nil:
+			depths[name] += m.GetCounter().GetValue()
+		}
+	}
+	return depths, nil
+}
+
+// Append samples to output CSV file
+func (s *Sampler) write(samples []Sample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	outfile := filepath.Clean(s.output)
+	file, err := os.OpenFile(outfile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	for i := range samples {
+		if err := writer.Write(samples[i].GetSliceOfStrings()); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
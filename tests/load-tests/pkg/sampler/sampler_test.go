@@ -0,0 +1,134 @@
+package sampler
+
+import "context"
+import "os"
+import "path/filepath"
+import "strings"
+import "testing"
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+import unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+import runtime "k8s.io/apimachinery/pkg/runtime"
+import schema "k8s.io/apimachinery/pkg/runtime/schema"
+import dynamicfake "k8s.io/client-go/dynamic/fake"
+import fake "k8s.io/client-go/kubernetes/fake"
+import metadatafake "k8s.io/client-go/metadata/fake"
+
+func podMetric(name string, containers ...map[string]interface{}) *unstructured.Unstructured {
+	list := []interface{}{}
+	for _, c := range containers {
+		list = append(list, c)
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"containers": list}}
+	u.SetAPIVersion("metrics.k8s.io/v1beta1")
+	u.SetKind("PodMetrics")
+	u.SetNamespace("build-service")
+	u.SetName(name)
+	u.SetLabels(map[string]string{"control-plane": "controller-manager"})
+	return u
+}
+
+func pipelineRun(namespace, name string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "tekton.dev/v1", Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+// Test pod usage and object counts are sampled and written to CSV.
+func Test_Sample(t *testing.T) {
+	// Metrics API resource name can not be guessed from kind, so create pod metrics explicitly
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{podMetrics: "PodMetricsList"})
+	_, err := dynamicClient.Resource(podMetrics).Namespace("build-service").Create(context.Background(), podMetric("controller-1",
+		map[string]interface{}{"name": "manager", "usage": map[string]interface{}{"cpu": "250m", "memory": "100Mi"}},
+		map[string]interface{}{"name": "proxy", "usage": map[string]interface{}{"cpu": "50m", "memory": "28Mi"}},
+	), metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("Failed to create pod metrics: %v", err)
+	}
+
+	scheme := runtime.NewScheme()
+	_ = metav1.AddMetaToScheme(scheme)
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme,
+		pipelineRun("testuser-0001-tenant", "build-1"),
+		pipelineRun("testuser-0001-tenant", "build-2"),
+		pipelineRun("testuser-0002-tenant", "build-1"),
+		pipelineRun("other-tenant", "build-1"),
+	)
+
+	config := &Config{
+		Pods:            []PodTarget{{Namespace: "build-service", LabelSelector: "control-plane=controller-manager"}},
+		Objects:         []ObjectTarget{{Group: "tekton.dev", Version: "v1", Resource: "pipelineruns"}},
+		NamespacePrefix: "testuser-",
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Invalid config: %v", err)
+	}
+	output := filepath.Join(t.TempDir(), "samples.csv")
+	s := NewSampler(fake.NewSimpleClientset(), dynamicClient, metadataClient, config, 0, output, func(err error) { t.Errorf("Unexpected error: %v", err) })
+
+	samples, errs := s.Sample(context.Background())
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	expected := map[string]float64{
+		"pod-cpu/build-service/controller-1":        0.3,
+		"pod-memory/build-service/controller-1":     128 * 1024 * 1024,
+		"objects/testuser-0001-tenant/pipelineruns": 2,
+		"objects/testuser-0002-tenant/pipelineruns": 1,
+	}
+	if len(samples) != len(expected) {
+		t.Errorf("Expected %d samples, got %+v", len(expected), samples)
+	}
+	for _, sample := range samples {
+		key := sample.Kind + "/" + sample.Namespace + "/" + sample.Name
+		if value, ok := expected[key]; !ok || value != sample.Value {
+			t.Errorf("Unexpected sample %s = %v", key, sample.Value)
+		}
+	}
+
+	// Objects are watched after the first sample, not listed again
+	if _, errs := s.Sample(context.Background()); len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	lists := 0
+	for _, action := range metadataClient.Actions() {
+		if action.GetVerb() == "list" && action.GetResource().Resource == "pipelineruns" {
+			lists++
+		}
+	}
+	if lists != 1 {
+		t.Errorf("Expected pipelineruns listed once, listed %d times", lists)
+	}
+
+	if err := s.write(samples); err != nil {
+		t.Fatalf("Failed to write samples: %v", err)
+	}
+	data, _ := os.ReadFile(output)
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 4 || !strings.Contains(lines[0], ",pod-cpu,build-service,controller-1,0.3") {
+		t.Errorf("Unexpected CSV content: %s", data)
+	}
+}
+
+// Test workqueue depths are read by queue name.
+func Test_ParseWorkqueueDepths(t *testing.T) {
+	data := []byte(`# HELP workqueue_depth Current depth of workqueue
+# TYPE workqueue_depth gauge
+workqueue_depth{name="component"} 3
+workqueue_depth{name="pipelinerun"} 12
+# TYPE tekton_pipelines_controller_workqueue_depth gauge
+tekton_pipelines_controller_workqueue_depth 7
+`)
+	depths, err := parseWorkqueueDepths(data, "workqueue_depth")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(depths) != 2 || depths["component"] != 3 || depths["pipelinerun"] != 12 {
+		t.Errorf("Unexpected depths: %v", depths)
+	}
+
+	depths, _ = parseWorkqueueDepths(data, "tekton_pipelines_controller_workqueue_depth")
+	if depths["tekton_pipelines_controller_workqueue_depth"] != 7 {
+		t.Errorf("Unexpected depths for metric without name label: %v", depths)
+	}
+}
@@ -1,5 +1,6 @@
 package types
 
+import "context"
 import "sync"
 import "time"
 
@@ -10,6 +11,7 @@ import options "github.com/konflux-ci/e2e-tests/tests/load-tests/pkg/options"
 // Struct to hold user journey thread data
 type PerUserContext struct {
 	PerUserWG              *sync.WaitGroup
+	Context                context.Context // cancelled when journeys are stopped, waits for resources end with it
 	UserIndex              int
 	StartupPause           time.Duration
 	JourneyRepeatsCounter  int
@@ -39,12 +41,15 @@ type PerApplicationContext struct {
 
 // Struct to hold data for thread to process each component
 type PerComponentContext struct {
-	PerComponentWG *sync.WaitGroup
-	ComponentIndex int
-	StartupPause   time.Duration
-	Framework      *framework.Framework
-	ParentContext  *PerApplicationContext
-	ComponentName  string
-	SnapshotName   string
-	ReleaseName    string
+	PerComponentWG  *sync.WaitGroup
+	ComponentIndex  int
+	StartupPause    time.Duration
+	Framework       *framework.Framework
+	ParentContext   *PerApplicationContext
+	ComponentName   string
+	SnapshotName    string
+	ReleaseName     string
+	CommitSHA       string          // revision pushed by HandleComponentCommit, its build is waited for next
+	PipelineRunName string          // build PipelineRun of the latest build
+	ObservedUIDs    map[string]bool // PipelineRuns and Snapshots of earlier builds, never matched again
 }